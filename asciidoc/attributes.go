@@ -24,8 +24,8 @@ func (al AttributeList) Equals(oal AttributeList) bool {
 	return true
 }
 
-func (al AttributeList) SetAttribute(name AttributeName, value Set) {
-	for _, a := range al {
+func (al *AttributeList) SetAttribute(name AttributeName, value Set) {
+	for _, a := range *al {
 		switch a := a.(type) {
 		case *NamedAttribute:
 			if a.Name == name {
@@ -46,7 +46,7 @@ func (al *AttributeList) DeleteAttribute(name AttributeName) {
 		}
 	}
 	if index >= 0 {
-		*al = slices.Delete(*al, index, index)
+		*al = slices.Delete(*al, index, index+1)
 	}
 }
 
@@ -0,0 +1,75 @@
+package html
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func describeConstraint(c constraint.Constraint, dataType *types.DataType) string {
+	if c == nil {
+		return ""
+	}
+	var length bool
+	if dataType != nil {
+		length = dataType.HasLength()
+	}
+	switch c := c.(type) {
+	case *constraint.AllConstraint:
+		return "any value"
+	case *constraint.DescribedConstraint:
+		return "see description"
+	case *constraint.ExactConstraint:
+		if length {
+			return fmt.Sprintf("length of exactly %s", c.Value.ASCIIDocString(dataType))
+		}
+		return fmt.Sprintf("exactly %s", c.Value.ASCIIDocString(dataType))
+	case *constraint.GenericConstraint:
+		return ""
+	case *constraint.MinConstraint:
+		if length {
+			return fmt.Sprintf("length of at least %s", c.Minimum.ASCIIDocString(dataType))
+		}
+		return fmt.Sprintf("at least %s", c.Minimum.ASCIIDocString(dataType))
+	case *constraint.MaxConstraint:
+		if length {
+			return fmt.Sprintf("length of at most %s", c.Maximum.ASCIIDocString(dataType))
+		}
+		return fmt.Sprintf("at most %s", c.Maximum.ASCIIDocString(dataType))
+	case *constraint.RangeConstraint:
+		if length {
+			return fmt.Sprintf("length between %s and %s", c.Minimum.ASCIIDocString(dataType), c.Maximum.ASCIIDocString(dataType))
+		}
+		return fmt.Sprintf("between %s and %s", c.Minimum.ASCIIDocString(dataType), c.Maximum.ASCIIDocString(dataType))
+	case *constraint.ListConstraint:
+		var entryType *types.DataType
+		if dataType != nil {
+			entryType = dataType.EntryType
+		}
+		list := describeConstraint(c.Constraint, nil)
+		entry := describeConstraint(c.EntryConstraint, entryType)
+		switch {
+		case list == "" && entry == "":
+			return ""
+		case entry == "":
+			return fmt.Sprintf("list with %s entries", list)
+		case list == "":
+			return fmt.Sprintf("each entry %s", entry)
+		default:
+			return fmt.Sprintf("list with %s entries, each entry %s", list, entry)
+		}
+	case constraint.Set:
+		var descriptions []string
+		for _, cc := range c {
+			d := describeConstraint(cc, dataType)
+			if d != "" {
+				descriptions = append(descriptions, d)
+			}
+		}
+		return strings.Join(descriptions, ", or ")
+	default:
+		return ""
+	}
+}
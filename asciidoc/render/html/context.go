@@ -0,0 +1,149 @@
+package html
+
+import (
+	"context"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+type listState struct {
+	tag    string
+	marker string
+}
+
+type renderContext struct {
+	context.Context
+
+	renderer *Renderer
+	doc      *spec.Doc
+
+	out strings.Builder
+
+	paragraphClose string
+	pendingNewLine bool
+	lists          []listState
+	continuation   bool
+	admonition     asciidoc.AdmonitionType
+
+	ids      map[asciidoc.Element]string
+	counters map[string]int
+
+	preformatted bool
+	footnote     *footnote
+	footnotes    []*footnote
+}
+
+func newContext(cxt context.Context, renderer *Renderer, doc *spec.Doc) *renderContext {
+	rc := &renderContext{
+		Context:  cxt,
+		renderer: renderer,
+		doc:      doc,
+		ids:      make(map[asciidoc.Element]string),
+		counters: make(map[string]int),
+	}
+	anchors, err := doc.Anchors()
+	if err == nil {
+		for id, as := range anchors {
+			for _, a := range as {
+				rc.ids[a.Element] = id
+			}
+		}
+	}
+	return rc
+}
+
+func (rc *renderContext) String() string {
+	return rc.out.String()
+}
+
+func (rc *renderContext) write(s string) {
+	if rc.footnote != nil {
+		rc.footnote.text.WriteString(s)
+		return
+	}
+	rc.out.WriteString(s)
+}
+
+func (rc *renderContext) writeEscaped(s string) {
+	rc.write(escape(s))
+}
+
+func (rc *renderContext) openParagraph() {
+	if rc.paragraphClose != "" {
+		return
+	}
+	if rc.admonition != asciidoc.AdmonitionTypeNone {
+		renderAdmonitionStart(rc, rc.admonition)
+		rc.admonition = asciidoc.AdmonitionTypeNone
+		rc.write("<p>")
+		rc.paragraphClose = "</p></div>\n"
+		return
+	}
+	rc.write("<p>")
+	rc.paragraphClose = "</p>\n"
+}
+
+func (rc *renderContext) closeParagraph() {
+	if rc.paragraphClose == "" {
+		return
+	}
+	rc.write(rc.paragraphClose)
+	rc.paragraphClose = ""
+	rc.pendingNewLine = false
+}
+
+func (rc *renderContext) closeLists(depth int) {
+	for len(rc.lists) > depth {
+		l := rc.lists[len(rc.lists)-1]
+		rc.write(itemCloseTag(l.tag))
+		rc.write("</" + l.tag + ">\n")
+		rc.lists = rc.lists[:len(rc.lists)-1]
+	}
+}
+
+// closeBlocks closes any open paragraph and, unless we're inside a list continuation, any open lists
+func (rc *renderContext) closeBlocks() {
+	rc.closeParagraph()
+	if !rc.continuation {
+		rc.closeLists(0)
+	}
+}
+
+type savedState struct {
+	paragraphClose string
+	lists          []listState
+	continuation   bool
+	admonition     asciidoc.AdmonitionType
+}
+
+// nest resets block state so a nested container (table cell, delimited block) can be rendered independently
+func (rc *renderContext) nest() savedState {
+	s := savedState{
+		paragraphClose: rc.paragraphClose,
+		lists:          rc.lists,
+		continuation:   rc.continuation,
+		admonition:     rc.admonition,
+	}
+	rc.paragraphClose = ""
+	rc.lists = nil
+	rc.continuation = false
+	rc.admonition = asciidoc.AdmonitionTypeNone
+	return s
+}
+
+func (rc *renderContext) restore(s savedState) {
+	rc.continuation = false
+	rc.closeBlocks()
+	rc.paragraphClose = s.paragraphClose
+	rc.lists = s.lists
+	rc.continuation = s.continuation
+	rc.admonition = s.admonition
+}
+
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;", "'", "&#39;")
+
+func escape(s string) string {
+	return htmlEscaper.Replace(s)
+}
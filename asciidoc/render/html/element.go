@@ -0,0 +1,364 @@
+package html
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/internal/parse"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+func unwrap(e asciidoc.Element) asciidoc.Element {
+	if s, ok := e.(*spec.Section); ok {
+		return s.Base
+	}
+	if hb, ok := e.(parse.HasBase); ok {
+		return hb.GetBase()
+	}
+	return e
+}
+
+func renderBlocks(rc *renderContext, els asciidoc.Set) (err error) {
+	for _, e := range els {
+		e = unwrap(e)
+		switch el := e.(type) {
+		case asciidoc.EmptyLine, *asciidoc.EmptyLine:
+			rc.closeParagraph()
+		case *asciidoc.NewLine:
+			if rc.paragraphClose != "" {
+				rc.pendingNewLine = true
+			}
+		case *asciidoc.Section:
+			rc.closeBlocks()
+			err = renderSection(rc, el)
+		case *asciidoc.Paragraph:
+			rc.closeBlocks()
+			err = renderParagraph(rc, el)
+		case *asciidoc.Table:
+			rc.closeBlocks()
+			err = renderTable(rc, el)
+		case *asciidoc.UnorderedListItem:
+			rc.closeParagraph()
+			err = renderListItem(rc, "ul", el.Marker, el.Set)
+		case *asciidoc.OrderedListItem:
+			rc.closeParagraph()
+			err = renderListItem(rc, "ol", el.Marker, el.Set)
+		case *asciidoc.DescriptionListItem:
+			rc.closeParagraph()
+			err = renderDescriptionListItem(rc, el)
+		case *asciidoc.ListContinuation:
+			err = renderContinuation(rc, el.Child())
+		case *asciidoc.AttachedBlock:
+			err = renderContinuation(rc, el.Child())
+		case *asciidoc.BlockImage:
+			rc.closeBlocks()
+			err = renderBlockImage(rc, el)
+		case *asciidoc.Admonition:
+			rc.closeBlocks()
+			rc.admonition = el.AdmonitionType
+		case *asciidoc.Listing:
+			rc.closeBlocks()
+			err = renderLines(rc, "listingblock", el, el.Lines())
+		case *asciidoc.LiteralBlock:
+			rc.closeBlocks()
+			err = renderLines(rc, "literalblock", el, el.Lines())
+		case *asciidoc.StemBlock:
+			rc.closeBlocks()
+			err = renderLines(rc, "stemblock", el, el.Lines())
+		case *asciidoc.SourceBlock:
+			rc.closeBlocks()
+			err = renderPreformatted(rc, "listingblock", el, el.Set)
+		case *asciidoc.FencedBlock:
+			rc.closeBlocks()
+			err = renderPreformatted(rc, "listingblock", el, el.Set)
+		case *asciidoc.SidebarBlock:
+			rc.closeBlocks()
+			err = renderDelimitedBlock(rc, "div", "sidebarblock", el, el.Set)
+		case *asciidoc.ExampleBlock:
+			rc.closeBlocks()
+			err = renderDelimitedBlock(rc, "div", "exampleblock", el, el.Set)
+		case *asciidoc.OpenBlock:
+			rc.closeBlocks()
+			err = renderDelimitedBlock(rc, "div", "openblock", el, el.Set)
+		case *asciidoc.QuoteBlock:
+			rc.closeBlocks()
+			err = renderDelimitedBlock(rc, "blockquote", "quoteblock", el, el.Set)
+		case *asciidoc.ThematicBreak:
+			rc.closeBlocks()
+			rc.write("<hr>\n")
+		case *asciidoc.Anchor:
+			err = renderAnchor(rc, el)
+		case *asciidoc.PageBreak, *asciidoc.SingleLineComment, *asciidoc.MultiLineComment,
+			*asciidoc.AttributeEntry, *asciidoc.AttributeReset, *asciidoc.BlockAttributes, *asciidoc.FileInclude,
+			*asciidoc.IfDef, *asciidoc.IfNDef, *asciidoc.IfEval, *asciidoc.EndIf,
+			*asciidoc.IfDefBlock, *asciidoc.IfNDefBlock, *asciidoc.IfEvalBlock:
+		case nil:
+		default:
+			switch e.Type() {
+			case asciidoc.ElementTypeInline, asciidoc.ElementTypeInlineLiteral:
+				if s, ok := e.(*asciidoc.String); ok && rc.paragraphClose == "" && strings.TrimSpace(s.Value) == "" {
+					continue
+				}
+				if len(rc.lists) > 0 && !rc.continuation {
+					rc.closeLists(0)
+				}
+				rc.openParagraph()
+				if rc.pendingNewLine {
+					rc.write("\n")
+					rc.pendingNewLine = false
+				}
+				err = renderInline(rc, e)
+			default:
+				err = fmt.Errorf("unknown HTML render element type: %T", e)
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func renderInline(rc *renderContext, els ...asciidoc.Element) (err error) {
+	for _, e := range els {
+		e = unwrap(e)
+		switch el := e.(type) {
+		case *asciidoc.String:
+			rc.writeText(el.Value)
+		case asciidoc.ParagraphLine:
+			rc.writeText(el.Text)
+		case *asciidoc.NewLine:
+			rc.write("\n")
+		case asciidoc.EmptyLine, *asciidoc.EmptyLine:
+			rc.write("\n")
+		case asciidoc.SpecialCharacter:
+			rc.writeEscaped(el.Character)
+		case *asciidoc.SpecialCharacter:
+			rc.writeEscaped(el.Character)
+		case *asciidoc.Bold:
+			err = renderFormattedText(rc, "strong", el.Set)
+		case *asciidoc.DoubleBold:
+			err = renderFormattedText(rc, "strong", el.Set)
+		case *asciidoc.Italic:
+			err = renderFormattedText(rc, "em", el.Set)
+		case *asciidoc.DoubleItalic:
+			err = renderFormattedText(rc, "em", el.Set)
+		case *asciidoc.Monospace:
+			err = renderFormattedText(rc, "code", el.Set)
+		case *asciidoc.DoubleMonospace:
+			err = renderFormattedText(rc, "code", el.Set)
+		case *asciidoc.Superscript:
+			err = renderFormattedText(rc, "sup", el.Set)
+		case *asciidoc.Subscript:
+			err = renderFormattedText(rc, "sub", el.Set)
+		case *asciidoc.Marked:
+			err = renderFormattedText(rc, "mark", el.Set)
+		case *asciidoc.DoubleMarked:
+			err = renderFormattedText(rc, "mark", el.Set)
+		case *asciidoc.LineBreak, *asciidoc.LineContinuation:
+			rc.write("<br>\n")
+		case *asciidoc.CrossReference:
+			err = renderCrossReference(rc, el)
+		case *asciidoc.DocumentCrossReference:
+			err = renderDocumentCrossReference(rc, el)
+		case *asciidoc.Link:
+			err = renderLink(rc, el.URL, el.AttributeList)
+		case *asciidoc.LinkMacro:
+			err = renderLink(rc, el.URL, el.AttributeList)
+		case asciidoc.Email:
+			rc.write(fmt.Sprintf("<a href=\"mailto:%s\">", escape(el.Address)))
+			rc.writeEscaped(el.Address)
+			rc.write("</a>")
+		case *asciidoc.InlineImage:
+			err = renderInlineImage(rc, el)
+		case *asciidoc.Anchor:
+			err = renderAnchor(rc, el)
+		case *asciidoc.CharacterReplacementReference:
+			rc.writeEscaped(el.ReplacementValue())
+		case *asciidoc.UserAttributeReference:
+			rc.writeEscaped(fmt.Sprintf("{%s}", el.Name()))
+		case *asciidoc.InlinePassthrough:
+			err = renderInline(rc, el.Elements()...)
+		case *asciidoc.InlineDoublePassthrough:
+			err = renderInline(rc, el.Elements()...)
+		case *asciidoc.Icon:
+			rc.write("<span class=\"icon\">[")
+			rc.writeEscaped(el.Path)
+			rc.write("]</span>")
+		case *asciidoc.Counter:
+			renderCounter(rc, el)
+		case *asciidoc.InlineIfDef, *asciidoc.InlineIfNDef:
+		case nil:
+		default:
+			err = fmt.Errorf("unknown HTML inline element type: %T", e)
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func renderFormattedText(rc *renderContext, tag string, els asciidoc.Set) (err error) {
+	rc.write("<" + tag + ">")
+	err = renderInline(rc, els...)
+	rc.write("</" + tag + ">")
+	return
+}
+
+func renderAnchor(rc *renderContext, a *asciidoc.Anchor) (err error) {
+	rc.write(fmt.Sprintf("<a id=\"%s\"></a>", escape(a.ID)))
+	if len(a.Set) > 0 {
+		rc.openParagraph()
+		err = renderInline(rc, a.Set...)
+	}
+	return
+}
+
+func renderCounter(rc *renderContext, c *asciidoc.Counter) {
+	val, ok := rc.counters[c.Name]
+	if ok {
+		val++
+	} else {
+		val = 1
+		if c.InitialValue != "" {
+			if i, err := strconv.Atoi(c.InitialValue); err == nil {
+				val = i
+			}
+		}
+	}
+	rc.counters[c.Name] = val
+	if c.Display {
+		rc.write(strconv.Itoa(val))
+	}
+}
+
+func renderParagraph(rc *renderContext, p *asciidoc.Paragraph) (err error) {
+	if p.Admonition != asciidoc.AdmonitionTypeNone {
+		rc.admonition = p.Admonition
+	}
+	title := titleAttribute(p.AttributeList)
+	if title != nil {
+		rc.write("<div class=\"title\">")
+		err = renderInline(rc, title...)
+		if err != nil {
+			return
+		}
+		rc.write("</div>\n")
+	}
+	rc.openParagraph()
+	err = renderInline(rc, trimTrailingNewLines(p.Set)...)
+	rc.closeParagraph()
+	return
+}
+
+func trimTrailingNewLines(els asciidoc.Set) asciidoc.Set {
+	for len(els) > 0 {
+		if _, ok := els[len(els)-1].(*asciidoc.NewLine); !ok {
+			break
+		}
+		els = els[:len(els)-1]
+	}
+	return els
+}
+
+func renderAdmonitionStart(rc *renderContext, at asciidoc.AdmonitionType) {
+	name := admonitionName(at)
+	rc.write(fmt.Sprintf("<div class=\"admonitionblock %s\"><span class=\"admonition-label\">%s</span>", name, name))
+}
+
+func admonitionName(at asciidoc.AdmonitionType) string {
+	switch at {
+	case asciidoc.AdmonitionTypeNote:
+		return "note"
+	case asciidoc.AdmonitionTypeTip:
+		return "tip"
+	case asciidoc.AdmonitionTypeImportant:
+		return "important"
+	case asciidoc.AdmonitionTypeCaution:
+		return "caution"
+	case asciidoc.AdmonitionTypeWarning:
+		return "warning"
+	default:
+		return ""
+	}
+}
+
+func renderContinuation(rc *renderContext, child asciidoc.Element) (err error) {
+	rc.closeParagraph()
+	continuation := rc.continuation
+	rc.continuation = len(rc.lists) > 0
+	err = renderBlocks(rc, asciidoc.Set{child})
+	rc.closeParagraph()
+	rc.continuation = continuation
+	return
+}
+
+func renderLines(rc *renderContext, class string, el asciidoc.Attributable, lines []string) (err error) {
+	err = renderBlockTitle(rc, el)
+	if err != nil {
+		return
+	}
+	rc.write(fmt.Sprintf("<pre class=\"%s\">", class))
+	for i, l := range lines {
+		if i > 0 {
+			rc.write("\n")
+		}
+		rc.writeEscaped(l)
+	}
+	rc.write("</pre>\n")
+	return
+}
+
+func renderPreformatted(rc *renderContext, class string, el asciidoc.Attributable, els asciidoc.Set) (err error) {
+	err = renderBlockTitle(rc, el)
+	if err != nil {
+		return
+	}
+	rc.write(fmt.Sprintf("<pre class=\"%s\">", class))
+	rc.preformatted = true
+	err = renderInline(rc, els...)
+	rc.preformatted = false
+	rc.write("</pre>\n")
+	return
+}
+
+func renderDelimitedBlock(rc *renderContext, tag string, class string, el asciidoc.Attributable, els asciidoc.Set) (err error) {
+	err = renderBlockTitle(rc, el)
+	if err != nil {
+		return
+	}
+	rc.write(fmt.Sprintf("<%s class=\"%s\">\n", tag, class))
+	state := rc.nest()
+	err = renderBlocks(rc, els)
+	rc.restore(state)
+	rc.write(fmt.Sprintf("</%s>\n", tag))
+	return
+}
+
+func renderBlockTitle(rc *renderContext, el asciidoc.Attributable) (err error) {
+	title := titleAttribute(el.Attributes())
+	if title == nil {
+		return
+	}
+	rc.write("<div class=\"title\">")
+	err = renderInline(rc, title...)
+	rc.write("</div>\n")
+	return
+}
+
+func titleAttribute(attributes []asciidoc.Attribute) asciidoc.Set {
+	for _, a := range attributes {
+		switch a := a.(type) {
+		case *asciidoc.TitleAttribute:
+			return a.Val
+		case *asciidoc.NamedAttribute:
+			if a.Name == asciidoc.AttributeNameTitle {
+				return a.Val
+			}
+		}
+	}
+	return nil
+}
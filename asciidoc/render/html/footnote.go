@@ -0,0 +1,88 @@
+package html
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The parser leaves footnote macros as plain text, so they're picked out of the text as it's rendered; a footnote's
+// text can still contain formatting, since everything written until its closing bracket goes into the footnote
+var footnotePattern = regexp.MustCompile(`footnote:(\w*)\[`)
+
+type footnote struct {
+	id     string
+	number int
+	text   strings.Builder
+}
+
+// writeText writes the text of a paragraph, turning any footnote macros in it into footnote references
+func (rc *renderContext) writeText(s string) {
+	if rc.preformatted {
+		rc.writeEscaped(s)
+		return
+	}
+	for len(s) > 0 {
+		if rc.footnote != nil {
+			end := strings.IndexRune(s, ']')
+			if end < 0 {
+				rc.writeEscaped(s)
+				return
+			}
+			rc.writeEscaped(s[:end])
+			rc.endFootnote()
+			s = s[end+1:]
+			continue
+		}
+		match := footnotePattern.FindStringSubmatchIndex(s)
+		if match == nil {
+			rc.writeEscaped(s)
+			return
+		}
+		rc.writeEscaped(s[:match[0]])
+		rc.footnote = &footnote{id: s[match[2]:match[3]]}
+		s = s[match[1]:]
+	}
+}
+
+func (rc *renderContext) endFootnote() {
+	fn := rc.footnote
+	rc.footnote = nil
+	if fn.id != "" && fn.text.Len() == 0 {
+		// A reference to a footnote defined earlier
+		for _, f := range rc.footnotes {
+			if f.id == fn.id {
+				rc.write(footnoteReference(f.number, false))
+				return
+			}
+		}
+	}
+	fn.number = len(rc.footnotes) + 1
+	rc.footnotes = append(rc.footnotes, fn)
+	rc.write(footnoteReference(fn.number, true))
+}
+
+func footnoteReference(number int, first bool) string {
+	var id string
+	if first {
+		id = fmt.Sprintf(" id=\"_footnoteref_%d\"", number)
+	}
+	return fmt.Sprintf("<sup class=\"footnote\">[<a%s href=\"#_footnotedef_%d\">%d</a>]</sup>", id, number, number)
+}
+
+func renderFootnotes(rc *renderContext) {
+	if rc.footnote != nil {
+		// An unclosed footnote runs to the end of the document
+		rc.endFootnote()
+	}
+	if len(rc.footnotes) == 0 {
+		return
+	}
+	rc.write("<div class=\"footnotes\">\n<hr>\n")
+	for _, fn := range rc.footnotes {
+		rc.write(fmt.Sprintf("<div class=\"footnote\" id=\"_footnotedef_%d\"><a href=\"#_footnoteref_%d\">%d</a>. ", fn.number, fn.number, fn.number))
+		rc.write(fn.text.String())
+		rc.write("</div>\n")
+	}
+	rc.write("</div>\n")
+}
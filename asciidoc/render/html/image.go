@@ -0,0 +1,77 @@
+package html
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+)
+
+func renderBlockImage(rc *renderContext, image *asciidoc.BlockImage) (err error) {
+	rc.write("<div class=\"imageblock\"")
+	id := elementID(rc, image)
+	if id != "" {
+		rc.write(fmt.Sprintf(" id=\"%s\"", escape(id)))
+	}
+	rc.write(">")
+	renderImage(rc, image.Path, image.AttributeList)
+	title := titleAttribute(image.Attributes())
+	if title != nil {
+		rc.write("<div class=\"title\">")
+		err = renderInline(rc, title...)
+		rc.write("</div>")
+	}
+	rc.write("</div>\n")
+	return
+}
+
+func renderInlineImage(rc *renderContext, image *asciidoc.InlineImage) (err error) {
+	renderImage(rc, image.Path, image.AttributeList)
+	return
+}
+
+func renderImage(rc *renderContext, path asciidoc.Set, attributes asciidoc.AttributeList) {
+	src := imageSource(rc, asciidoc.AttributeAsciiDocString(path))
+	rc.write(fmt.Sprintf("<img src=\"%s\"", escape(src)))
+	alt := imageAlternateText(attributes)
+	if alt != "" {
+		rc.write(fmt.Sprintf(" alt=\"%s\"", escape(alt)))
+	}
+	for _, name := range []asciidoc.AttributeName{asciidoc.AttributeNameWidth, asciidoc.AttributeNameHeight} {
+		a := attributes.GetAttributeByName(name)
+		if a != nil {
+			rc.write(fmt.Sprintf(" %s=\"%s\"", name, escape(asciidoc.AttributeAsciiDocString(a.Val))))
+		}
+	}
+	rc.write(">")
+}
+
+// imageSource resolves an image path relative to the source document into a path relative to the rendered HTML file
+func imageSource(rc *renderContext, path string) string {
+	if strings.Contains(path, "://") || filepath.IsAbs(path) {
+		return path
+	}
+	source := filepath.Join(filepath.Dir(rc.doc.Path.Absolute), path)
+	rel, err := filepath.Rel(filepath.Dir(rc.renderer.OutputPath(rc.doc)), source)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+func imageAlternateText(attributes asciidoc.AttributeList) string {
+	for _, a := range attributes {
+		switch a := a.(type) {
+		case *asciidoc.NamedAttribute:
+			if a.Name == asciidoc.AttributeNameAlternateText {
+				return asciidoc.AttributeAsciiDocString(a.Val)
+			}
+		case *asciidoc.PositionalAttribute:
+			if a.Offset == 0 {
+				return asciidoc.AttributeAsciiDocString(a.Val)
+			}
+		}
+	}
+	return ""
+}
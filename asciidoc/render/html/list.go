@@ -0,0 +1,45 @@
+package html
+
+import (
+	"github.com/project-chip/alchemy/asciidoc"
+)
+
+func renderListItem(rc *renderContext, tag string, marker string, els asciidoc.Set) (err error) {
+	openList(rc, tag, marker)
+	rc.write("<li>")
+	err = renderInline(rc, trimTrailingNewLines(els)...)
+	return
+}
+
+func renderDescriptionListItem(rc *renderContext, dli *asciidoc.DescriptionListItem) (err error) {
+	openList(rc, "dl", dli.Marker)
+	rc.write("<dt>")
+	err = renderInline(rc, dli.Term...)
+	if err != nil {
+		return
+	}
+	rc.write("</dt>\n<dd>")
+	err = renderInline(rc, trimTrailingNewLines(dli.Set)...)
+	return
+}
+
+// openList either continues an open list with the same marker (closing any lists nested within it), or starts a new nested list
+func openList(rc *renderContext, tag string, marker string) {
+	for i := len(rc.lists) - 1; i >= 0; i-- {
+		l := rc.lists[i]
+		if l.tag == tag && l.marker == marker {
+			rc.closeLists(i + 1)
+			rc.write(itemCloseTag(tag))
+			return
+		}
+	}
+	rc.lists = append(rc.lists, listState{tag: tag, marker: marker})
+	rc.write("<" + tag + ">\n")
+}
+
+func itemCloseTag(tag string) string {
+	if tag == "dl" {
+		return "</dd>\n"
+	}
+	return "</li>\n"
+}
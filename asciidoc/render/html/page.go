@@ -0,0 +1,36 @@
+package html
+
+const stylesheet = `body { font-family: sans-serif; max-width: 60em; margin: 2em auto; padding: 0 1em; line-height: 1.4; }
+table.tableblock { border-collapse: collapse; margin: 1em 0; }
+table.tableblock th, table.tableblock td { border: 1px solid #bbb; padding: 0.25em 0.5em; vertical-align: top; }
+table.tableblock th { background: #eee; }
+table.tableblock p { margin: 0; }
+caption, div.title { font-style: italic; text-align: left; margin: 0.5em 0; }
+.halign-center { text-align: center; }
+.halign-right { text-align: right; }
+.valign-middle { vertical-align: middle; }
+.valign-bottom { vertical-align: bottom; }
+.style-strong { font-weight: bold; }
+.style-emphasis { font-style: italic; }
+.style-monospace, .style-literal { font-family: monospace; }
+.admonitionblock { border-left: 4px solid #888; padding: 0.25em 1em; margin: 1em 0; background: #f6f6f6; }
+.admonitionblock.warning, .admonitionblock.caution { border-color: #c33; }
+.admonition-label { font-weight: bold; text-transform: uppercase; margin-right: 0.5em; }
+.annotation { font-size: 0.8em; color: #555; margin-top: 0.25em; }
+pre { background: #f4f4f4; padding: 0.5em; overflow-x: auto; }
+a.unresolved { color: #c33; }
+.sidebarblock, .exampleblock { border: 1px solid #ddd; padding: 0.5em 1em; margin: 1em 0; }
+.footnotes { font-size: 0.9em; margin-top: 2em; }
+`
+
+func renderHeader(rc *renderContext, title string) {
+	rc.write("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>")
+	rc.writeEscaped(title)
+	rc.write("</title>\n<style>\n")
+	rc.write(stylesheet)
+	rc.write("</style>\n</head>\n<body>\n")
+}
+
+func renderFooter(rc *renderContext) {
+	rc.write("</body>\n</html>\n")
+}
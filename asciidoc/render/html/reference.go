@@ -0,0 +1,81 @@
+package html
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+func renderCrossReference(rc *renderContext, xref *asciidoc.CrossReference) (err error) {
+	anchor := rc.doc.FindAnchor(xref.ID)
+	if anchor == nil {
+		rc.write(fmt.Sprintf("<a class=\"xref unresolved\" href=\"#%s\">", escape(xref.ID)))
+		if len(xref.Set) > 0 {
+			err = renderLabel(rc, xref.Set)
+		} else {
+			rc.writeEscaped(xref.ID)
+		}
+		rc.write("</a>")
+		return
+	}
+	href := rc.renderer.linkPath(rc.doc, anchor.Document) + "#" + anchor.ID
+	rc.write(fmt.Sprintf("<a class=\"xref\" href=\"%s\">", escape(href)))
+	switch {
+	case len(xref.Set) > 0:
+		err = renderLabel(rc, xref.Set)
+	case len(anchor.LabelElements) > 0:
+		err = renderLabel(rc, anchor.LabelElements)
+	default:
+		name := spec.ReferenceName(anchor.Element)
+		if name == "" {
+			name = anchor.ID
+		}
+		rc.writeEscaped(name)
+	}
+	rc.write("</a>")
+	return
+}
+
+func renderDocumentCrossReference(rc *renderContext, xref *asciidoc.DocumentCrossReference) (err error) {
+	path := asciidoc.AttributeAsciiDocString(xref.Path)
+	target := path
+	if ext := filepath.Ext(path); ext == ".adoc" {
+		target = strings.TrimSuffix(path, ext) + ".html"
+	}
+	rc.write(fmt.Sprintf("<a class=\"xref\" href=\"%s\">", escape(target)))
+	rc.writeEscaped(path)
+	rc.write("</a>")
+	return
+}
+
+func renderLink(rc *renderContext, url asciidoc.URL, attributes asciidoc.AttributeList) (err error) {
+	href := url.Scheme + asciidoc.AttributeAsciiDocString(url.Path)
+	rc.write(fmt.Sprintf("<a href=\"%s\">", escape(href)))
+	var label asciidoc.Set
+	for _, a := range attributes {
+		if pa, ok := a.(*asciidoc.PositionalAttribute); ok {
+			label = pa.Val
+			break
+		}
+	}
+	if len(label) > 0 {
+		err = renderInline(rc, label...)
+	} else {
+		rc.writeEscaped(href)
+	}
+	rc.write("</a>")
+	return
+}
+
+// renderLabel renders the label of a cross reference, dropping the whitespace that follows the comma in <<id, label>>
+func renderLabel(rc *renderContext, label asciidoc.Set) error {
+	if len(label) > 0 {
+		if s, ok := label[0].(*asciidoc.String); ok {
+			label = append(asciidoc.Set{asciidoc.NewString(strings.TrimLeft(s.Value, " "))}, label[1:]...)
+		}
+	}
+	return renderInline(rc, label...)
+}
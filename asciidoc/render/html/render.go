@@ -0,0 +1,94 @@
+package html
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-chip/alchemy/internal/parse"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+type Option func(r *Renderer)
+
+type Renderer struct {
+	outputRoot     string
+	annotateTables bool
+}
+
+func NewRenderer(options ...Option) *Renderer {
+	r := &Renderer{}
+	for _, o := range options {
+		o(r)
+	}
+	return r
+}
+
+// OutputRoot sets the directory HTML files are written to; if empty, each file is written alongside its source document
+func OutputRoot(root string) Option {
+	return func(r *Renderer) {
+		r.outputRoot = root
+	}
+}
+
+// AnnotateTables adds English descriptions beneath conformance and constraint cells
+func AnnotateTables(annotate bool) Option {
+	return func(r *Renderer) {
+		r.annotateTables = annotate
+	}
+}
+
+func (p *Renderer) Name() string {
+	return "Rendering HTML"
+}
+
+func (p *Renderer) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeIndividual
+}
+
+func (p *Renderer) Process(cxt context.Context, input *pipeline.Data[*spec.Doc], index int32, total int32) (outputs []*pipeline.Data[string], extra []*pipeline.Data[*spec.Doc], err error) {
+	doc := input.Content
+	rc := newContext(cxt, p, doc)
+
+	title := doc.Path.Base()
+	top := parse.FindFirst[*spec.Section](doc.Elements())
+	if top != nil {
+		title = top.Name
+	}
+
+	renderHeader(rc, title)
+	err = renderBlocks(rc, doc.Elements())
+	if err != nil {
+		return
+	}
+	rc.closeBlocks()
+	renderFootnotes(rc)
+	renderFooter(rc)
+	outputs = append(outputs, pipeline.NewData(p.OutputPath(doc), rc.String()))
+	return
+}
+
+// OutputPath returns the path of the HTML file rendered for the given document
+func (p *Renderer) OutputPath(doc *spec.Doc) string {
+	var path string
+	if p.outputRoot == "" {
+		path = doc.Path.Absolute
+	} else {
+		path = filepath.Join(p.outputRoot, doc.Path.Relative)
+	}
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".html"
+}
+
+func (p *Renderer) linkPath(from *spec.Doc, to *spec.Doc) string {
+	if from == to {
+		return ""
+	}
+	fromPath := p.OutputPath(from)
+	toPath := p.OutputPath(to)
+	rel, err := filepath.Rel(filepath.Dir(fromPath), toPath)
+	if err != nil {
+		return filepath.ToSlash(toPath)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,31 @@
+package html
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+func TestRenderFootnotes(t *testing.T) {
+	doc, err := spec.ReadFile("testdata/footnotes.adoc", "testdata")
+	if err != nil {
+		t.Fatalf("failed reading doc: %v", err)
+	}
+	outputs, _, err := NewRenderer().Process(context.Background(), pipeline.NewData("testdata/footnotes.adoc", doc), 0, 1)
+	if err != nil {
+		t.Fatalf("failed rendering doc: %v", err)
+	}
+	if len(outputs) != 1 {
+		t.Fatalf("expected 1 output, got %d", len(outputs))
+	}
+	expected, err := os.ReadFile("testdata/footnotes.html")
+	if err != nil {
+		t.Fatalf("failed reading expected HTML: %v", err)
+	}
+	if outputs[0].Content != string(expected) {
+		t.Errorf("unexpected HTML:\n%s", outputs[0].Content)
+	}
+}
@@ -0,0 +1,71 @@
+package html
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/project-chip/alchemy/asciidoc"
+)
+
+func renderSection(rc *renderContext, s *asciidoc.Section) (err error) {
+	level := s.Level + 1
+	if level > 6 {
+		level = 6
+	}
+	id := elementID(rc, s)
+	if id == "" {
+		id = generateSectionID(s.Name())
+	}
+	rc.write(fmt.Sprintf("<h%d id=\"%s\">", level, escape(id)))
+	err = renderInline(rc, s.Title...)
+	if err != nil {
+		return
+	}
+	rc.write(fmt.Sprintf("</h%d>\n", level))
+	err = renderBlocks(rc, s.Elements())
+	rc.closeBlocks()
+	return
+}
+
+// elementID returns the anchor ID of an element, either from the document's anchor index or from its own attributes
+func elementID(rc *renderContext, el asciidoc.Element) string {
+	if id, ok := rc.ids[el]; ok {
+		return id
+	}
+	attributable, ok := el.(asciidoc.Attributable)
+	if !ok {
+		return ""
+	}
+	for _, a := range attributable.Attributes() {
+		switch a := a.(type) {
+		case *asciidoc.AnchorAttribute:
+			if a.ID != nil {
+				return a.ID.Value
+			}
+		case *asciidoc.ShorthandAttribute:
+			if a.ID != nil {
+				return asciidoc.AttributeAsciiDocString(a.ID.Set)
+			}
+		case *asciidoc.NamedAttribute:
+			if a.Name == asciidoc.AttributeNameID {
+				return asciidoc.AttributeAsciiDocString(a.Val)
+			}
+		}
+	}
+	return ""
+}
+
+func generateSectionID(name string) string {
+	var sb strings.Builder
+	sb.WriteRune('_')
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('_')
+		}
+	}
+	return sb.String()
+}
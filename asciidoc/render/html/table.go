@@ -0,0 +1,147 @@
+package html
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+func renderTable(rc *renderContext, table *asciidoc.Table) (err error) {
+	rows := table.TableRows()
+	headerRowIndex := -1
+	ti, tableErr := spec.ReadTable(rc.doc, table)
+	if tableErr == nil && ti.ColumnMap != nil {
+		headerRowIndex = ti.HeaderRowIndex
+	} else {
+		ti = nil
+		if hasHeaderOption(table) {
+			headerRowIndex = 0
+		}
+	}
+
+	rc.write("<table class=\"tableblock\"")
+	id := elementID(rc, table)
+	if id != "" {
+		rc.write(fmt.Sprintf(" id=\"%s\"", escape(id)))
+	}
+	rc.write(">\n")
+	title := titleAttribute(table.Attributes())
+	if title != nil {
+		rc.write("<caption>")
+		err = renderInline(rc, title...)
+		if err != nil {
+			return
+		}
+		rc.write("</caption>\n")
+	}
+	if headerRowIndex >= 0 {
+		rc.write("<thead>\n")
+	} else {
+		rc.write("<tbody>\n")
+	}
+	for i, row := range rows {
+		if i == headerRowIndex+1 && headerRowIndex >= 0 {
+			rc.write("</thead>\n<tbody>\n")
+		}
+		err = renderTableRow(rc, ti, row, i <= headerRowIndex)
+		if err != nil {
+			return
+		}
+	}
+	rc.write("</tbody>\n</table>\n")
+	return
+}
+
+func renderTableRow(rc *renderContext, ti *spec.TableInfo, row *asciidoc.TableRow, header bool) (err error) {
+	rc.write("<tr>")
+	for i, cell := range row.TableCells() {
+		if cell.Blank {
+			continue
+		}
+		tag := "td"
+		if header || (cell.Format != nil && cell.Format.Style.Value == asciidoc.TableCellStyleHeader) {
+			tag = "th"
+		}
+		rc.write("<" + tag + cellAttributes(cell) + ">")
+		state := rc.nest()
+		err = renderBlocks(rc, cell.Elements())
+		rc.restore(state)
+		if err != nil {
+			return
+		}
+		if !header && ti != nil && rc.renderer.annotateTables {
+			renderAnnotation(rc, ti, row, i)
+		}
+		rc.write("</" + tag + ">")
+	}
+	rc.write("</tr>\n")
+	return
+}
+
+func cellAttributes(cell *asciidoc.TableCell) string {
+	format := cell.Format
+	if format == nil {
+		return ""
+	}
+	var sb strings.Builder
+	if format.Span.Column.IsSet && format.Span.Column.Value > 1 {
+		sb.WriteString(fmt.Sprintf(" colspan=\"%d\"", format.Span.Column.Value))
+	}
+	if format.Span.Row.IsSet && format.Span.Row.Value > 1 {
+		sb.WriteString(fmt.Sprintf(" rowspan=\"%d\"", format.Span.Row.Value))
+	}
+	var classes []string
+	if format.HorizontalAlign.IsSet {
+		classes = append(classes, "halign-"+format.HorizontalAlign.Value.String())
+	}
+	if format.VerticalAlign.IsSet {
+		classes = append(classes, "valign-"+format.VerticalAlign.Value.String())
+	}
+	if format.Style.IsSet {
+		switch format.Style.Value {
+		case asciidoc.TableCellStyleEmphasis, asciidoc.TableCellStyleLiteral, asciidoc.TableCellStyleMonospace, asciidoc.TableCellStyleStrong:
+			classes = append(classes, "style-"+format.Style.Value.String())
+		}
+	}
+	if len(classes) > 0 {
+		sb.WriteString(fmt.Sprintf(" class=\"%s\"", strings.Join(classes, " ")))
+	}
+	return sb.String()
+}
+
+func hasHeaderOption(table *asciidoc.Table) bool {
+	options := table.GetAttributeByName(asciidoc.AttributeName("options"))
+	if options == nil {
+		return false
+	}
+	return strings.Contains(asciidoc.AttributeAsciiDocString(options.Val), "header")
+}
+
+func renderAnnotation(rc *renderContext, ti *spec.TableInfo, row *asciidoc.TableRow, column int) {
+	if index, ok := ti.ColumnMap[matter.TableColumnConformance]; ok && index == column {
+		conf := ti.ReadConformance(row, matter.TableColumnConformance)
+		if conf != nil {
+			rc.write("<div class=\"annotation conformance\">")
+			rc.writeEscaped(conf.Description())
+			rc.write("</div>")
+		}
+	}
+	if index, ok := ti.ColumnMap[matter.TableColumnConstraint]; ok && index == column {
+		c := ti.ReadConstraint(row, matter.TableColumnConstraint)
+		var description string
+		if _, ok := ti.ColumnMap[matter.TableColumnType]; ok {
+			dataType, _ := ti.ReadDataType(row, matter.TableColumnType)
+			description = describeConstraint(c, dataType)
+		} else {
+			description = describeConstraint(c, nil)
+		}
+		if description != "" {
+			rc.write("<div class=\"annotation constraint\">")
+			rc.writeEscaped(description)
+			rc.write("</div>")
+		}
+	}
+}
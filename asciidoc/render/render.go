@@ -2,6 +2,7 @@ package render
 
 import (
 	"context"
+	"math"
 	"regexp"
 	"strings"
 
@@ -11,7 +12,9 @@ import (
 type Option func(r *Renderer)
 
 type Renderer struct {
-	wordWrapLength int
+	wordWrapLength     int
+	semanticLineBreaks bool
+	unwrapParagraphs   bool
 }
 
 func NewRenderer(options ...Option) *Renderer {
@@ -33,9 +36,14 @@ func (p Renderer) Type() pipeline.ProcessorType {
 func (p Renderer) Process(cxt context.Context, input *pipeline.Data[InputDocument], index int32, total int32) (outputs []*pipeline.Data[string], extra []*pipeline.Data[InputDocument], err error) {
 	doc := input.Content
 	var renderContext Target
-	if p.wordWrapLength > 0 {
+	switch {
+	case p.semanticLineBreaks:
+		renderContext = NewSentenceWrappedTarget(cxt, p.wordWrapLength)
+	case p.unwrapParagraphs:
+		renderContext = NewWrappedTarget(cxt, math.MaxInt)
+	case p.wordWrapLength > 0:
 		renderContext = NewWrappedTarget(cxt, p.wordWrapLength)
-	} else {
+	default:
 		renderContext = NewUnwrappedTarget(cxt)
 	}
 
@@ -2,7 +2,10 @@ package render
 
 import (
 	"context"
+	"math"
+	"regexp"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 	"unsafe"
 )
@@ -13,6 +16,20 @@ func Wrap(lineLength int) Option {
 	}
 }
 
+// SemanticLineBreaks puts each sentence of a paragraph on its own line; combined with Wrap, long sentences are also wrapped
+func SemanticLineBreaks(on bool) Option {
+	return func(r *Renderer) {
+		r.semanticLineBreaks = on
+	}
+}
+
+// UnwrapParagraphs joins the lines of each paragraph into a single line
+func UnwrapParagraphs(on bool) Option {
+	return func(r *Renderer) {
+		r.unwrapParagraphs = on
+	}
+}
+
 type wrappedTarget struct {
 	context.Context
 
@@ -25,12 +42,16 @@ type wrappedTarget struct {
 	lastRemovedNewline  int
 	lastSpace           int
 	indented            bool
+	overflow            bool
 
 	blocks        []*strings.Builder
 	currentBlock  *strings.Builder
 	lastBlockRune rune
 
 	wrapLength int
+
+	sentences     bool
+	sentenceBreak int
 }
 
 func NewWrappedTarget(parent context.Context, wrapLength int) Target {
@@ -41,7 +62,18 @@ func NewWrappedTarget(parent context.Context, wrapLength int) Target {
 		lastInsertedNewline: -1,
 		lastRemovedNewline:  -1,
 		lastNewline:         -1,
+		sentenceBreak:       -1,
+	}
+}
+
+// NewSentenceWrappedTarget returns a target that breaks lines after the end of each sentence; if wrapLength is 0, lines are otherwise unwrapped
+func NewSentenceWrappedTarget(parent context.Context, wrapLength int) Target {
+	if wrapLength <= 0 {
+		wrapLength = math.MaxInt
 	}
+	t := NewWrappedTarget(parent, wrapLength).(*wrappedTarget)
+	t.sentences = true
+	return t
 }
 
 func (o *wrappedTarget) WriteString(s string) {
@@ -77,6 +109,9 @@ func (o *wrappedTarget) writeRune(r rune) {
 	case '\r': // We just strip these out as we go
 		return
 	case '\n':
+		o.completeWord()
+		o.sentenceBreak = -1
+		o.overflow = false
 		if o.lastNewline == index-1 { // Double new line; don't remove
 			o.lastNewline = index
 			o.lastSpace = -1
@@ -94,6 +129,7 @@ func (o *wrappedTarget) writeRune(r rune) {
 				r = ' '
 				o.lastSpace = index
 				o.lastRemovedNewline = index
+				o.markSentenceBreak(index)
 			} else {
 				o.lastNewline = index
 				o.lastSpace = -1
@@ -102,19 +138,44 @@ func (o *wrappedTarget) writeRune(r rune) {
 		}
 	default:
 		if isWhitespace(r) {
+			o.completeWord()
 			if o.lastRemovedNewline != -1 && o.lastRemovedNewline == index-1 {
 				o.indented = true
 				o.out[o.lastRemovedNewline] = '\n'
 				o.lastNewline = o.lastRemovedNewline
 				o.lastRemovedNewline = -1
+				o.sentenceBreak = -1
 			}
 			o.lastSpace = index
+			o.markSentenceBreak(index)
 		}
 	}
 
 	o.out = utf8.AppendRune(o.out, r)
 	o.lastRune = r
-	if o.indented {
+	if !o.indented && len(o.out)-o.lastNewline > o.wrapLength { // We're outside our wrap length; wrap once we know what would start the next line
+		o.overflow = true
+	}
+}
+
+// completeWord decides whether to break the line before the word just written, now that the whole word is known
+func (o *wrappedTarget) completeWord() {
+	start := max(o.lastSpace, o.lastNewline) + 1
+	if start >= len(o.out) {
+		return
+	}
+	if o.sentenceBreak >= 0 {
+		if o.sentenceBreak == start-1 {
+			o.breakSentence(string(o.out[start:]))
+		}
+		o.sentenceBreak = -1
+	}
+	o.wrapLine("")
+}
+
+// wrapLine replaces the last space with a new line if we're outside our wrap length, as long as the text after it can safely start a line
+func (o *wrappedTarget) wrapLine(next string) {
+	if !o.overflow || o.indented {
 		return
 	}
 	if len(o.out)-o.lastNewline <= o.wrapLength { // We're within our wrap length
@@ -123,6 +184,10 @@ func (o *wrappedTarget) writeRune(r rune) {
 	if o.lastSpace == -1 || o.lastSpace < o.lastNewline { // We're outside our wrap length, but there's no space to split on
 		return
 	}
+	if o.lastSpace != o.lastRemovedNewline && !safeLineStart(string(o.out[o.lastSpace+1:])+next) { // Restoring a removed new line is always safe
+		return
+	}
+	o.overflow = false
 	o.out[o.lastSpace] = '\n'
 	if o.lastSpace == len(o.out)-1 {
 		o.lastRune = '\n'
@@ -130,11 +195,14 @@ func (o *wrappedTarget) writeRune(r rune) {
 	o.lastNewline = o.lastSpace
 	o.lastInsertedNewline = o.lastSpace
 	o.lastSpace = -1
-
 }
 
 func (o *wrappedTarget) writeBlockText(s string) {
-	if o.lastNewline != -1 && (len(o.out)-o.lastNewline)+len(s) > o.wrapLength && o.lastNewline != len(o.out)-1 {
+	if len(s) > 0 && o.sentenceBreak >= 0 && o.sentenceBreak == len(o.out)-1 {
+		o.breakSentence(s)
+	}
+	o.wrapLine(s)
+	if o.lastNewline != -1 && (len(o.out)-o.lastNewline)+len(s) > o.wrapLength && o.lastNewline != len(o.out)-1 && safeLineStart(s) {
 		// We're outside our wrap length, but we can't split the block, so prepend a newline
 		o.insertNewLine()
 	}
@@ -143,6 +211,41 @@ func (o *wrappedTarget) writeBlockText(s string) {
 	o.updateLastIndexes()
 }
 
+// markSentenceBreak records the whitespace at index as a candidate line break if it follows the end of a sentence
+func (o *wrappedTarget) markSentenceBreak(index int) {
+	if !o.sentences || o.indented {
+		return
+	}
+	if o.sentenceBreak >= 0 && o.sentenceBreak == index-1 { // Run of whitespace after the end of a sentence; break on the last one
+		o.sentenceBreak = index
+		return
+	}
+	o.sentenceBreak = -1
+	if endsSentence(o.out[:index]) {
+		o.sentenceBreak = index
+	}
+}
+
+// breakSentence replaces the candidate sentence break with a new line if the text following it can safely start a new line
+func (o *wrappedTarget) breakSentence(next string) {
+	index := o.sentenceBreak
+	o.sentenceBreak = -1
+	r, _ := utf8.DecodeRuneInString(next)
+	if !startsSentence(r) || (index != o.lastRemovedNewline && !safeLineStart(next)) {
+		return
+	}
+	o.out[index] = '\n'
+	o.lastNewline = index
+	o.lastInsertedNewline = index
+	if o.lastRemovedNewline == index {
+		o.lastRemovedNewline = -1
+	}
+	o.lastSpace = -1
+	if index == len(o.out)-1 {
+		o.lastRune = '\n'
+	}
+}
+
 func (o *wrappedTarget) insertNewLine() {
 	o.lastInsertedNewline = len(o.out)
 	o.lastNewline = len(o.out)
@@ -202,10 +305,14 @@ func (o *wrappedTarget) String() string {
 	}
 	o.blocks = nil
 	o.currentBlock = nil
+	o.completeWord()
 	return unsafe.String(unsafe.SliceData(o.out), len(o.out))
 }
 
 func (o *wrappedTarget) FlushWrap() {
+	if o.disableWrapCount == 0 && o.currentBlock == nil {
+		o.completeWord()
+	}
 	if o.lastRemovedNewline >= 0 && len(o.out) == o.lastRemovedNewline+1 {
 		// We ended on a new line and removed it; restore the new line
 		o.out[o.lastRemovedNewline] = '\n'
@@ -223,6 +330,9 @@ func (o *wrappedTarget) EnableWrap() {
 }
 
 func (o *wrappedTarget) DisableWrap() {
+	if o.disableWrapCount == 0 && o.currentBlock == nil {
+		o.completeWord()
+	}
 	o.disableWrapCount += 1
 }
 
@@ -280,6 +390,60 @@ func lastIndexRune(b []byte, r rune) int {
 	return -1
 }
 
+// endsSentence checks whether the text ends with sentence-ending punctuation, optionally followed by closing quotes or parentheses
+func endsSentence(b []byte) bool {
+	for len(b) > 0 {
+		r, size := utf8.DecodeLastRune(b)
+		b = b[:len(b)-size]
+		switch r {
+		case ')', '"', '\'', ']':
+			continue
+		case '.', '?', '!':
+		default:
+			return false
+		}
+		prev, size := utf8.DecodeLastRune(b)
+		if unicode.IsLetter(prev) {
+			// Skip single-letter abbreviations and initials (e.g. "i.e.")
+			before, _ := utf8.DecodeLastRune(b[:len(b)-size])
+			return len(b) > size && before != '.' && !isWhitespace(before) && before != '\n'
+		}
+		switch prev {
+		case ')', ']', '"', '\'', '`', '*', '_', '>', '+':
+			return true
+		}
+		return unicode.IsDigit(prev)
+	}
+	return false
+}
+
+// startsSentence checks whether a rune looks like the start of a new sentence
+func startsSentence(r rune) bool {
+	return unicode.IsUpper(r)
+}
+
+var listMarkerPattern = regexp.MustCompile(`^(?:[0-9]+\.|[A-Za-z]\.|[ivxlcdm]+\)|[IVXLCDM]+\))$`)
+
+// safeLineStart checks whether text can start a new line without changing the meaning of the Asciidoc, e.g. by starting a list or an admonition
+func safeLineStart(s string) bool {
+	if len(s) == 0 {
+		return true
+	}
+	switch s[0] {
+	case '|', '=', '*', '-', '.':
+		return false
+	}
+	if strings.HasPrefix(s, "//") {
+		return false
+	}
+	word, _, _ := strings.Cut(s, " ")
+	switch word {
+	case "NOTE:", "TIP:", "IMPORTANT:", "WARNING:", "CAUTION:":
+		return false
+	}
+	return !listMarkerPattern.MatchString(word)
+}
+
 func isWhitespace(r rune) bool {
 	switch r {
 	case '\t', ' ', 0xA0: // Tabs, spaces and nbsps
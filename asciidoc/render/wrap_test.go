@@ -0,0 +1,62 @@
+package render
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name     string
+		width    int
+		in       string
+		expected string
+	}{
+		{"wraps at spaces", 20, "The quick brown fox jumps over the lazy dog.\n", "The quick brown fox\njumps over the lazy\ndog.\n"},
+		{"ordered list marker", 20, "Choose one of these A. or B. instead.\n", "Choose one of these A.\nor B. instead.\n"},
+		{"numbered list marker", 16, "It was released in 1950. for sure.\n", "It was released\nin 1950. for\nsure.\n"},
+		{"roman list marker", 18, "The options are (i) and ii) here.\n", "The options are\n(i) and ii) here.\n"},
+		{"admonition", 20, "Read the following NOTE: carefully.\n", "Read the following NOTE:\ncarefully.\n"},
+		{"comment", 14, "See the path a//b or //c here.\n", "See the path\na//b or //c\nhere.\n"},
+		{"table cell", 11, "Values a | b are separated.\n", "Values a |\nb are\nseparated.\n"},
+		{"syntax characters", 10, "Some text = foo * bar - baz . end\n", "Some text =\nfoo * bar -\nbaz . end\n"},
+		{"restores source line", 20, "Reference Guide\n===============\n", "Reference Guide\n===============\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := NewWrappedTarget(context.Background(), tt.width)
+			target.WriteString(tt.in)
+			target.FlushWrap()
+			if out := target.String(); out != tt.expected {
+				t.Errorf("unexpected output:\n%q\nexpected:\n%q", out, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSemanticLineBreaks(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		expected string
+	}{
+		{"breaks after sentences", "This is one. This is two! Is this three? Yes.\n", "This is one.\nThis is two!\nIs this three?\nYes.\n"},
+		{"joins lines", "This is\none sentence. And\nthis is another.\n", "This is one sentence.\nAnd this is another.\n"},
+		{"abbreviations", "This is e.g. an example. Next.\n", "This is e.g. an example.\nNext.\n"},
+		{"ordered list marker", "Pick one. A. is first. I. is second.\n", "Pick one. A. is first. I. is second.\n"},
+		{"admonition", "Read this. NOTE: it matters. TIP: so does this.\n", "Read this. NOTE: it matters. TIP: so does this.\n"},
+		{"admonition-like word", "Read this. NOTES are useful.\n", "Read this.\nNOTES are useful.\n"},
+		{"syntax characters", "One. |Two. =Three. *Four. -Five. .Six. //Seven.\n", "One. |Two. =Three. *Four. -Five. .Six. //Seven.\n"},
+		{"end of text", "One. Two", "One.\nTwo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := NewSentenceWrappedTarget(context.Background(), 0)
+			target.WriteString(tt.in)
+			target.FlushWrap()
+			if out := target.String(); out != tt.expected {
+				t.Errorf("unexpected output:\n%q\nexpected:\n%q", out, tt.expected)
+			}
+		})
+	}
+}
@@ -4,10 +4,17 @@ package cmd
 
 import (
 	"github.com/project-chip/alchemy/cmd/compare"
+	"github.com/project-chip/alchemy/cmd/defines"
 	"github.com/project-chip/alchemy/cmd/disco"
 	"github.com/project-chip/alchemy/cmd/dm"
 	"github.com/project-chip/alchemy/cmd/dump"
 	"github.com/project-chip/alchemy/cmd/format"
+	"github.com/project-chip/alchemy/cmd/html"
+	"github.com/project-chip/alchemy/cmd/idl"
+	"github.com/project-chip/alchemy/cmd/promote"
+	"github.com/project-chip/alchemy/cmd/rename"
+	"github.com/project-chip/alchemy/cmd/scaffold"
+	"github.com/project-chip/alchemy/cmd/site"
 	"github.com/project-chip/alchemy/cmd/testplan"
 	"github.com/project-chip/alchemy/cmd/validate"
 	"github.com/project-chip/alchemy/cmd/zap"
@@ -22,10 +29,17 @@ func init() {
 	rootCmd.AddCommand(format.Command)
 	rootCmd.AddCommand(disco.Command)
 	rootCmd.AddCommand(zap.Command)
+	rootCmd.AddCommand(defines.Command)
 	rootCmd.AddCommand(compare.Command)
 	rootCmd.AddCommand(conformanceCommand)
 	rootCmd.AddCommand(dump.Command)
 	rootCmd.AddCommand(dm.Command)
+	rootCmd.AddCommand(idl.Command)
 	rootCmd.AddCommand(testplan.Command)
 	rootCmd.AddCommand(validate.Command)
+	rootCmd.AddCommand(html.Command)
+	rootCmd.AddCommand(site.Command)
+	rootCmd.AddCommand(rename.Command)
+	rootCmd.AddCommand(promote.Command)
+	rootCmd.AddCommand(scaffold.Command)
 }
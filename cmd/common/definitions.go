@@ -0,0 +1,39 @@
+package common
+
+import (
+	"context"
+
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+// FilterWithDefinitions filters the spec docs to the given paths plus any docs parsed from cluster definitions; with
+// neither, every doc is kept
+func FilterWithDefinitions(cxt context.Context, pipelineOptions pipeline.Options, specDocs pipeline.Map[string, *pipeline.Data[*spec.Doc]], paths []string, definitions []*pipeline.Data[*spec.Doc]) (pipeline.Map[string, *pipeline.Data[*spec.Doc]], error) {
+	if len(paths) == 0 && len(definitions) == 0 {
+		return specDocs, nil
+	}
+	// Definitions may not be on disk for the filter to find, so they're set aside and added back afterwards
+	var definitionDocs []*pipeline.Data[*spec.Doc]
+	for _, d := range definitions {
+		if dd, ok := specDocs.Load(d.Path); ok {
+			definitionDocs = append(definitionDocs, dd)
+			specDocs.Delete(d.Path)
+		}
+	}
+	if len(paths) > 0 {
+		var err error
+		filter := files.NewPathFilter[*spec.Doc](paths)
+		specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, filter, specDocs)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		specDocs = pipeline.NewMap[string, *pipeline.Data[*spec.Doc]]()
+	}
+	for _, d := range definitionDocs {
+		specDocs.Store(d.Path, d)
+	}
+	return specDocs, nil
+}
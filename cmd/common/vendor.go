@@ -0,0 +1,36 @@
+package common
+
+import (
+	"context"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+// ParseVendorDocs parses the docs under each vendor root; vendor roots are laid out like the spec, with their docs
+// under src/
+func ParseVendorDocs(cxt context.Context, pipelineOptions pipeline.Options, specRoot string, vendorRoots []string, asciiSettings []asciidoc.AttributeName) (vendorDocs []*pipeline.Data[*spec.Doc], err error) {
+	for _, vendorRoot := range vendorRoots {
+		var vendorFiles pipeline.Map[string, *pipeline.Data[struct{}]]
+		vendorFiles, err = pipeline.Start[struct{}](cxt, spec.Targeter(vendorRoot))
+		if err != nil {
+			return
+		}
+		var docParser spec.Parser
+		docParser, err = spec.NewVendorParser(specRoot, asciiSettings)
+		if err != nil {
+			return
+		}
+		var docs pipeline.Map[string, *pipeline.Data[*spec.Doc]]
+		docs, err = pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, vendorFiles)
+		if err != nil {
+			return
+		}
+		docs.Range(func(path string, doc *pipeline.Data[*spec.Doc]) bool {
+			vendorDocs = append(vendorDocs, doc)
+			return true
+		})
+	}
+	return
+}
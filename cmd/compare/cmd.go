@@ -65,9 +65,32 @@ func compareSpec(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	xmlPaths, err := pipeline.Start[struct{}](cxt, files.PathsTargeter(filepath.Join(sdkRoot, "src/app/zap-templates/zcl/data-model/chip/*.xml")))
+	var diffs []*compare.ClusterDifferences
+	diffs, err = Compare(cxt, pipelineOptions, sdkRoot, specBuilder.Spec, specDocs, nil)
 	if err != nil {
-		return err
+		return
+	}
+
+	if fileOptions.DryRun {
+		return nil
+	}
+
+	if text {
+		WriteText(os.Stdout, diffs)
+		return
+	}
+
+	jm := json.NewEncoder(os.Stdout)
+	jm.SetIndent("", "\t")
+	return jm.Encode(diffs)
+}
+
+// Compare compares the clusters in the spec docs to the ZAP templates in the SDK; if include is not nil, only the docs it accepts are compared
+func Compare(cxt context.Context, pipelineOptions pipeline.Options, sdkRoot string, specification *spec.Specification, specDocs pipeline.Map[string, *pipeline.Data[*spec.Doc]], include func(path string) bool) (diffs []*compare.ClusterDifferences, err error) {
+	var xmlPaths pipeline.Map[string, *pipeline.Data[struct{}]]
+	xmlPaths, err = pipeline.Start[struct{}](cxt, files.PathsTargeter(filepath.Join(sdkRoot, "src/app/zap-templates/zcl/data-model/chip/*.xml")))
+	if err != nil {
+		return
 	}
 
 	var xmlFiles pipeline.Map[string, *pipeline.Data[[]byte]]
@@ -100,6 +123,9 @@ func compareSpec(cmd *cobra.Command, args []string) (err error) {
 
 	specEntityMap := make(map[string][]types.Entity, specEntities.Size())
 	specEntities.Range(func(path string, entities *pipeline.Data[[]types.Entity]) bool {
+		if include != nil && !include(path) {
+			return true
+		}
 
 		errata := errata.GetZAP(path)
 
@@ -123,22 +149,6 @@ func compareSpec(cmd *cobra.Command, args []string) (err error) {
 		return true
 	})
 
-	var diffs []*compare.ClusterDifferences
-	diffs, err = compare.Entities(specBuilder.Spec, specEntityMap, zapEntityMap)
-	if err != nil {
-		return
-	}
-
-	if fileOptions.DryRun {
-		return nil
-	}
-
-	if text {
-		writeText(os.Stdout, diffs)
-		return
-	}
-
-	jm := json.NewEncoder(os.Stdout)
-	jm.SetIndent("", "\t")
-	return jm.Encode(diffs)
+	diffs, err = compare.Entities(specification, specEntityMap, zapEntityMap)
+	return
 }
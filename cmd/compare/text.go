@@ -12,7 +12,7 @@ import (
 	"github.com/project-chip/alchemy/matter/types"
 )
 
-func writeText(w io.Writer, diffs []*compare.ClusterDifferences) {
+func WriteText(w io.Writer, diffs []*compare.ClusterDifferences) {
 	for _, cd := range diffs {
 		writeClusterDifference(w, cd)
 	}
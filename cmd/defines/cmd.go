@@ -0,0 +1,149 @@
+package defines
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/errata"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/zap/generate"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "defines",
+	Short: "preview the defines and type names the ZAP templates would declare, and report collisions and differences from the SDK",
+	RunE:  defines,
+}
+
+func init() {
+	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.Flags().String("sdkRoot", "connectedhomeip", "the root of your clone of project-chip/connectedhomeip")
+	Command.Flags().StringSlice("vendorRoot", nil, "the root of a vendor's manufacturer-specific cluster docs, laid out like the spec; this flag can be provided more than once")
+	Command.Flags().Bool("list", false, "list every define and type name, not just collisions and differences")
+}
+
+func defines(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	specRoot, _ := cmd.Flags().GetString("specRoot")
+	sdkRoot, _ := cmd.Flags().GetString("sdkRoot")
+	vendorRoots, _ := cmd.Flags().GetStringSlice("vendorRoot")
+	list, _ := cmd.Flags().GetBool("list")
+
+	asciiSettings := common.ASCIIDocAttributes(cmd)
+	pipelineOptions := pipeline.Flags(cmd)
+
+	errata.LoadErrataConfig(specRoot)
+
+	specFiles, err := pipeline.Start[struct{}](cxt, spec.Targeter(specRoot))
+	if err != nil {
+		return err
+	}
+	docParser, err := spec.NewParser(specRoot, asciiSettings)
+	if err != nil {
+		return err
+	}
+	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
+	if err != nil {
+		return err
+	}
+	vendorDocs, err := common.ParseVendorDocs(cxt, pipelineOptions, specRoot, vendorRoots, asciiSettings)
+	if err != nil {
+		return err
+	}
+	for _, d := range vendorDocs {
+		specDocs.Store(d.Path, d)
+	}
+
+	specBuilder := spec.NewBuilder()
+	specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
+	if err != nil {
+		return err
+	}
+
+	clusters, _, _, err := generate.SplitZAPDocs(cxt, specDocs)
+	if err != nil {
+		return err
+	}
+
+	reporter := generate.NewDefineReporter(sdkRoot)
+	_, err = pipeline.Process[*spec.Doc, struct{}](cxt, pipelineOptions, reporter, clusters)
+	if err != nil {
+		return err
+	}
+
+	err = writeReport(os.Stdout, reporter, list)
+	if err != nil {
+		return err
+	}
+	if len(reporter.Collisions) > 0 {
+		return fmt.Errorf("found %d define collisions", len(reporter.Collisions))
+	}
+	return nil
+}
+
+func writeReport(w io.Writer, reporter *generate.DefineReporter, list bool) error {
+	if list {
+		for _, d := range reporter.Defines {
+			fmt.Fprintf(w, "%s\n", d)
+		}
+		fmt.Fprintln(w)
+	}
+	if len(reporter.Collisions) > 0 {
+		fmt.Fprintln(w, "Collisions:")
+		for _, c := range reporter.Collisions {
+			fmt.Fprintf(w, "\t%s %s\n", c.Kind, c.Name)
+			for _, d := range c.Defines {
+				fmt.Fprintf(w, "\t\t%s\n", d)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	var differences []*generate.Define
+	for _, d := range reporter.Defines {
+		if d.Existing != "" {
+			differences = append(differences, d)
+		}
+	}
+	if len(differences) > 0 {
+		fmt.Fprintln(w, "Differences from the SDK:")
+		slices.SortStableFunc(differences, func(a, b *generate.Define) int { return strings.Compare(a.Template, b.Template) })
+		var template string
+		for _, d := range differences {
+			if d.Template != template {
+				template = d.Template
+				fmt.Fprintf(w, "\t%s\n", template)
+			}
+			fmt.Fprintf(w, "\t\t%s: %q -> %q\n", d, d.Existing, d.Generated)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(reporter.Suggestions) > 0 {
+		suggestions := struct {
+			Errata map[string]*errata.Errata `yaml:"errata"`
+		}{Errata: make(map[string]*errata.Errata, len(reporter.Suggestions))}
+		for path, z := range reporter.Suggestions {
+			suggestions.Errata[path] = &errata.Errata{ZAP: *z}
+		}
+		b, err := yaml.Marshal(suggestions)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, "Suggested errata:")
+		fmt.Fprint(w, string(b))
+	}
+	if len(reporter.Collisions) == 0 && len(differences) == 0 {
+		fmt.Fprintln(w, "No collisions or differences found")
+	}
+	return nil
+}
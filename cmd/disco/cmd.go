@@ -2,6 +2,8 @@ package disco
 
 import (
 	"context"
+	"fmt"
+	"os"
 
 	"github.com/project-chip/alchemy/asciidoc/render"
 	"github.com/project-chip/alchemy/disco"
@@ -26,8 +28,41 @@ func discoBall(cmd *cobra.Command, args []string) (err error) {
 	pipelineOptions := pipeline.Flags(cmd)
 	fileOptions := files.Flags(cmd)
 
+	lint, _ := cmd.Flags().GetBool("lint")
+	if lint {
+		var findings []disco.Finding
+		findings, err = disco.Lint(cxt, specRoot, args, pipelineOptions, getDiscoOptions(cmd))
+		if err != nil {
+			return
+		}
+		for _, f := range findings {
+			fmt.Fprintln(os.Stdout, f.String())
+		}
+		if len(findings) > 0 {
+			err = fmt.Errorf("%d disco-ball violations", len(findings))
+		}
+		return
+	}
+
 	writer := files.NewWriter[string]("Writing disco-balled docs", fileOptions)
 
+	changedSince, _ := cmd.Flags().GetString("changedSince")
+	if changedSince != "" {
+		if !fileOptions.Patch {
+			return fmt.Errorf("--changedSince requires --patch")
+		}
+		var changedLines files.LineScope
+		dir := specRoot
+		if dir == "" {
+			dir = "."
+		}
+		changedLines, err = files.ChangedLines(cxt, dir, changedSince)
+		if err != nil {
+			return
+		}
+		writer = files.NewScopedPatcher[string]("Writing disco-balled docs", os.Stdout, changedLines, nil)
+	}
+
 	err = disco.Pipeline(cxt, specRoot, args, pipelineOptions, getDiscoOptions(cmd), getRenderOptions(cmd), writer)
 
 	return
@@ -50,7 +85,24 @@ func init() {
 	Command.Flags().Bool("addSpaceAfterPunctuation", true, "add missing space after punctuation")
 	Command.Flags().Bool("removeExtraSpaces", true, "remove extraneous spaces")
 	Command.Flags().Bool("disambiguateConformanceChoice", false, "ensure conformance choices are only used once per document")
+	Command.Flags().Bool("canonicalConformance", false, "simplify conformance to its canonical form, reporting rewrites that may change its meaning")
+	Command.Flags().Bool("crossCheckClusters", false, "check that the cluster names and IDs in device type requirement tables match the spec")
+	Command.Flags().Bool("linkClusterNames", false, "link cluster names in device type requirement tables to their cluster documents")
+	Command.Flags().Bool("sortNamespaceTags", false, "sort the tags in namespace documents by ID")
+	Command.Flags().Bool("normalizeDeviceTypeTables", false, "rename and reorder the columns of the condition and requirement tables in device type documents")
+	Command.Flags().Bool("normalizeNamespaceTables", false, "rename and reorder the columns of the tables in namespace documents")
+	Command.Flags().Bool("normalizeAnchors", true, "normalize anchors and the cross references to them")
+	Command.Flags().Bool("formatConformance", true, "reformat conformance cells")
+	Command.Flags().Bool("formatConstraint", true, "reformat constraint cells")
+	Command.Flags().Bool("canonicalizeDataTypeNames", true, "rename data type sections to their canonical names")
+	Command.Flags().Bool("fixBitmapRanges", true, "fix the order and separators of bit ranges in bitmaps")
+	Command.Flags().Bool("normalizeSectionNames", true, "rename sections to their standard names")
 	Command.Flags().Int("wrap", 0, "the maximum length of a line")
+	Command.Flags().Bool("semanticLineBreaks", false, "put each sentence on its own line")
+	Command.Flags().Bool("unwrap", false, "join the lines of each paragraph into a single line")
+	Command.Flags().Bool("lint", false, "report every violation of the disco-ball rules, with the rule name, file and line, without changing anything")
+	Command.Flags().String("changedSince", "", "with --patch, only include the changes that overlap the lines changed since the given git ref")
+	Command.Flags().Bool("check", false, "list the files that would be changed by disco-balling, without writing them, and exit with an error if there are any")
 }
 
 type discoOption func(bool) disco.Option
@@ -72,9 +124,25 @@ func getDiscoOptions(cmd *cobra.Command) []disco.Option {
 		"removeExtraSpaces":             disco.RemoveExtraSpaces,
 		"normalizeFeatureNames":         disco.NormalizeFeatureNames,
 		"disambiguateConformanceChoice": disco.DisambiguateConformanceChoice,
+		"canonicalConformance":          disco.CanonicalConformance,
+		"crossCheckClusters":            disco.CrossCheckClusters,
+		"linkClusterNames":              disco.LinkClusterNames,
+		"sortNamespaceTags":             disco.SortNamespaceTags,
+		"normalizeDeviceTypeTables":     disco.NormalizeDeviceTypeTables,
+		"normalizeNamespaceTables":      disco.NormalizeNamespaceTables,
+		"normalizeAnchors":              disco.NormalizeAnchors,
+		"formatConformance":             disco.FormatConformance,
+		"formatConstraint":              disco.FormatConstraint,
+		"canonicalizeDataTypeNames":     disco.CanonicalizeDataTypeNames,
+		"fixBitmapRanges":               disco.FixBitmapRanges,
+		"normalizeSectionNames":         disco.NormalizeSectionNames,
 	}
 	var discoOptions []disco.Option
 	for name, o := range optionFuncs {
+		// Flags left at their defaults defer to the spec repo's rule configuration
+		if !cmd.Flags().Changed(name) {
+			continue
+		}
 		on, err := cmd.Flags().GetBool(name)
 		if err != nil {
 			continue
@@ -90,5 +158,13 @@ func getRenderOptions(cmd *cobra.Command) []render.Option {
 	if err == nil {
 		renderOptions = append(renderOptions, render.Wrap(wrap))
 	}
+	semanticLineBreaks, err := cmd.Flags().GetBool("semanticLineBreaks")
+	if err == nil {
+		renderOptions = append(renderOptions, render.SemanticLineBreaks(semanticLineBreaks))
+	}
+	unwrap, err := cmd.Flags().GetBool("unwrap")
+	if err == nil {
+		renderOptions = append(renderOptions, render.UnwrapParagraphs(unwrap))
+	}
 	return renderOptions
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/project-chip/alchemy/internal/files"
 	"github.com/project-chip/alchemy/internal/pipeline"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/scaffold"
 	"github.com/spf13/cobra"
 )
 
@@ -44,6 +45,25 @@ func dataModel(cmd *cobra.Command, args []string) (err error) {
 	if err != nil {
 		return err
 	}
+
+	definitionPaths, _ := cmd.Flags().GetStringSlice("definition")
+	definitions, err := scaffold.ParseDefinitions(specRoot, definitionPaths, asciiSettings)
+	if err != nil {
+		return err
+	}
+	for _, d := range definitions {
+		specDocs.Store(d.Path, d)
+	}
+
+	vendorRoots, _ := cmd.Flags().GetStringSlice("vendorRoot")
+	vendorDocs, err := common.ParseVendorDocs(cxt, pipelineOptions, specRoot, vendorRoots, asciiSettings)
+	if err != nil {
+		return err
+	}
+	for _, d := range vendorDocs {
+		specDocs.Store(d.Path, d)
+	}
+
 	specBuilder := spec.NewBuilder()
 	specBuilder.IgnoreHierarchy = true
 	specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
@@ -51,12 +71,9 @@ func dataModel(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	if len(args) > 0 {
-		filter := files.NewPathFilter[*spec.Doc](args)
-		specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, filter, specDocs)
-		if err != nil {
-			return err
-		}
+	specDocs, err = common.FilterWithDefinitions(cxt, pipelineOptions, specDocs, args, definitions)
+	if err != nil {
+		return err
 	}
 
 	renderer := dm.NewRenderer(dmRoot)
@@ -82,4 +99,6 @@ func dataModel(cmd *cobra.Command, args []string) (err error) {
 func init() {
 	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
 	Command.Flags().String("dmRoot", "connectedhomeip/data_model/master", "where to place the data model files")
+	Command.Flags().StringSlice("definition", nil, "a YAML or JSON cluster definition to generate from as though it were in the spec; this flag can be provided more than once")
+	Command.Flags().StringSlice("vendorRoot", nil, "the root of a vendor's manufacturer-specific cluster docs, laid out like the spec; this flag can be provided more than once")
 }
@@ -2,6 +2,9 @@ package format
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
 
 	"github.com/project-chip/alchemy/asciidoc/render"
 	"github.com/project-chip/alchemy/internal/files"
@@ -11,14 +14,22 @@ import (
 )
 
 var Command = &cobra.Command{
-	Use:   "format",
+	Use:   "format [filename_pattern | -]",
 	Short: "format Matter spec documents",
+	Long:  "format Matter spec documents; pass - to read a single document from stdin and write the formatted document to stdout",
 	RunE:  format,
 }
 
 func format(cmd *cobra.Command, args []string) (err error) {
 	cxt := context.Background()
 
+	pipelineOptions := pipeline.Flags(cmd)
+	fileOptions := files.Flags(cmd)
+
+	if len(args) == 1 && args[0] == "-" {
+		return formatStdin(cxt, cmd, pipelineOptions)
+	}
+
 	var inputs pipeline.Map[string, *pipeline.Data[struct{}]]
 
 	inputs, err = pipeline.Start[struct{}](cxt, files.PathsTargeter(args...))
@@ -27,9 +38,6 @@ func format(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	pipelineOptions := pipeline.Flags(cmd)
-	fileOptions := files.Flags(cmd)
-
 	docReader, err := spec.NewReader("Reading docs", "")
 	if err != nil {
 		return err
@@ -39,25 +47,64 @@ func format(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	ids := pipeline.NewConcurrentMapPresized[string, *pipeline.Data[render.InputDocument]](docs.Size())
-	err = pipeline.Cast(docs, ids)
+	renders, err := renderDocs(cxt, cmd, pipelineOptions, docs)
 	if err != nil {
 		return err
 	}
 
-	wrap, _ := cmd.Flags().GetInt("wrap")
-	renderer := render.NewRenderer(render.Wrap(wrap))
-	var renders pipeline.Map[string, *pipeline.Data[string]]
-	renders, err = pipeline.Process[render.InputDocument, string](cxt, pipelineOptions, renderer, ids)
+	writer := files.NewWriter[string]("Formatting docs", fileOptions)
+	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, writer, renders)
+	return
+}
+
+func formatStdin(cxt context.Context, cmd *cobra.Command, pipelineOptions pipeline.Options) (err error) {
+	var b []byte
+	b, err = io.ReadAll(os.Stdin)
+	if err != nil {
+		return
+	}
+
+	inputs := pipeline.NewMap[string, *pipeline.Data[string]]()
+	inputs.Store("-", pipeline.NewData("-", string(b)))
+
+	docReader, err := spec.NewStringReader("Reading stdin", "")
+	if err != nil {
+		return err
+	}
+	docs, err := pipeline.Process[string, *spec.Doc](cxt, pipelineOptions, docReader, inputs)
 	if err != nil {
 		return err
 	}
 
-	writer := files.NewWriter[string]("Formatting docs", fileOptions)
-	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, writer, renders)
+	renders, err := renderDocs(cxt, cmd, pipelineOptions, docs)
+	if err != nil {
+		return err
+	}
+	renders.Range(func(path string, value *pipeline.Data[string]) bool {
+		_, err = fmt.Fprint(os.Stdout, value.Content)
+		return err == nil
+	})
+	return
+}
+
+func renderDocs(cxt context.Context, cmd *cobra.Command, pipelineOptions pipeline.Options, docs pipeline.Map[string, *pipeline.Data[*spec.Doc]]) (renders pipeline.Map[string, *pipeline.Data[string]], err error) {
+	ids := pipeline.NewConcurrentMapPresized[string, *pipeline.Data[render.InputDocument]](docs.Size())
+	err = pipeline.Cast(docs, ids)
+	if err != nil {
+		return
+	}
+
+	wrap, _ := cmd.Flags().GetInt("wrap")
+	semanticLineBreaks, _ := cmd.Flags().GetBool("semanticLineBreaks")
+	unwrap, _ := cmd.Flags().GetBool("unwrap")
+	renderer := render.NewRenderer(render.Wrap(wrap), render.SemanticLineBreaks(semanticLineBreaks), render.UnwrapParagraphs(unwrap))
+	renders, err = pipeline.Process[render.InputDocument, string](cxt, pipelineOptions, renderer, ids)
 	return
 }
 
 func init() {
 	Command.Flags().Int("wrap", 0, "the maximum length of a line")
+	Command.Flags().Bool("semanticLineBreaks", false, "put each sentence on its own line")
+	Command.Flags().Bool("unwrap", false, "join the lines of each paragraph into a single line")
+	Command.Flags().Bool("check", false, "list the files that would be changed by formatting, without writing them, and exit with an error if there are any")
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/project-chip/alchemy/config"
 	"github.com/project-chip/alchemy/disco"
@@ -34,8 +35,13 @@ func Action(cmd *cobra.Command, args []string) error {
 	if pr == nil {
 		return nil
 	}
+	client, err := newClient(githubContext, action)
+	if err != nil {
+		return err
+	}
 	var changedFiles []string
-	changedFiles, err = getPRChangedFiles(cxt, githubContext, action, pr)
+	var prLines diffLines
+	changedFiles, prLines, err = getPRChangedFiles(cxt, client, githubContext, action, pr)
 	if err != nil {
 		return fmt.Errorf("failed on getting pull request changes: %w", err)
 	}
@@ -58,8 +64,30 @@ func Action(cmd *cobra.Command, args []string) error {
 
 	pipelineOptions := pipeline.Options{NoProgress: true}
 
-	var out bytes.Buffer
-	writer := files.NewPatcher[string]("Generating patch file...", &out)
+	var out, outOfScope bytes.Buffer
+	var writer *files.Patcher[string]
+	switch scope := action.GetInput("scope"); scope {
+	case "", "documents":
+		writer = files.NewPatcher[string]("Generating patch file...", &out)
+	case "changes":
+		// Only suggest disco-ball changes to the lines this PR changed
+		base := action.GetInput("base-ref")
+		if base == "" {
+			base = pr.GetBase().GetSHA()
+		}
+		var changedLines files.LineScope
+		changedLines, err = files.ChangedLines(cxt, ".", base)
+		if err != nil {
+			// Usually a shallow checkout that doesn't have the base commit
+			action.Warningf("Can't find the lines changed since %s, so suggesting disco-ball changes to whole documents; check out with fetch-depth: 0 to limit them to this PR's changes: %v", base, err)
+			err = nil
+			writer = files.NewPatcher[string]("Generating patch file...", &out)
+			break
+		}
+		writer = files.NewScopedPatcher[string]("Generating patch file...", &out, changedLines, &outOfScope)
+	default:
+		return fmt.Errorf("unknown scope: %s", scope)
+	}
 
 	err = disco.Pipeline(cxt, ".", changedDocs, pipelineOptions, nil, nil, writer)
 	if err != nil {
@@ -75,5 +103,33 @@ func Action(cmd *cobra.Command, args []string) error {
 	} else {
 		action.SetOutput("disco_status", "unpatched")
 	}
+	var annotations []annotation
+	annotations, err = checkSemantics(cxt, cmd, action, pipelineOptions, changedDocs)
+	if err != nil {
+		return fmt.Errorf("failed checking spec: %w", err)
+	}
+	annotate(action, annotations)
+	action.SetOutput("finding_count", strconv.Itoa(len(annotations)))
+
+	if action.GetInput("review") == "true" {
+		comments := discoSuggestions(writer.Hunks())
+		comments = append(comments, annotationComments(annotations)...)
+		var skipped int
+		skipped, err = postReview(cxt, client, githubContext, pr, comments, prLines)
+		if err != nil {
+			// The patch file and annotations are still there, so a failed review isn't fatal
+			if isPermissionError(err) {
+				action.Warningf("The token can't post pull request reviews; disco-ball suggestions are only in disco.patch: %v", err)
+			} else {
+				action.Warningf("Failed posting pull request review; disco-ball suggestions are only in disco.patch: %v", err)
+			}
+			err = nil
+		} else if skipped > 0 {
+			action.Infof("%d review comments are outside of the pull request's diff, and are only in disco.patch or the workflow annotations", skipped)
+		}
+	}
+	if outOfScope.Len() > 0 && action.GetInput("report-out-of-scope") == "true" {
+		action.Infof("Disco-ball changes outside of the lines changed by this PR, for information only:\n%s", outOfScope.String())
+	}
 	return nil
 }
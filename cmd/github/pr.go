@@ -3,34 +3,93 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/google/go-github/v60/github"
+	"github.com/project-chip/alchemy/internal/files"
 	"github.com/sethvargo/go-githubactions"
 )
 
-func getPRChangedFiles(cxt context.Context, githubContext *githubactions.GitHubContext, action *githubactions.Action, pr *github.PullRequest) (changedFiles []string, err error) {
-
+func newClient(githubContext *githubactions.GitHubContext, action *githubactions.Action) (*github.Client, error) {
 	token := action.Getenv("GITHUB_AUTH_TOKEN")
 	if token == "" {
 		return nil, fmt.Errorf("missing github token")
 	}
 	client := github.NewClient(nil).WithAuthToken(token)
+	if githubContext.APIURL != "" {
+		// Respect GITHUB_API_URL, for GitHub Enterprise or a local stand-in for the API
+		baseURL, err := url.Parse(strings.TrimSuffix(githubContext.APIURL, "/") + "/")
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub API URL %s: %w", githubContext.APIURL, err)
+		}
+		client.BaseURL = baseURL
+	}
+	return client, nil
+}
+
+// diffLines are the lines on the new side of a PR's diff, keyed by the file's path in the repo
+type diffLines map[string][]files.LineRange
+
+func (dl diffLines) contains(path string, start int, end int) bool {
+	for _, r := range dl[path] {
+		if start >= r.Start && end <= r.End {
+			return true
+		}
+	}
+	return false
+}
 
+// getPRChangedFiles lists the files changed by the PR, along with the lines of each file that appear in the PR's diff
+func getPRChangedFiles(cxt context.Context, client *github.Client, githubContext *githubactions.GitHubContext, action *githubactions.Action, pr *github.PullRequest) (changedFiles []string, prLines diffLines, err error) {
 	owner, repo := githubContext.Repo()
 
 	action.Infof("Fetching PR from: %s/%s\n", owner, repo)
 
-	var files []*github.CommitFile
-	files, _, err = client.PullRequests.ListFiles(cxt, owner, repo, *pr.Number, nil)
-	if err != nil {
-		err = fmt.Errorf("failed listing files in PR: %w", err)
-		return
+	prLines = make(diffLines)
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		var prFiles []*github.CommitFile
+		var resp *github.Response
+		prFiles, resp, err = client.PullRequests.ListFiles(cxt, owner, repo, pr.GetNumber(), opts)
+		if err != nil {
+			err = fmt.Errorf("failed listing files in PR: %w", err)
+			return
+		}
+		for _, file := range prFiles {
+			if file.GetStatus() == "deleted" {
+				continue
+			}
+			changedFiles = append(changedFiles, file.GetFilename())
+			prLines[file.GetFilename()] = patchLines(file.GetPatch())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
 	}
-	for _, file := range files {
-		if *file.Status == "deleted" {
+	return
+}
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// patchLines finds the ranges of lines on the new side of each hunk in a patch, which are the lines a review can comment on
+func patchLines(patch string) (ranges []files.LineRange) {
+	for _, line := range strings.Split(patch, "\n") {
+		match := hunkHeaderPattern.FindStringSubmatch(line)
+		if match == nil {
 			continue
 		}
-		changedFiles = append(changedFiles, *file.Filename)
+		start, _ := strconv.Atoi(match[1])
+		count := 1
+		if match[2] != "" {
+			count, _ = strconv.Atoi(match[2])
+		}
+		if count > 0 {
+			ranges = append(ranges, files.LineRange{Start: start, End: start + count - 1})
+		}
 	}
 	return
 }
@@ -0,0 +1,113 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/project-chip/alchemy/config"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/sethvargo/go-githubactions"
+)
+
+// reviewComment is an inline comment on lines of the new side of a PR's diff
+type reviewComment struct {
+	Path      string
+	StartLine int
+	Line      int
+	Body      string
+}
+
+func discoSuggestions(hunks []files.Hunk) (comments []reviewComment) {
+	for _, h := range hunks {
+		comments = append(comments, reviewComment{
+			Path:      repoPath(h.Path),
+			StartLine: h.Start,
+			Line:      h.End,
+			Body:      "Disco-ball suggestion:\n\n" + suggestionBlock(h.Replacement),
+		})
+	}
+	return
+}
+
+func suggestionBlock(replacement string) string {
+	fence := "```"
+	for strings.Contains(replacement, fence) {
+		fence += "`"
+	}
+	var s strings.Builder
+	s.WriteString(fence)
+	s.WriteString("suggestion\n")
+	s.WriteString(replacement)
+	if !strings.HasSuffix(replacement, "\n") && replacement != "" {
+		s.WriteRune('\n')
+	}
+	s.WriteString(fence)
+	return s.String()
+}
+
+// repoPath makes a path relative to the working directory, which is the root of the checked out repo
+func repoPath(path string) string {
+	if filepath.IsAbs(path) {
+		if wd, err := filepath.Abs("."); err == nil {
+			if rel, err := filepath.Rel(wd, path); err == nil {
+				path = rel
+			}
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// postReview posts the comments that fall within the PR's diff as a single review, and returns how many didn't
+func postReview(cxt context.Context, client *github.Client, githubContext *githubactions.GitHubContext, pr *github.PullRequest, comments []reviewComment, prLines diffLines) (skipped int, err error) {
+	var drafts []*github.DraftReviewComment
+	for _, c := range comments {
+		if !prLines.contains(c.Path, c.StartLine, c.Line) {
+			// GitHub rejects the whole review if any comment is outside of the diff
+			skipped++
+			continue
+		}
+		draft := &github.DraftReviewComment{
+			Path: github.String(c.Path),
+			Body: github.String(c.Body),
+			Side: github.String("RIGHT"),
+			Line: github.Int(c.Line),
+		}
+		if c.StartLine < c.Line {
+			draft.StartLine = github.Int(c.StartLine)
+			draft.StartSide = github.String("RIGHT")
+		}
+		drafts = append(drafts, draft)
+	}
+	if len(drafts) == 0 {
+		return
+	}
+	body := fmt.Sprintf("Alchemy %s left %d comments.", config.Version(), len(drafts))
+	if skipped > 0 {
+		body += fmt.Sprintf(" %d more are outside of this pull request's diff, and are only in the patch file or the workflow annotations.", skipped)
+	}
+	owner, repo := githubContext.Repo()
+	_, _, err = client.PullRequests.CreateReview(cxt, owner, repo, pr.GetNumber(), &github.PullRequestReviewRequest{
+		CommitID: github.String(pr.GetHead().GetSHA()),
+		Body:     github.String(body),
+		Event:    github.String("COMMENT"),
+		Comments: drafts,
+	})
+	return
+}
+
+// isPermissionError returns true if the GitHub API refused a request because the token lacks permission
+func isPermissionError(err error) bool {
+	var errorResponse *github.ErrorResponse
+	if errors.As(err, &errorResponse) && errorResponse.Response != nil {
+		switch errorResponse.Response.StatusCode {
+		case http.StatusForbidden, http.StatusNotFound, http.StatusUnauthorized:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/sethvargo/go-githubactions"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*github.Client, *githubactions.GitHubContext) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	githubContext := &githubactions.GitHubContext{APIURL: server.URL, Repository: "owner/repo"}
+	action := githubactions.New(githubactions.WithGetenv(func(key string) string {
+		if key == "GITHUB_AUTH_TOKEN" {
+			return "token"
+		}
+		return ""
+	}))
+	client, err := newClient(githubContext, action)
+	if err != nil {
+		t.Fatalf("failed creating client: %v", err)
+	}
+	return client, githubContext
+}
+
+func TestPostReview(t *testing.T) {
+	var review github.PullRequestReviewRequest
+	client, githubContext := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/owner/repo/pulls/7/reviews" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+			t.Errorf("failed decoding review: %v", err)
+		}
+		w.Write([]byte(`{"id":1}`))
+	})
+	pr := &github.PullRequest{Number: github.Int(7), Head: &github.PullRequestBranch{SHA: github.String("abc")}}
+	hunks := []files.Hunk{
+		{Path: "src/Doc.adoc", Start: 3, End: 4, Replacement: "| ID     | Name\n| 0x0001 | One\n"},
+		{Path: "src/Doc.adoc", Start: 40, End: 40, Replacement: "outside\n"},
+	}
+	prLines := diffLines{"src/Doc.adoc": patchLines("@@ -1,2 +1,6 @@\n line\n+added")}
+	skipped, err := postReview(context.Background(), client, githubContext, pr, discoSuggestions(hunks), prLines)
+	if err != nil {
+		t.Fatalf("failed posting review: %v", err)
+	}
+	if skipped != 1 {
+		t.Errorf("expected 1 comment outside of the diff, got %d", skipped)
+	}
+	if len(review.Comments) != 1 {
+		t.Fatalf("expected 1 comment, got %d", len(review.Comments))
+	}
+	c := review.Comments[0]
+	if c.GetPath() != "src/Doc.adoc" || c.GetStartLine() != 3 || c.GetLine() != 4 {
+		t.Errorf("unexpected comment location: %s %d-%d", c.GetPath(), c.GetStartLine(), c.GetLine())
+	}
+	expected := "Disco-ball suggestion:\n\n```suggestion\n| ID     | Name\n| 0x0001 | One\n```"
+	if c.GetBody() != expected {
+		t.Errorf("unexpected comment body: %q", c.GetBody())
+	}
+	if review.GetCommitID() != "abc" || review.GetEvent() != "COMMENT" {
+		t.Errorf("unexpected review: %s %s", review.GetCommitID(), review.GetEvent())
+	}
+}
+
+func TestPostReviewWithoutPermission(t *testing.T) {
+	client, githubContext := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message":"Resource not accessible by integration"}`))
+	})
+	pr := &github.PullRequest{Number: github.Int(7)}
+	comments := []reviewComment{{Path: "src/Doc.adoc", StartLine: 1, Line: 1, Body: "suggestion"}}
+	_, err := postReview(context.Background(), client, githubContext, pr, comments, diffLines{"src/Doc.adoc": {{Start: 1, End: 1}}})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if !isPermissionError(err) {
+		t.Errorf("expected a permission error, got %v", err)
+	}
+}
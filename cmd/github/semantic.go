@@ -0,0 +1,142 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/project-chip/alchemy/cmd/common"
+	comparecmd "github.com/project-chip/alchemy/cmd/compare"
+	"github.com/project-chip/alchemy/compare"
+	"github.com/project-chip/alchemy/errata"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/spec/validate"
+	"github.com/sethvargo/go-githubactions"
+	"github.com/spf13/cobra"
+)
+
+// annotation is a finding about a line in a changed document
+type annotation struct {
+	Path    string
+	Line    int
+	Level   slog.Level
+	Title   string
+	Message string
+}
+
+// checkSemantics builds the whole spec, and finds the validation and ZAP comparison problems in the changed documents
+func checkSemantics(cxt context.Context, cmd *cobra.Command, action *githubactions.Action, pipelineOptions pipeline.Options, changedDocs []string) (annotations []annotation, err error) {
+	runValidate := action.GetInput("validate") == "true"
+	runCompare := action.GetInput("compare") == "true"
+	if !runValidate && !runCompare {
+		return
+	}
+
+	specRoot := "."
+	errata.LoadErrataConfig(specRoot)
+
+	changed := make(map[string]struct{}, len(changedDocs))
+	for _, path := range changedDocs {
+		changed[repoPath(path)] = struct{}{}
+	}
+	isChanged := func(path string) bool {
+		_, ok := changed[repoPath(path)]
+		return ok
+	}
+
+	var specFiles pipeline.Map[string, *pipeline.Data[struct{}]]
+	specFiles, err = pipeline.Start[struct{}](cxt, spec.Targeter(specRoot))
+	if err != nil {
+		return
+	}
+	var docParser spec.Parser
+	docParser, err = spec.NewParser(specRoot, common.ASCIIDocAttributes(cmd))
+	if err != nil {
+		return
+	}
+	var specDocs pipeline.Map[string, *pipeline.Data[*spec.Doc]]
+	specDocs, err = pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
+	if err != nil {
+		return
+	}
+	specBuilder := spec.NewBuilder()
+	specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
+	if err != nil {
+		return
+	}
+
+	if runValidate {
+		for _, v := range validate.Validate(specBuilder.Spec) {
+			if !isChanged(v.Path) {
+				continue
+			}
+			annotations = append(annotations, annotation{Path: repoPath(v.Path), Line: v.Line, Level: v.Level, Title: "Validation", Message: v.Message})
+		}
+	}
+
+	if runCompare {
+		sdkRoot := action.GetInput("sdk-root")
+		if sdkRoot == "" {
+			sdkRoot = "connectedhomeip"
+		}
+		if _, statErr := os.Stat(filepath.Join(sdkRoot, "src/app/zap-templates")); statErr != nil {
+			action.Infof("No SDK checkout found at %s; skipping comparison with ZAP templates", sdkRoot)
+			return
+		}
+		diffs, compareErr := comparecmd.Compare(cxt, pipelineOptions, sdkRoot, specBuilder.Spec, specDocs, isChanged)
+		if compareErr != nil {
+			err = fmt.Errorf("failed comparing to ZAP templates: %w", compareErr)
+			return
+		}
+		for _, cd := range diffs {
+			cluster, ok := specBuilder.Spec.ClustersByID[cd.ID.Value()]
+			if !ok {
+				continue
+			}
+			path, line := cluster.Origin()
+			var details strings.Builder
+			comparecmd.WriteText(&details, []*compare.ClusterDifferences{cd})
+			annotations = append(annotations, annotation{
+				Path:    repoPath(path),
+				Line:    line,
+				Level:   slog.LevelWarn,
+				Title:   "ZAP template differences",
+				Message: fmt.Sprintf("%s differs from its ZAP template:\n%s", cluster.Name, strings.TrimSpace(details.String())),
+			})
+		}
+	}
+	return
+}
+
+func annotate(action *githubactions.Action, annotations []annotation) {
+	for _, a := range annotations {
+		fields := map[string]string{"file": a.Path, "title": a.Title}
+		if a.Line > 0 {
+			fields["line"] = strconv.Itoa(a.Line)
+		}
+		annotated := action.WithFieldsMap(fields)
+		switch {
+		case a.Level >= slog.LevelError:
+			annotated.Errorf("%s", a.Message)
+		case a.Level >= slog.LevelWarn:
+			annotated.Warningf("%s", a.Message)
+		default:
+			annotated.Noticef("%s", a.Message)
+		}
+	}
+}
+
+func annotationComments(annotations []annotation) (comments []reviewComment) {
+	for _, a := range annotations {
+		if a.Line <= 0 {
+			continue
+		}
+		comments = append(comments, reviewComment{Path: a.Path, StartLine: a.Line, Line: a.Line, Body: fmt.Sprintf("**%s:** %s", a.Title, a.Message)})
+	}
+	return
+}
@@ -0,0 +1,88 @@
+package html
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/project-chip/alchemy/asciidoc/render/html"
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/errata"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:     "html [filename_pattern]",
+	Short:   "render Matter spec documents as HTML for previewing",
+	Aliases: []string{"preview"},
+	RunE:    renderHTML,
+}
+
+func renderHTML(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	specRoot, _ := cmd.Flags().GetString("specRoot")
+	outputRoot, _ := cmd.Flags().GetString("out")
+	annotate, _ := cmd.Flags().GetBool("annotate")
+
+	errata.LoadErrataConfig(specRoot)
+
+	asciiSettings := common.ASCIIDocAttributes(cmd)
+	fileOptions := files.Flags(cmd)
+	pipelineOptions := pipeline.Flags(cmd)
+
+	specFiles, err := pipeline.Start[struct{}](cxt, spec.Targeter(specRoot))
+	if err != nil {
+		return err
+	}
+
+	docParser, err := spec.NewParser(specRoot, asciiSettings)
+	if err != nil {
+		return err
+	}
+	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
+	if err != nil {
+		return err
+	}
+	specBuilder := spec.NewBuilder()
+	specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		filter := files.NewPathFilter[*spec.Doc](args)
+		specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, filter, specDocs)
+		if err != nil {
+			return err
+		}
+	}
+
+	var renderOptions []html.Option
+	if outputRoot != "" {
+		outputRoot, err = filepath.Abs(outputRoot)
+		if err != nil {
+			return err
+		}
+		renderOptions = append(renderOptions, html.OutputRoot(outputRoot))
+	}
+	renderOptions = append(renderOptions, html.AnnotateTables(annotate))
+
+	renderer := html.NewRenderer(renderOptions...)
+	htmlDocs, err := pipeline.Process[*spec.Doc, string](cxt, pipelineOptions, renderer, specDocs)
+	if err != nil {
+		return err
+	}
+
+	writer := files.NewWriter[string]("Writing HTML", fileOptions)
+	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, writer, htmlDocs)
+	return
+}
+
+func init() {
+	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.Flags().String("out", "", "where to place the HTML files; if not set, each HTML file is written alongside its source document")
+	Command.Flags().Bool("annotate", false, "annotate conformance and constraint table cells with English descriptions")
+}
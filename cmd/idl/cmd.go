@@ -0,0 +1,93 @@
+package idl
+
+import (
+	"context"
+
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/errata"
+	"github.com/project-chip/alchemy/idl"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/scaffold"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "idl",
+	Short: "transmute the Matter spec into Matter IDL",
+	RunE:  generateIDL,
+}
+
+func generateIDL(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	specRoot, _ := cmd.Flags().GetString("specRoot")
+	idlRoot, _ := cmd.Flags().GetString("idlRoot")
+
+	errata.LoadErrataConfig(specRoot)
+
+	asciiSettings := common.ASCIIDocAttributes(cmd)
+	fileOptions := files.Flags(cmd)
+	pipelineOptions := pipeline.Flags(cmd)
+
+	specFiles, err := pipeline.Start[struct{}](cxt, spec.Targeter(specRoot))
+	if err != nil {
+		return err
+	}
+
+	docParser, err := spec.NewParser(specRoot, asciiSettings)
+	if err != nil {
+		return err
+	}
+	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
+	if err != nil {
+		return err
+	}
+
+	definitionPaths, _ := cmd.Flags().GetStringSlice("definition")
+	definitions, err := scaffold.ParseDefinitions(specRoot, definitionPaths, asciiSettings)
+	if err != nil {
+		return err
+	}
+	for _, d := range definitions {
+		specDocs.Store(d.Path, d)
+	}
+
+	vendorRoots, _ := cmd.Flags().GetStringSlice("vendorRoot")
+	vendorDocs, err := common.ParseVendorDocs(cxt, pipelineOptions, specRoot, vendorRoots, asciiSettings)
+	if err != nil {
+		return err
+	}
+	for _, d := range vendorDocs {
+		specDocs.Store(d.Path, d)
+	}
+
+	specBuilder := spec.NewBuilder()
+	specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
+	if err != nil {
+		return err
+	}
+
+	specDocs, err = common.FilterWithDefinitions(cxt, pipelineOptions, specDocs, args, definitions)
+	if err != nil {
+		return err
+	}
+
+	renderer := idl.NewRenderer(idlRoot)
+	idlDocs, err := pipeline.Process[*spec.Doc, string](cxt, pipelineOptions, renderer, specDocs)
+	if err != nil {
+		return err
+	}
+
+	writer := files.NewWriter[string]("Writing IDL", fileOptions)
+	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, writer, idlDocs)
+	return
+}
+
+func init() {
+	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.Flags().String("idlRoot", "idl", "where to place the .matter files")
+	Command.Flags().StringSlice("definition", nil, "a YAML or JSON cluster definition to generate from as though it were in the spec; this flag can be provided more than once")
+	Command.Flags().StringSlice("vendorRoot", nil, "the root of a vendor's manufacturer-specific cluster docs, laid out like the spec; this flag can be provided more than once")
+}
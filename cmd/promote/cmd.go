@@ -0,0 +1,70 @@
+package promote
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/project-chip/alchemy/asciidoc/render"
+	"github.com/project-chip/alchemy/cmd/common"
+	zapcmd "github.com/project-chip/alchemy/cmd/zap"
+	"github.com/project-chip/alchemy/disco"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/zap/generate"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:     "promote",
+	Short:   "move a cluster's bitmap, enum or struct into the global data types",
+	Example: `  alchemy promote --entity "Thermostat.ScheduleTransitionStruct" --sdkRoot ./connectedhomeip`,
+	RunE:    promoteDataType,
+}
+
+func init() {
+	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.Flags().String("entity", "", "the data type to promote, as Cluster.Name")
+	Command.Flags().String("target", "", "the global data types document to move the data type to; defaults to the document with the most global data types")
+	Command.Flags().String("sdkRoot", "", "the root of your clone of project-chip/connectedhomeip; if set, the affected ZAP templates and global objects are regenerated")
+	Command.Flags().Int("wrap", 0, "the maximum length of a line")
+	Command.MarkFlagRequired("entity")
+}
+
+func promoteDataType(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	specRoot, _ := cmd.Flags().GetString("specRoot")
+	entity, _ := cmd.Flags().GetString("entity")
+	target, _ := cmd.Flags().GetString("target")
+	sdkRoot, _ := cmd.Flags().GetString("sdkRoot")
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %v", args)
+	}
+
+	pipelineOptions := pipeline.Flags(cmd)
+	fileOptions := files.Flags(cmd)
+
+	var renderOptions []render.Option
+	if wrap, err := cmd.Flags().GetInt("wrap"); err == nil {
+		renderOptions = append(renderOptions, render.Wrap(wrap))
+	}
+
+	writer := files.NewWriter[string]("Writing promoted docs", fileOptions)
+	var clusterPaths []string
+	clusterPaths, err = disco.Promote(cxt, specRoot, entity, target, pipelineOptions, renderOptions, writer)
+	if err != nil || sdkRoot == "" {
+		return
+	}
+	if fileOptions.DryRun || fileOptions.Patch || fileOptions.Check {
+		slog.Warn("spec documents were not written; skipping ZAP regeneration")
+		return
+	}
+	zapOptions := zapcmd.Options{
+		SpecRoot:        specRoot,
+		SdkRoot:         sdkRoot,
+		DocPaths:        clusterPaths,
+		ASCIIAttributes: common.ASCIIDocAttributes(cmd),
+	}
+	return zapcmd.Generate(cxt, zapOptions, fileOptions, pipelineOptions, generate.GenerateFeatureXML(true))
+}
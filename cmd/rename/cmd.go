@@ -0,0 +1,51 @@
+package rename
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/project-chip/alchemy/asciidoc/render"
+	"github.com/project-chip/alchemy/disco"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "rename",
+	Short: "rename a data type, attribute, command, event, field or feature, along with every reference to it",
+	Example: `  alchemy rename --entity "Thermostat.SystemModeEnum" --to ThermostatSystemModeEnum
+  alchemy rename --entity "Thermostat.SetpointRaiseLower.Amount" --to Delta`,
+	RunE: renameEntity,
+}
+
+func init() {
+	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.Flags().String("entity", "", "the entity to rename, as Cluster.Name or Cluster.Name.Field; global data types are given by name alone")
+	Command.Flags().String("to", "", "the new name")
+	Command.Flags().Int("wrap", 0, "the maximum length of a line")
+	Command.MarkFlagRequired("entity")
+	Command.MarkFlagRequired("to")
+}
+
+func renameEntity(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	specRoot, _ := cmd.Flags().GetString("specRoot")
+	entity, _ := cmd.Flags().GetString("entity")
+	to, _ := cmd.Flags().GetString("to")
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %v", args)
+	}
+
+	pipelineOptions := pipeline.Flags(cmd)
+	fileOptions := files.Flags(cmd)
+
+	var renderOptions []render.Option
+	if wrap, err := cmd.Flags().GetInt("wrap"); err == nil {
+		renderOptions = append(renderOptions, render.Wrap(wrap))
+	}
+
+	writer := files.NewWriter[string]("Writing renamed docs", fileOptions)
+	return disco.Rename(cxt, specRoot, entity, to, pipelineOptions, renderOptions, writer)
+}
@@ -0,0 +1,118 @@
+package scaffold
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/scaffold"
+	"github.com/project-chip/alchemy/zap/parse"
+	"github.com/spf13/cobra"
+)
+
+var clusterCommand = &cobra.Command{
+	Use:   "cluster",
+	Short: "create a new cluster document and add it to its domain's app cluster index",
+	Example: `  alchemy new cluster --name "Foo Bar" --id 0xFFF1 --domain Appliances
+  alchemy new cluster --definition foo-bar.yaml
+  alchemy new cluster --zap connectedhomeip/src/app/zap-templates/zcl/data-model/chip/foo-bar-cluster.xml --domain General`,
+	RunE: newCluster,
+}
+
+func init() {
+	clusterCommand.Flags().String("definition", "", "a YAML or JSON cluster definition to create the document from")
+	clusterCommand.Flags().String("zap", "", "a ZAP template to create the document from; if it has more than one cluster, --id picks which")
+	clusterCommand.Flags().String("pics", "", "the PICS code of the new cluster; defaults to the upper-cased name")
+	clusterCommand.Flags().String("hierarchy", "Base", "the hierarchy of the new cluster")
+	clusterCommand.Flags().String("role", "Application", "the role of the new cluster")
+	clusterCommand.Flags().String("scope", "Endpoint", "the scope of the new cluster")
+}
+
+func newCluster(cmd *cobra.Command, args []string) (err error) {
+	var def *scaffold.ClusterDefinition
+	definitionPath, _ := cmd.Flags().GetString("definition")
+	zapPath, _ := cmd.Flags().GetString("zap")
+	if definitionPath != "" && zapPath != "" {
+		return fmt.Errorf("--definition and --zap can not be used together")
+	}
+	if definitionPath != "" || zapPath != "" {
+		if definitionPath != "" {
+			def, err = scaffold.ReadDefinition(definitionPath)
+		} else {
+			def, err = readZAPDefinition(cmd, zapPath)
+		}
+		if err != nil {
+			return
+		}
+		// Flags given on the command line override the definition
+		for flag, value := range map[string]*string{"name": &def.Name, "id": &def.ID, "domain": &def.Domain, "pics": &def.PICS, "hierarchy": &def.Hierarchy, "role": &def.Role, "scope": &def.Scope} {
+			if cmd.Flags().Changed(flag) {
+				*value, _ = cmd.Flags().GetString(flag)
+			}
+		}
+	} else {
+		def = &scaffold.ClusterDefinition{}
+		def.Name, _ = cmd.Flags().GetString("name")
+		def.ID, _ = cmd.Flags().GetString("id")
+		def.Domain, _ = cmd.Flags().GetString("domain")
+		def.PICS, _ = cmd.Flags().GetString("pics")
+		def.Hierarchy, _ = cmd.Flags().GetString("hierarchy")
+		def.Role, _ = cmd.Flags().GetString("role")
+		def.Scope, _ = cmd.Flags().GetString("scope")
+	}
+	flags, err := getNewDocFlags(cmd, args, def.Name, def.ID, def.Domain)
+	if err != nil {
+		return
+	}
+	def.Name = flags.name
+	def.ID = flags.id.HexString()
+	return writeNewDoc(cmd, flags, "app_clusters", scaffold.Cluster(def))
+}
+
+func readZAPDefinition(cmd *cobra.Command, path string) (*scaffold.ClusterDefinition, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	zapParser := parse.NewZapParser()
+	outputs, _, err := zapParser.Process(context.Background(), pipeline.NewData(path, b), 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	zapParser.ResolveReferences()
+
+	var clusters []*matter.Cluster
+	for _, o := range outputs {
+		for _, e := range o.Content {
+			switch e := e.(type) {
+			case *matter.ClusterGroup:
+				clusters = append(clusters, e.Clusters...)
+			case *matter.Cluster:
+				clusters = append(clusters, e)
+			}
+		}
+	}
+	var id *matter.Number
+	if cmd.Flags().Changed("id") {
+		idText, _ := cmd.Flags().GetString("id")
+		id = matter.ParseNumber(idText)
+	}
+	var names []string
+	for _, c := range clusters {
+		if id != nil && c.ID.Equals(id) || id == nil && len(clusters) == 1 {
+			return scaffold.ZAPDefinition(c), nil
+		}
+		names = append(names, fmt.Sprintf("%s (%s)", c.Name, c.ID.HexString()))
+	}
+	switch {
+	case len(clusters) == 0:
+		return nil, fmt.Errorf("no clusters found in %s", path)
+	case id != nil:
+		return nil, fmt.Errorf("no cluster with ID %s in %s; found %s", id.HexString(), path, strings.Join(names, ", "))
+	default:
+		return nil, fmt.Errorf("%s has more than one cluster; use --id to pick one of %s", path, strings.Join(names, ", "))
+	}
+}
@@ -0,0 +1,111 @@
+package scaffold
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/scaffold"
+	"github.com/project-chip/alchemy/zap"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "new",
+	Short: "create a new cluster or device type document",
+}
+
+func init() {
+	Command.PersistentFlags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.PersistentFlags().String("name", "", "the name of the new entity, e.g. \"Foo Bar\"")
+	Command.PersistentFlags().String("id", "", "the ID of the new entity, e.g. 0xFFF1")
+	Command.PersistentFlags().String("domain", "", "the domain whose index the new document is added to, e.g. Appliances")
+
+	Command.AddCommand(clusterCommand)
+	Command.AddCommand(deviceTypeCommand)
+}
+
+type newDocFlags struct {
+	specRoot string
+	name     string
+	id       *matter.Number
+	domain   matter.Domain
+}
+
+func getNewDocFlags(cmd *cobra.Command, args []string, name string, id string, domain string) (flags newDocFlags, err error) {
+	if len(args) > 0 {
+		err = fmt.Errorf("unexpected arguments: %v", args)
+		return
+	}
+	flags.specRoot, _ = cmd.Flags().GetString("specRoot")
+	flags.name = strings.TrimSpace(name)
+	if flags.name == "" {
+		err = fmt.Errorf("missing name")
+		return
+	}
+	flags.id = matter.ParseNumber(id)
+	if !flags.id.Valid() {
+		err = fmt.Errorf("invalid ID: %q", id)
+		return
+	}
+	flags.domain = zap.StringToDomain(domain)
+	if flags.domain == matter.DomainUnknown {
+		var names []string
+		for d, n := range matter.DomainNames {
+			if d != matter.DomainUnknown {
+				names = append(names, n)
+			}
+		}
+		err = fmt.Errorf("unknown domain %q; expected one of %s", domain, strings.Join(names, ", "))
+	}
+	return
+}
+
+func writeNewDoc(cmd *cobra.Command, flags newDocFlags, dir string, content string) error {
+	cxt := context.Background()
+
+	dir = filepath.Join(flags.specRoot, "src", dir)
+	docPath := filepath.Join(dir, strings.Join(strings.Fields(flags.name), "")+".adoc")
+	exists, err := files.Exists(docPath)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%s already exists", docPath)
+	}
+
+	docs := pipeline.NewMap[string, *pipeline.Data[string]]()
+	docs.Store(docPath, pipeline.NewData(docPath, content))
+
+	indexPath, err := scaffold.FindIndex(dir, flags.domain)
+	if err != nil {
+		slog.Warn("Unable to register new document in index", "path", docPath, "error", err)
+	} else {
+		var index string
+		var changed bool
+		index, changed, err = scaffold.Register(indexPath, docPath)
+		if err != nil {
+			return err
+		}
+		if changed {
+			docs.Store(indexPath, pipeline.NewData(indexPath, index))
+		}
+	}
+
+	fileOptions := files.Flags(cmd)
+	writer := files.NewWriter[string]("Writing new docs", fileOptions)
+	_, err = pipeline.Process[string, struct{}](cxt, pipeline.Flags(cmd), writer, docs)
+	if err != nil {
+		return err
+	}
+	if !fileOptions.DryRun && !fileOptions.Patch && !fileOptions.Check {
+		fmt.Fprintf(os.Stderr, "Created %s\n", docPath)
+	}
+	return nil
+}
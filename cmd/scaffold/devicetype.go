@@ -0,0 +1,34 @@
+package scaffold
+
+import (
+	"github.com/project-chip/alchemy/scaffold"
+	"github.com/spf13/cobra"
+)
+
+var deviceTypeCommand = &cobra.Command{
+	Use:     "devicetype",
+	Short:   "create a new device type document and add it to its domain's device type index",
+	Example: `  alchemy new devicetype --name "Foo Bar Appliance" --id 0xFFF1 --domain Appliances`,
+	RunE:    newDeviceType,
+}
+
+func init() {
+	deviceTypeCommand.Flags().String("superset", "", "the device type the new device type is a superset of")
+	deviceTypeCommand.Flags().String("class", "Simple", "the class of the new device type")
+	deviceTypeCommand.Flags().String("scope", "Endpoint", "the scope of the new device type")
+}
+
+func newDeviceType(cmd *cobra.Command, args []string) (err error) {
+	name, _ := cmd.Flags().GetString("name")
+	id, _ := cmd.Flags().GetString("id")
+	domain, _ := cmd.Flags().GetString("domain")
+	flags, err := getNewDocFlags(cmd, args, name, id, domain)
+	if err != nil {
+		return
+	}
+	settings := scaffold.DeviceTypeSettings{Name: flags.name, ID: flags.id}
+	settings.Superset, _ = cmd.Flags().GetString("superset")
+	settings.Class, _ = cmd.Flags().GetString("class")
+	settings.Scope, _ = cmd.Flags().GetString("scope")
+	return writeNewDoc(cmd, flags, "device_types", scaffold.DeviceType(settings))
+}
@@ -0,0 +1,74 @@
+package site
+
+import (
+	"context"
+
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/errata"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/site"
+	"github.com/spf13/cobra"
+)
+
+var Command = &cobra.Command{
+	Use:   "site [filename_pattern]",
+	Short: "generate a static, searchable HTML reference of the Matter data model",
+	RunE:  generateSite,
+}
+
+func generateSite(cmd *cobra.Command, args []string) (err error) {
+	cxt := context.Background()
+
+	specRoot, _ := cmd.Flags().GetString("specRoot")
+	outputRoot, _ := cmd.Flags().GetString("out")
+
+	errata.LoadErrataConfig(specRoot)
+
+	asciiSettings := common.ASCIIDocAttributes(cmd)
+	fileOptions := files.Flags(cmd)
+	pipelineOptions := pipeline.Flags(cmd)
+
+	specFiles, err := pipeline.Start[struct{}](cxt, spec.Targeter(specRoot))
+	if err != nil {
+		return err
+	}
+
+	docParser, err := spec.NewParser(specRoot, asciiSettings)
+	if err != nil {
+		return err
+	}
+	specDocs, err := pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docParser, specFiles)
+	if err != nil {
+		return err
+	}
+	specBuilder := spec.NewBuilder()
+	specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		filter := files.NewPathFilter[*spec.Doc](args)
+		specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, filter, specDocs)
+		if err != nil {
+			return err
+		}
+	}
+
+	generator := site.NewGenerator(outputRoot, specBuilder.Spec)
+	pages, err := pipeline.Process[*spec.Doc, string](cxt, pipelineOptions, generator, specDocs)
+	if err != nil {
+		return err
+	}
+
+	writer := files.NewWriter[string]("Writing reference site", fileOptions)
+	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, writer, pages)
+	return
+}
+
+func init() {
+	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
+	Command.Flags().String("out", "site", "where to place the generated site")
+}
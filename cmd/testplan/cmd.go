@@ -27,6 +27,9 @@ func init() {
 	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
 	Command.Flags().String("testRoot", "chip-test-plans", "the root of your clone of CHIP-Specifications/chip-test-plans")
 	Command.Flags().Bool("overwrite", false, "overwrite existing test plans")
+	Command.Flags().Bool("merge", false, "update the PICS definitions and generated test procedures of existing test plans, keeping their test cases")
+	Command.Flags().Bool("pics", false, "also generate PICS XML for each cluster")
+	Command.Flags().Bool("python", false, "also generate Python test skeletons for each cluster")
 }
 
 func tp(cmd *cobra.Command, args []string) (err error) {
@@ -36,6 +39,9 @@ func tp(cmd *cobra.Command, args []string) (err error) {
 	specRoot, _ := cmd.Flags().GetString("specRoot")
 	testRoot, _ := cmd.Flags().GetString("testRoot")
 	overwrite, _ := cmd.Flags().GetBool("overwrite")
+	merge, _ := cmd.Flags().GetBool("merge")
+	pics, _ := cmd.Flags().GetBool("pics")
+	python, _ := cmd.Flags().GetBool("python")
 
 	asciiSettings := common.ASCIIDocAttributes(cmd)
 	fileOptions := files.Flags(cmd)
@@ -91,7 +97,7 @@ func tp(cmd *cobra.Command, args []string) (err error) {
 		}
 	}
 
-	generator := testplan.NewGenerator(testRoot, overwrite)
+	generator := testplan.NewGenerator(testRoot, overwrite, merge)
 	var testplans pipeline.Map[string, *pipeline.Data[string]]
 	testplans, err = pipeline.Process[*spec.Doc, string](cxt, pipelineOptions, generator, specDocs)
 	if err != nil {
@@ -126,5 +132,33 @@ func tp(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
+	if pics {
+		picsGenerator := testplan.NewPICSGenerator(testRoot, overwrite)
+		var picsFiles pipeline.Map[string, *pipeline.Data[string]]
+		picsFiles, err = pipeline.Process[*spec.Doc, string](cxt, pipelineOptions, picsGenerator, specDocs)
+		if err != nil {
+			return err
+		}
+		picsWriter := files.NewWriter[string]("Writing PICS", fileOptions)
+		_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, picsWriter, picsFiles)
+		if err != nil {
+			return err
+		}
+	}
+
+	if python {
+		pythonGenerator := testplan.NewPythonGenerator(testRoot, overwrite)
+		var pythonFiles pipeline.Map[string, *pipeline.Data[string]]
+		pythonFiles, err = pipeline.Process[*spec.Doc, string](cxt, pipelineOptions, pythonGenerator, specDocs)
+		if err != nil {
+			return err
+		}
+		pythonWriter := files.NewWriter[string]("Writing Python tests", fileOptions)
+		_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, pythonWriter, pythonFiles)
+		if err != nil {
+			return err
+		}
+	}
+
 	return
 }
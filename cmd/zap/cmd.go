@@ -2,7 +2,9 @@ package zap
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/project-chip/alchemy/cmd/common"
 	"github.com/project-chip/alchemy/errata"
@@ -11,6 +13,7 @@ import (
 	"github.com/project-chip/alchemy/internal/pipeline"
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/scaffold"
 	"github.com/project-chip/alchemy/zap"
 	"github.com/project-chip/alchemy/zap/generate"
 	"github.com/spf13/cobra"
@@ -26,22 +29,50 @@ func init() {
 	Command.Flags().String("specRoot", "connectedhomeip-spec", "the src root of your clone of CHIP-Specifications/connectedhomeip-spec")
 	Command.Flags().String("sdkRoot", "connectedhomeip", "the root of your clone of project-chip/connectedhomeip")
 	Command.Flags().Bool("featureXML", true, "write new style feature XML")
-	Command.Flags().Bool("conformanceXML", false, "write new style conformance XML")
+	Command.Flags().Bool("conformanceXML", true, "write new style conformance XML")
+	Command.Flags().Bool("overwrite", false, "regenerate existing ZAP templates and device types from scratch instead of amending them")
+	Command.Flags().Bool("check", false, "compare the generated ZAP templates, device types, namespaces, cluster list and zcl files against the SDK without writing them, listing the element-level differences in each stale file, and exit with an error if there are any")
+	Command.Flags().Bool("removeOrphans", false, "remove ZAP templates that are no longer generated from the spec, and their registrations in the SDK; without this flag, they're only reported")
+	Command.Flags().StringSlice("definition", nil, "a YAML or JSON cluster definition to generate from as though it were in the spec; this flag can be provided more than once")
+	Command.Flags().StringSlice("vendorRoot", nil, "the root of a vendor's manufacturer-specific cluster docs, laid out like the spec; this flag can be provided more than once")
 }
 
 func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 
 	cxt := context.Background()
 
-	specRoot, _ := cmd.Flags().GetString("specRoot")
-	sdkRoot, _ := cmd.Flags().GetString("sdkRoot")
+	var options Options
+	options.SpecRoot, _ = cmd.Flags().GetString("specRoot")
+	options.SdkRoot, _ = cmd.Flags().GetString("sdkRoot")
+	options.DocPaths = args
+	options.DefinitionPaths, _ = cmd.Flags().GetStringSlice("definition")
+	options.VendorRoots, _ = cmd.Flags().GetStringSlice("vendorRoot")
+	options.RemoveOrphans, _ = cmd.Flags().GetBool("removeOrphans")
+	options.ASCIIAttributes = common.ASCIIDocAttributes(cmd)
 
-	errata.LoadErrataConfig(specRoot)
-
-	asciiSettings := common.ASCIIDocAttributes(cmd)
 	fileOptions := files.Flags(cmd)
 	pipelineOptions := pipeline.Flags(cmd)
 
+	var templateOptions []generate.TemplateOption
+	featureXML, _ := cmd.Flags().GetBool("featureXML")
+	templateOptions = append(templateOptions, generate.GenerateFeatureXML(featureXML))
+	conformanceXML, _ := cmd.Flags().GetBool("conformanceXML")
+	templateOptions = append(templateOptions, generate.GenerateConformanceXML(conformanceXML))
+	overwrite, _ := cmd.Flags().GetBool("overwrite")
+	templateOptions = append(templateOptions, generate.Overwrite(overwrite))
+
+	return Generate(cxt, options, fileOptions, pipelineOptions, templateOptions...)
+}
+
+// Generate writes the ZAP templates, global objects, device types, namespaces and cluster list for the spec
+func Generate(cxt context.Context, options Options, fileOptions files.Options, pipelineOptions pipeline.Options, templateOptions ...generate.TemplateOption) (err error) {
+
+	specRoot := options.SpecRoot
+	sdkRoot := options.SdkRoot
+	asciiSettings := options.ASCIIAttributes
+
+	errata.LoadErrataConfig(specRoot)
+
 	specFiles, err := pipeline.Start[struct{}](cxt, spec.Targeter(specRoot))
 	if err != nil {
 		return err
@@ -56,6 +87,22 @@ func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
+	definitions, err := scaffold.ParseDefinitions(specRoot, options.DefinitionPaths, asciiSettings)
+	if err != nil {
+		return err
+	}
+	for _, d := range definitions {
+		specDocs.Store(d.Path, d)
+	}
+
+	vendorDocs, err := common.ParseVendorDocs(cxt, pipelineOptions, specRoot, options.VendorRoots, asciiSettings)
+	if err != nil {
+		return err
+	}
+	for _, d := range vendorDocs {
+		specDocs.Store(d.Path, d)
+	}
+
 	specBuilder := spec.NewBuilder()
 	specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, specDocs)
 	if err != nil {
@@ -81,12 +128,10 @@ func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 		return
 	})
 
-	if len(args) > 0 { // Filter the spec by whatever extra args were passed
-		filter := files.NewPathFilter[*spec.Doc](args)
-		specDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, filter, specDocs)
-		if err != nil {
-			return err
-		}
+	allDocs := specDocs
+	specDocs, err = common.FilterWithDefinitions(cxt, pipelineOptions, specDocs, options.DocPaths, definitions)
+	if err != nil {
+		return err
 	}
 
 	var clusters pipeline.Map[string, *pipeline.Data[*spec.Doc]]
@@ -97,11 +142,6 @@ func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 		return err
 	}
 
-	var templateOptions []generate.TemplateOption
-	featureXML, _ := cmd.Flags().GetBool("featureXML")
-	templateOptions = append(templateOptions, generate.GenerateFeatureXML(featureXML))
-	conformanceXML, _ := cmd.Flags().GetBool("conformanceXML")
-	templateOptions = append(templateOptions, generate.GenerateConformanceXML(conformanceXML))
 	templateOptions = append(templateOptions, generate.AsciiAttributes(asciiSettings))
 	templateOptions = append(templateOptions, generate.SpecRoot(specRoot))
 
@@ -109,8 +149,8 @@ func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 	var globalObjectFiles pipeline.Map[string, *pipeline.Data[string]]
 	var provisionalZclFiles pipeline.Map[string, *pipeline.Data[struct{}]]
 	var clusterAliases pipeline.Map[string, []string]
+	templateGenerator := generate.NewTemplateGenerator(specBuilder.Spec, fileOptions, pipelineOptions, sdkRoot, templateOptions...)
 	if clusters.Size() > 0 {
-		templateGenerator := generate.NewTemplateGenerator(specBuilder.Spec, fileOptions, pipelineOptions, sdkRoot, templateOptions...)
 		zapTemplateDocs, err = pipeline.Process[*spec.Doc, string](cxt, pipelineOptions, templateGenerator, clusters)
 		if err != nil {
 			return err
@@ -128,7 +168,7 @@ func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 
 	var patchedDeviceTypes pipeline.Map[string, *pipeline.Data[[]byte]]
 	if deviceTypes.Size() > 0 {
-		deviceTypePatcher := generate.NewDeviceTypesPatcher(sdkRoot, specBuilder.Spec, clusterAliases)
+		deviceTypePatcher := generate.NewDeviceTypesPatcher(sdkRoot, specBuilder.Spec, clusterAliases, templateGenerator.Overwrite())
 		patchedDeviceTypes, err = pipeline.Process[[]*matter.DeviceType, []byte](cxt, pipelineOptions, deviceTypePatcher, deviceTypes)
 		if err != nil {
 			return err
@@ -163,6 +203,48 @@ func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 
 	}
 
+	var orphanPatcher *generate.OrphanPatcher
+	var orphanDocs pipeline.Map[string, *pipeline.Data[[]byte]]
+	if len(options.DocPaths) == 0 || options.RemoveOrphans {
+		var allClusters pipeline.Map[string, *pipeline.Data[*spec.Doc]]
+		allClusters, _, _, err = generate.SplitZAPDocs(cxt, allDocs)
+		if err != nil {
+			return err
+		}
+		orphanPatcher = generate.NewOrphanPatcher(sdkRoot, provisionalDocs, clusterList)
+		orphanDocs, err = pipeline.Process[*spec.Doc, []byte](cxt, pipelineOptions, orphanPatcher, allClusters)
+		if err != nil {
+			return err
+		}
+		if options.RemoveOrphans || fileOptions.Check {
+			// The orphan patcher amended the registry files the other patchers produced, so its versions win
+			orphanDocs.Range(func(key string, value *pipeline.Data[[]byte]) bool {
+				for _, m := range []pipeline.Map[string, *pipeline.Data[[]byte]]{provisionalDocs, clusterList} {
+					if m != nil {
+						m.Delete(key)
+					}
+				}
+				return true
+			})
+		} else {
+			reportOrphans(orphanPatcher)
+			orphanDocs = nil
+		}
+	}
+
+	if fileOptions.Check {
+		err = checkOutputs(cxt, pipelineOptions, []pipeline.Map[string, *pipeline.Data[string]]{zapTemplateDocs, globalObjectFiles}, []pipeline.Map[string, *pipeline.Data[[]byte]]{provisionalDocs, patchedDeviceTypes, patchedNamespaces, clusterList, orphanDocs})
+		if orphanPatcher != nil && len(orphanPatcher.Orphans) > 0 {
+			for _, o := range orphanPatcher.Orphans {
+				fmt.Fprintf(os.Stdout, "%s: orphaned\n", o)
+			}
+			if err == nil {
+				err = fmt.Errorf("%d orphaned ZAP templates", len(orphanPatcher.Orphans))
+			}
+		}
+		return err
+	}
+
 	stringWriter := files.NewWriter[string]("", fileOptions)
 	if zapTemplateDocs != nil && zapTemplateDocs.Size() > 0 {
 		stringWriter.SetName("Writing ZAP templates")
@@ -213,10 +295,73 @@ func zapTemplates(cmd *cobra.Command, args []string) (err error) {
 			return err
 		}
 	}
+
+	if orphanDocs != nil && orphanDocs.Size() > 0 {
+		byteWriter.SetName("Removing orphaned registrations")
+		_, err = pipeline.Process[[]byte, struct{}](cxt, pipelineOptions, byteWriter, orphanDocs)
+		if err != nil {
+			return err
+		}
+	}
+	if options.RemoveOrphans && orphanPatcher != nil {
+		err = removeOrphanFiles(orphanPatcher.Orphans, fileOptions)
+	}
 	return
 
 }
 
+func reportOrphans(orphanPatcher *generate.OrphanPatcher) {
+	for _, o := range orphanPatcher.Orphans {
+		slog.Warn("Orphaned ZAP template; use --removeOrphans to remove it", "path", o)
+	}
+	for path, entries := range orphanPatcher.Registrations {
+		for _, e := range entries {
+			slog.Warn("Stale ZAP template registration; use --removeOrphans to remove it", "path", path, "entry", e)
+		}
+	}
+}
+
+func removeOrphanFiles(orphans []string, fileOptions files.Options) error {
+	for _, o := range orphans {
+		if fileOptions.DryRun || fileOptions.Patch {
+			fmt.Fprintf(os.Stderr, "Skipping removal of %s...\n", o)
+			continue
+		}
+		slog.Info("Removing orphaned ZAP template", "path", o)
+		err := os.Remove(o)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkOutputs compares everything that would be written against the SDK in a single pass, so every stale file is listed
+func checkOutputs(cxt context.Context, pipelineOptions pipeline.Options, stringOutputs []pipeline.Map[string, *pipeline.Data[string]], byteOutputs []pipeline.Map[string, *pipeline.Data[[]byte]]) (err error) {
+	outputs := pipeline.NewMap[string, *pipeline.Data[[]byte]]()
+	for _, m := range stringOutputs {
+		if m == nil {
+			continue
+		}
+		m.Range(func(key string, value *pipeline.Data[string]) bool {
+			outputs.Store(value.Path, pipeline.NewData(value.Path, []byte(value.Content)))
+			return true
+		})
+	}
+	for _, m := range byteOutputs {
+		if m == nil {
+			continue
+		}
+		m.Range(func(key string, value *pipeline.Data[[]byte]) bool {
+			outputs.Store(value.Path, value)
+			return true
+		})
+	}
+	checker := files.NewSemanticChecker[[]byte]("Checking ZAP templates", os.Stdout)
+	_, err = pipeline.Process[[]byte, struct{}](cxt, pipelineOptions, checker, outputs)
+	return
+}
+
 func patchSpec(spec *spec.Specification) {
 	/* This is a hacky workaround for a spec problem: SemanticTagStruct is defined twice, in two different ways.
 	The first is a global struct that's used by the Descriptor cluster
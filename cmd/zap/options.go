@@ -0,0 +1,20 @@
+package zap
+
+import "github.com/project-chip/alchemy/asciidoc"
+
+// Options are the inputs to Generate
+type Options struct {
+	SpecRoot string
+	SdkRoot  string
+
+	// DocPaths limits generation to these spec docs; if there are neither these nor definitions, the whole spec is generated
+	DocPaths []string
+	// DefinitionPaths are YAML or JSON cluster definitions, generated as though they were in the spec
+	DefinitionPaths []string
+	// VendorRoots hold manufacturer-specific cluster docs, laid out like the spec
+	VendorRoots []string
+	// RemoveOrphans removes templates the spec no longer generates, and their registrations, instead of reporting them
+	RemoveOrphans bool
+
+	ASCIIAttributes []asciidoc.AttributeName
+}
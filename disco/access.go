@@ -68,6 +68,9 @@ func (b *Ball) fixAccessCells(dp *docParse, subSection *subSection, entityType t
 		}
 		replacementAccess := spec.AccessToASCIIDocString(access, entityType)
 		if vc != replacementAccess {
+			if b.violation(RuleFormatAccess, accessCell, "access %q should be %q", strings.TrimSpace(vc), replacementAccess) {
+				continue
+			}
 			err = setCellString(accessCell, replacementAccess)
 			if err != nil {
 				return
@@ -125,7 +125,7 @@ func (p AnchorNormalizer) Process(cxt context.Context, inputs []*pipeline.Data[*
 	return
 }
 
-func (AnchorNormalizer) normalizeAnchors(inputs []*pipeline.Data[*spec.Doc]) (anchorGroups map[*spec.DocGroup]*anchorGroup, err error) {
+func (p AnchorNormalizer) normalizeAnchors(inputs []*pipeline.Data[*spec.Doc]) (anchorGroups map[*spec.DocGroup]*anchorGroup, err error) {
 	anchorGroups = make(map[*spec.DocGroup]*anchorGroup)
 	unaffiliatedDocs := spec.NewDocGroup("")
 	for _, input := range inputs {
@@ -152,9 +152,14 @@ func (AnchorNormalizer) normalizeAnchors(inputs []*pipeline.Data[*spec.Doc]) (an
 			return
 		}
 
+		enabled := p.enabled(doc)
 		for _, as := range da {
 			for _, a := range as {
 				id := a.ID
+				if !enabled {
+					ag.updatedAnchors[id] = append(ag.updatedAnchors[id], a)
+					continue
+				}
 				newID := normalizeAnchor(a)
 				if id == newID {
 					ag.updatedAnchors[id] = append(ag.updatedAnchors[id], a)
@@ -174,6 +179,39 @@ func (AnchorNormalizer) normalizeAnchors(inputs []*pipeline.Data[*spec.Doc]) (an
 	return
 }
 
+// enabled applies the disco options to a ball for the document, so that the rule configuration can turn anchor normalization off for it
+func (p AnchorNormalizer) enabled(doc *spec.Doc) bool {
+	b := NewBall(doc)
+	for _, option := range p.discoOptions {
+		option(b)
+	}
+	return b.options.normalizeAnchors
+}
+
+// lint reports the anchors that would be renamed by normalization, without changing them
+func (p AnchorNormalizer) lint(inputs []*pipeline.Data[*spec.Doc]) (findings []Finding, err error) {
+	var anchorGroups map[*spec.DocGroup]*anchorGroup
+	anchorGroups, err = p.normalizeAnchors(inputs)
+	if err != nil {
+		return
+	}
+	for _, ag := range anchorGroups {
+		for id, infos := range ag.updatedAnchors {
+			for _, info := range infos {
+				if info.ID == id {
+					continue
+				}
+				path, line := info.Source.Origin()
+				if info.Document != nil {
+					path = relativePath(info.Document, path)
+				}
+				findings = append(findings, Finding{Rule: RuleNormalizeAnchors, Path: path, Line: line, Message: fmt.Sprintf("anchor %s should be %s", info.ID, id)})
+			}
+		}
+	}
+	return
+}
+
 func normalizeAnchor(info *spec.Anchor) (id string) {
 	id = info.ID
 	if skipAnchor(info) {
@@ -105,7 +105,14 @@ func (b *Ball) linkIndexTables(cxt *discoContext, section *subSection) error {
 		if !ok {
 			label := normalizeAnchorLabel(name, nil)
 			id = normalizeAnchorID(name, nil)
+			if b.violation(RuleLinkIndexTables, cell, "%s should link to section %s", name, s.Name) {
+				continue
+			}
 			spec.NewAnchor(b.doc, id, s.Base, section.section, label...).SyncToDoc(id)
+		} else if isCrossReferenceTo(cell, id) {
+			continue
+		} else if b.violation(RuleLinkIndexTables, cell, "%s should link to section %s", name, s.Name) {
+			continue
 		}
 		icr := asciidoc.NewCrossReference(id)
 		err := cell.SetElements(asciidoc.Set{icr})
@@ -117,3 +124,12 @@ func (b *Ball) linkIndexTables(cxt *discoContext, section *subSection) error {
 
 	return nil
 }
+
+func isCrossReferenceTo(cell *asciidoc.TableCell, id string) bool {
+	els := cell.Elements()
+	if len(els) != 1 {
+		return false
+	}
+	xref, ok := els[0].(*asciidoc.CrossReference)
+	return ok && xref.ID == id
+}
@@ -63,6 +63,9 @@ func (b *Ball) organizeBitmapSection(cxt *discoContext, dp *docParse, bms *subSe
 var bitRangePattern = regexp.MustCompile(`^(?P<From>[0-9]+)(?<Separator>\.{2,}|\s*\-\s*)(?P<To>[0-9]+)$`)
 
 func (b *Ball) fixBitmapRange(bms *subSection) {
+	if !b.options.fixBitmapRanges {
+		return
+	}
 	if b.errata.IgnoreSection(bms.section.Name, errata.DiscoPurposeDataTypeBitmapFixRange) {
 		return
 	}
@@ -91,13 +94,17 @@ func (b *Ball) fixBitmapRange(bms *subSection) {
 		if err != nil {
 			continue
 		}
+		var fixed string
 		if start < end {
-			setCellString(cell, fmt.Sprintf("%d..%d", end, start))
+			fixed = fmt.Sprintf("%d..%d", end, start)
+		} else if strings.TrimSpace(matches[2]) == "-" {
+			fixed = fmt.Sprintf("%d..%d", start, end)
+		} else {
 			continue
 		}
-		if strings.TrimSpace(matches[2]) == "-" {
-			setCellString(cell, fmt.Sprintf("%d..%d", start, end))
+		if b.violation(RuleFixBitmapRanges, cell, "bit range %q should be %q", bit, fixed) {
 			continue
 		}
+		setCellString(cell, fixed)
 	}
 }
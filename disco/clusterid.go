@@ -12,10 +12,12 @@ func (b *Ball) organizeClusterIDSection(cxt *discoContext, dp *docParse) (err er
 		if clusterIDsTable == nil || clusterIDsTable.Element == nil {
 			return fmt.Errorf("no cluster ID section found")
 		}
+		title := matter.ClusterIDSectionName
 		if len(clusterIDsTable.Element.TableRows()) > 2 {
-			setSectionTitle(clusterIDs.section, matter.ClusterIDsSectionName)
-		} else {
-			setSectionTitle(clusterIDs.section, matter.ClusterIDSectionName)
+			title = matter.ClusterIDsSectionName
+		}
+		if b.options.normalizeSectionNames && clusterIDs.section.Name != title && !b.violation(RuleNormalizeSectionNames, clusterIDs.section, "section %s should be named %s", clusterIDs.section.Name, title) {
+			setSectionTitle(clusterIDs.section, title)
 		}
 
 		if clusterIDsTable.ColumnMap == nil {
@@ -89,7 +89,14 @@ func (b *Ball) fixCommandDirection(section *spec.Section, rows []*asciidoc.Table
 		if e != nil {
 			continue
 		}
-		err = setCellString(cell, strings.ToLower(vc))
+		lower := strings.ToLower(vc)
+		if lower == vc {
+			continue
+		}
+		if b.violation(RuleFixCommandDirection, cell, "command direction %q should be %q", strings.TrimSpace(vc), strings.TrimSpace(lower)) {
+			continue
+		}
+		err = setCellString(cell, lower)
 		if err != nil {
 			return
 		}
@@ -35,7 +35,21 @@ func (b *Ball) fixConformanceCells(docParse *docParse, section *subSection, rows
 
 		cs := conf.ASCIIDocString()
 
+		if b.options.canonicalConformance {
+			var rewrite bool
+			cs, rewrite = b.canonicalConformance(cell, vc, conf)
+			if !rewrite {
+				continue
+			}
+		}
+
 		if cs != vc {
+			if !b.options.formatConformance && cs == conf.ASCIIDocString() {
+				continue
+			}
+			if b.violation(RuleFormatConformance, cell, "conformance %q should be %q", strings.TrimSpace(vc), cs) {
+				continue
+			}
 			err = setCellString(cell, cs)
 			if err != nil {
 				return
@@ -46,7 +60,32 @@ func (b *Ball) fixConformanceCells(docParse *docParse, section *subSection, rows
 	return
 }
 
-func disambiguateConformance(docParse *docParse) (err error) {
+// canonicalConformance simplifies the conformance in a cell, and returns the canonical form if the cell should be rewritten with it
+func (b *Ball) canonicalConformance(cell *asciidoc.TableCell, vc string, conf conformance.Set) (cs string, rewrite bool) {
+	formatted := conf.ASCIIDocString()
+	cs = conformance.Simplify(conf).ASCIIDocString()
+	if cs == strings.TrimSpace(vc) {
+		return cs, false
+	}
+	equivalent, verified := conformance.Equivalent(conf, conformance.ParseConformance(cs))
+	switch {
+	case verified && !equivalent:
+		b.warning(RuleCanonicalConformance, cell, "suspicious conformance rewrite: %q would become %q, which changes its meaning; left as is", strings.TrimSpace(vc), cs)
+	case cs == formatted:
+		// Only reformatted, which formatConformance reports
+		return cs, true
+	case verified:
+		if b.violation(RuleCanonicalConformance, cell, "semantic-preserving conformance rewrite: %q should be %q", strings.TrimSpace(vc), cs) {
+			return cs, false
+		}
+		return cs, true
+	default:
+		b.warning(RuleCanonicalConformance, cell, "suspicious conformance rewrite: %q would become %q, which can't be verified; left as is", strings.TrimSpace(vc), cs)
+	}
+	return cs, false
+}
+
+func (b *Ball) disambiguateConformance(docParse *docParse) (err error) {
 	globalChoices := make(map[string]string)
 	parse.Traverse(docParse.doc, docParse.doc.Elements(), func(table *asciidoc.Table, parent parse.HasElements, index int) parse.SearchShould {
 		ti, ok := docParse.tableCache[table]
@@ -111,6 +150,9 @@ func disambiguateConformance(docParse *docParse) (err error) {
 				}
 			}
 			if modified {
+				if b.violation(RuleDisambiguateConformanceChoice, cell, "conformance choice should be unique in document: %s", conf.ASCIIDocString()) {
+					continue
+				}
 				err = setCellString(cell, conf.ASCIIDocString())
 				if err != nil {
 					return parse.SearchShouldStop
@@ -2,6 +2,7 @@ package disco
 
 import (
 	"log/slog"
+	"strings"
 
 	"github.com/project-chip/alchemy/errata"
 	"github.com/project-chip/alchemy/matter"
@@ -11,7 +12,7 @@ import (
 )
 
 func (b *Ball) fixConstraintCells(section *spec.Section, ti *spec.TableInfo) (err error) {
-	if len(ti.Rows) < 2 {
+	if !b.options.formatConstraint || len(ti.Rows) < 2 {
 		return
 	}
 	if b.errata.IgnoreSection(section.Name, errata.DiscoPurposeTableConstraint) {
@@ -54,6 +55,9 @@ func (b *Ball) fixConstraintCells(section *spec.Section, ti *spec.TableInfo) (er
 		c = simplifyConstraints(c, dataType, quality)
 		fixed := c.ASCIIDocString(dataType)
 		if fixed != vc {
+			if b.violation(RuleFormatConstraint, cell, "constraint %q should be %q", strings.TrimSpace(vc), fixed) {
+				continue
+			}
 			err = setCellString(cell, fixed)
 			if err != nil {
 				return
@@ -259,6 +259,10 @@ func (b *Ball) promoteDataType(top *spec.Section, suffix string, dataTypeFields
 			continue
 		}
 
+		if b.violation(RulePromoteDataTypes, dt.section, "%s%s should be promoted to the Data Types section", dt.name, suffix) {
+			continue
+		}
+
 		summaryIndex, hasSummaryColumn := ti.ColumnMap[matter.TableColumnSummary]
 		if !hasSummaryColumn {
 			descriptionIndex, hasDescriptionColumn := ti.ColumnMap[matter.TableColumnDescription]
@@ -462,6 +466,9 @@ func disambiguateDataTypes(cxt *discoContext, infos []*DataTypeEntry) error {
 }
 
 func (b *Ball) canonicalizeDataTypeSectionName(dp *docParse, s *spec.Section, dataTypeName string) {
+	if !b.options.canonicalizeDataTypeNames {
+		return
+	}
 	if b.errata.IgnoreSection(s.Name, errata.DiscoPurposeDataTypeRename) {
 		return
 	}
@@ -481,6 +488,9 @@ func (b *Ball) canonicalizeDataTypeSectionName(dp *docParse, s *spec.Section, da
 	if name == newName {
 		return
 	}
+	if b.violation(RuleCanonicalizeDataTypeNames, s, "section %s should be named %s", name, newName) {
+		return
+	}
 	setSectionTitle(s, newName)
 	oldName := text.TrimCaseInsensitiveSuffix(name, " type")
 	newName = text.TrimCaseInsensitiveSuffix(newName, " type")
@@ -0,0 +1,237 @@
+package disco
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/errata"
+	"github.com/project-chip/alchemy/internal/parse"
+	"github.com/project-chip/alchemy/internal/text"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+func (b *Ball) organizeConditionsSection(cxt *discoContext, dp *docParse) (err error) {
+	for _, conditions := range dp.conditions {
+		conditionsTable := conditions.table
+		if conditionsTable == nil || conditionsTable.Element == nil {
+			continue
+		}
+		if conditionsTable.ColumnMap == nil {
+			slog.Debug("can't rearrange conditions table without header row", slog.String("path", dp.doc.Path.String()))
+			continue
+		}
+		if !b.options.normalizeDeviceTypeTables {
+			continue
+		}
+		err = b.renameTableHeaderCells(dp.doc, conditions.section, conditionsTable, nil)
+		if err != nil {
+			return
+		}
+		err = b.reorderColumns(dp.doc, conditions.section, conditionsTable, matter.TableTypeConditions)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (b *Ball) organizeRequirementsSections(cxt *discoContext, dp *docParse) (err error) {
+	err = b.organizeRequirementsSection(dp, dp.clusterRequirements, matter.TableTypeClusterRequirements)
+	if err != nil {
+		return
+	}
+	err = b.organizeRequirementsSection(dp, dp.elementRequirements, matter.TableTypeElementRequirements)
+	if err != nil {
+		return
+	}
+	return b.organizeRequirementsSection(dp, dp.composedDeviceTypeRequirements, matter.TableTypeComposedDeviceTypeRequirements)
+}
+
+func (b *Ball) organizeRequirementsSection(dp *docParse, requirements []*subSection, tableType matter.TableType) (err error) {
+	// When linting, columns that would be renamed to Cluster still have their old names
+	clusterColumns := []matter.TableColumn{matter.TableColumnCluster}
+	for from, to := range matter.Tables[tableType].ColumnNames {
+		if to == matter.TableColumnCluster {
+			clusterColumns = append(clusterColumns, from)
+		}
+	}
+	for _, requirement := range requirements {
+		requirementsTable := requirement.table
+		if requirementsTable == nil || requirementsTable.Element == nil {
+			continue
+		}
+		if requirementsTable.ColumnMap == nil {
+			slog.Debug("can't rearrange requirements table without header row", slog.String("path", dp.doc.Path.String()), slog.String("section", requirement.section.Name))
+			continue
+		}
+		if b.options.normalizeDeviceTypeTables {
+			err = b.renameTableHeaderCells(dp.doc, requirement.section, requirementsTable, matter.Tables[tableType].ColumnNames)
+			if err != nil {
+				return
+			}
+		}
+		err = b.fixConformanceCells(dp, requirement, requirementsTable.Rows, requirementsTable.ColumnMap)
+		if err != nil {
+			return
+		}
+		b.crossCheckClusters(requirement.section, requirementsTable, clusterColumns)
+		err = b.linkClusterNames(requirement.section, requirementsTable, clusterColumns)
+		if err != nil {
+			return
+		}
+		if b.options.normalizeDeviceTypeTables {
+			err = b.reorderColumns(dp.doc, requirement.section, requirementsTable, tableType)
+			if err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// crossCheckClusters makes sure that the cluster ID and the cluster name in each row of a requirements table refer to the same cluster
+func (b *Ball) crossCheckClusters(section *spec.Section, ti *spec.TableInfo, clusterColumns []matter.TableColumn) {
+	if !b.options.crossCheckClusters {
+		return
+	}
+	s := b.doc.Spec()
+	if s == nil {
+		return
+	}
+	clusterIndex, ok := ti.ColumnIndex(clusterColumns...)
+	if !ok {
+		return
+	}
+	for row := range ti.Body() {
+		name, xref, err := ti.ReadName(row, clusterColumns...)
+		if err != nil || name == "" {
+			continue
+		}
+		cell := row.Cell(clusterIndex)
+		named := b.findCluster(s, name, xref)
+		id, _ := ti.ReadID(row, matter.TableColumnClusterID, matter.TableColumnID)
+		if !id.Valid() {
+			if named == nil {
+				b.warning(RuleCrossCheckClusters, cell, "unknown cluster %q in section %s", name, section.Name)
+			}
+			continue
+		}
+		identified, ok := s.ClustersByID[id.Value()]
+		if !ok {
+			b.warning(RuleCrossCheckClusters, cell, "unknown cluster ID %s in section %s", id.HexString(), section.Name)
+			continue
+		}
+		if named != identified {
+			b.warning(RuleCrossCheckClusters, cell, "cluster ID %s in section %s is %s, not %s", id.HexString(), section.Name, identified.Name, name)
+		}
+	}
+}
+
+// linkClusterNames replaces plain cluster names in a requirements table with cross references to the cluster's section
+func (b *Ball) linkClusterNames(section *spec.Section, ti *spec.TableInfo, clusterColumns []matter.TableColumn) (err error) {
+	if !b.options.linkClusterNames {
+		return
+	}
+	if b.errata.IgnoreSection(section.Name, errata.DiscoPurposeTableLinkIndexes) {
+		return
+	}
+	s := b.doc.Spec()
+	if s == nil {
+		return
+	}
+	clusterIndex, ok := ti.ColumnIndex(clusterColumns...)
+	if !ok {
+		return
+	}
+	for row := range ti.Body() {
+		name, xref, e := ti.ReadName(row, clusterColumns...)
+		if e != nil || name == "" || xref != nil {
+			continue
+		}
+		cluster := b.findCluster(s, name, nil)
+		if cluster == nil {
+			continue
+		}
+		clusterID, _ := ti.ReadID(row, matter.TableColumnClusterID, matter.TableColumnID)
+		if clusterID.Valid() && (cluster.ID == nil || !cluster.ID.Equals(clusterID)) {
+			// The cross check will complain about this; we don't know which one is right
+			continue
+		}
+		id := clusterAnchorID(s, cluster)
+		if id == "" || b.doc.FindAnchor(id) == nil {
+			continue
+		}
+		cell := row.Cell(clusterIndex)
+		if b.violation(RuleLinkClusterNames, cell, "cluster %s in section %s should link to %s", name, section.Name, id) {
+			continue
+		}
+		icr := asciidoc.NewCrossReference(id)
+		icr.Set = asciidoc.Set{asciidoc.NewString(" " + name)}
+		err = cell.SetElements(asciidoc.Set{icr})
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// findCluster finds the cluster a requirements table refers to, either by following its cross reference or by its name
+func (b *Ball) findCluster(s *spec.Specification, name string, xref *asciidoc.CrossReference) *matter.Cluster {
+	if xref != nil {
+		if b.clustersByAnchor == nil {
+			b.clustersByAnchor = make(map[string]*matter.Cluster, len(s.Clusters))
+			for c := range s.Clusters {
+				if id := clusterAnchorID(s, c); id != "" {
+					b.clustersByAnchor[id] = c
+				}
+			}
+		}
+		return b.clustersByAnchor[xref.ID]
+	}
+	if c, ok := s.ClustersByName[name]; ok {
+		return c
+	}
+	name = text.TrimCaseInsensitiveSuffix(name, " Cluster")
+	for n, c := range s.ClustersByName {
+		if strings.EqualFold(n, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// clusterAnchorID finds the ID of the anchor on the section that defines a cluster
+func clusterAnchorID(s *spec.Specification, cluster *matter.Cluster) (id string) {
+	doc, ok := s.DocRefs[cluster]
+	if !ok {
+		return
+	}
+	source, ok := cluster.Source().(*asciidoc.Section)
+	if !ok {
+		return
+	}
+	parse.Search(doc.Elements(), func(section *spec.Section) parse.SearchShould {
+		if section.SecType != matter.SectionCluster {
+			return parse.SearchShouldContinue
+		}
+		if section.Base != source && !containsSection(section, source) {
+			return parse.SearchShouldSkip
+		}
+		id = spec.AnchorID(section.Base)
+		return parse.SearchShouldStop
+	})
+	return
+}
+
+func containsSection(section *spec.Section, base *asciidoc.Section) (found bool) {
+	parse.Search(section.Elements(), func(ss *spec.Section) parse.SearchShould {
+		if ss.Base == base {
+			found = true
+			return parse.SearchShouldStop
+		}
+		return parse.SearchShouldContinue
+	})
+	return
+}
@@ -0,0 +1,70 @@
+package disco
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/asciidoc/render"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+)
+
+// discoBallDoc writes the document into a copy of the rename spec, disco-balls it with the given options, and returns the result
+func discoBallDoc(t *testing.T, path string, doc string, discoOptions ...Option) string {
+	specRoot := t.TempDir()
+	err := os.CopyFS(specRoot, os.DirFS("testdata/rename"))
+	if err != nil {
+		t.Fatalf("failed copying spec: %v", err)
+	}
+	path = filepath.Join(specRoot, path)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("failed creating %s: %v", filepath.Dir(path), err)
+	}
+	err = os.WriteFile(path, []byte(doc), 0644)
+	if err != nil {
+		t.Fatalf("failed writing %s: %v", path, err)
+	}
+	err = Pipeline(context.Background(), specRoot, []string{path}, pipeline.Options{NoProgress: true}, discoOptions, []render.Option{}, files.NewWriter[string]("Writing disco-balled docs", files.Options{}))
+	if err != nil {
+		t.Fatalf("failed disco-balling: %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed reading %s: %v", path, err)
+	}
+	return string(b)
+}
+
+func TestDeviceTypeTables(t *testing.T) {
+	b, err := os.ReadFile("testdata/rename/src/device_types/WidgetDevice.adoc")
+	if err != nil {
+		t.Fatalf("failed reading device type: %v", err)
+	}
+	doc := strings.Replace(string(b), `| ID     | Cluster | Client/Server | Quality | Conformance
+| 0xFFF1 | Widget  | Server        |         | M
+| 0xFFF2 | Gadget  | Server        |         | M`, `| Name   | ID     | Client/Server | Quality | Conformance
+| Widget | 0xFFF1 | Server        |         | M
+| Gadget | 0xFFF2 | Server        |         | M`, 1)
+
+	unchanged := "| Name | ID | Client/Server | Quality | Conformance\n| Widget | 0xFFF1 | Server | | M"
+	if out := discoBallDoc(t, "src/device_types/WidgetDevice.adoc", doc); !strings.Contains(squeezeSpaces(out), unchanged) {
+		t.Errorf("expected cluster requirements to be left alone by default, got:\n%s", out)
+	}
+	normalized := "| ID | Cluster | Client/Server | Quality | Conformance\n| 0xFFF1 | Widget | Server | | M"
+	if out := discoBallDoc(t, "src/device_types/WidgetDevice.adoc", doc, NormalizeDeviceTypeTables(true)); !strings.Contains(squeezeSpaces(out), normalized) {
+		t.Errorf("expected cluster requirements to be normalized, got:\n%s", out)
+	}
+}
+
+// squeezeSpaces collapses runs of spaces, so tables can be compared without their padding
+func squeezeSpaces(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.Join(lines, "\n")
+}
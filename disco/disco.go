@@ -15,7 +15,11 @@ type Ball struct {
 	doc    *spec.Doc
 	errata *errata.Disco
 
-	options options
+	options  options
+	findings []Finding
+
+	config           *Config
+	clustersByAnchor map[string]*matter.Cluster
 }
 
 func NewBall(doc *spec.Doc) *Ball {
@@ -86,11 +90,16 @@ func (b *Ball) disco(cxt context.Context) error {
 	}
 
 	if b.options.disambiguateConformanceChoice {
-		err = disambiguateConformance(dp)
+		err = b.disambiguateConformance(dp)
 		if err != nil {
 			return fmt.Errorf("error disambiguating conformance in %s: %w", doc.Path, err)
 		}
 	}
+
+	err = b.applyRules(cxt, topLevelSection)
+	if err != nil {
+		return fmt.Errorf("error applying rules in %s: %w", doc.Path, err)
+	}
 	return nil
 }
 
@@ -101,20 +110,20 @@ func (b *Ball) discoBallTopLevelSection(doc *spec.Doc, top *spec.Section, docTyp
 			slog.Debug("could not determine section order", slog.String("path", doc.Path.Relative), slog.String("docType", docType.String()))
 
 		} else {
-			err := reorderSection(top, sectionOrder)
+			err := b.reorderSection(top, sectionOrder)
 			if err != nil {
 				return fmt.Errorf("error reordering sections in %s: %w", doc.Path, err)
 			}
 		}
 		dataTypesSection := spec.FindSectionByType(top, matter.SectionDataTypes)
 		if dataTypesSection != nil {
-			err := reorderSection(dataTypesSection, matter.DataTypeSectionOrder)
+			err := b.reorderSection(dataTypesSection, matter.DataTypeSectionOrder)
 			if err != nil {
 				return fmt.Errorf("error reordering data types section in %s: %w", doc.Path, err)
 			}
 		}
 	}
 	b.ensureTableOptions(top.Elements())
-	b.postCleanUpStrings(top.Elements())
+	b.postCleanUpStrings(top)
 	return nil
 }
@@ -43,6 +43,9 @@ func (b *Ball) organizeFeaturesSection(cxt *discoContext, dp *docParse) (err err
 				}
 				vc = strings.TrimSpace(vc)
 				if strings.Contains(vc, " ") {
+					if b.violation(RuleNormalizeFeatureNames, featureCell, "feature name %q should be %q", vc, matter.Case(vc)) {
+						continue
+					}
 					vc = matter.Case(vc)
 					slog.Debug("fixing feature name", "name", vc)
 					err = setCellString(featureCell, vc)
@@ -68,6 +71,9 @@ func (b *Ball) organizeFeaturesSection(cxt *discoContext, dp *docParse) (err err
 				vc = strings.TrimSpace(vc)
 				uc := strings.ToUpper(vc)
 				if uc != vc {
+					if b.violation(RuleNormalizeFeatureNames, codeCell, "feature code %q should be %q", vc, uc) {
+						continue
+					}
 					slog.Debug("fixing feature code", "name", vc)
 					err = setCellString(codeCell, uc)
 					if err != nil {
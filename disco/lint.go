@@ -0,0 +1,185 @@
+package disco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/internal/parse"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+const (
+	RuleLinkIndexTables               = "linkIndexTables"
+	RuleAddMissingColumns             = "addMissingColumns"
+	RuleReorderColumns                = "reorderColumns"
+	RuleRenameTableHeaders            = "renameTableHeaders"
+	RuleFormatAccess                  = "formatAccess"
+	RulePromoteDataTypes              = "promoteDataTypes"
+	RuleReorderSections               = "reorderSections"
+	RuleNormalizeTableOptions         = "normalizeTableOptions"
+	RuleFixCommandDirection           = "fixCommandDirection"
+	RuleAppendSubsectionTypes         = "appendSubsectionTypes"
+	RuleUppercaseHex                  = "uppercaseHex"
+	RuleAddSpaceAfterPunctuation      = "addSpaceAfterPunctuation"
+	RuleRemoveExtraSpaces             = "removeExtraSpaces"
+	RuleNormalizeFeatureNames         = "normalizeFeatureNames"
+	RuleDisambiguateConformanceChoice = "disambiguateConformanceChoice"
+	RuleNormalizeAnchors              = "normalizeAnchors"
+	RuleFormatConformance             = "formatConformance"
+	RuleFormatConstraint              = "formatConstraint"
+	RuleCanonicalizeDataTypeNames     = "canonicalizeDataTypeNames"
+	RuleFixBitmapRanges               = "fixBitmapRanges"
+	RuleNormalizeSectionNames         = "normalizeSectionNames"
+	RuleCanonicalConformance          = "canonicalConformance"
+	RuleCrossCheckClusters            = "crossCheckClusters"
+	RuleLinkClusterNames              = "linkClusterNames"
+	RuleSortNamespaceTags             = "sortNamespaceTags"
+	RuleNormalizeDeviceTypeTables     = "normalizeDeviceTypeTables"
+	RuleNormalizeNamespaceTables      = "normalizeNamespaceTables"
+)
+
+// Finding is a single violation of a disco-ball rule
+type Finding struct {
+	Rule    string
+	Path    string
+	Line    int
+	Message string
+}
+
+func (f Finding) String() string {
+	if f.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s (%s)", f.Path, f.Line, f.Message, f.Rule)
+	}
+	return fmt.Sprintf("%s: %s (%s)", f.Path, f.Message, f.Rule)
+}
+
+func SortFindings(findings []Finding) {
+	slices.SortStableFunc(findings, func(a Finding, b Finding) int {
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return strings.Compare(a.Rule, b.Rule)
+	})
+}
+
+// violation records a finding against the given element, and returns true if the ball is only linting, in which case the caller should not rewrite anything
+func (b *Ball) violation(rule string, source any, format string, args ...any) (lintOnly bool) {
+	path, line := b.origin(source)
+	return b.report(Finding{Rule: rule, Path: path, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// warning records a finding that disco-ball can not fix on its own, logging it if the ball is rewriting
+func (b *Ball) warning(rule string, source any, format string, args ...any) {
+	path, line := b.origin(source)
+	f := Finding{Rule: rule, Path: path, Line: line, Message: fmt.Sprintf(format, args...)}
+	if !b.report(f) {
+		slog.Warn(f.Message, slog.String("rule", rule), slog.String("path", path), slog.Int("line", line))
+	}
+}
+
+func (b *Ball) report(f Finding) (lintOnly bool) {
+	b.findings = append(b.findings, f)
+	if !b.options.lint {
+		slog.Debug("disco ball rewrite", slog.String("rule", f.Rule), slog.String("source", f.String()))
+	}
+	return b.options.lint
+}
+
+func (b *Ball) origin(source any) (path string, line int) {
+	path = b.doc.Path.Relative
+	switch s := source.(type) {
+	case *spec.Section:
+		source = s.Base
+	case *spec.TableInfo:
+		source = s.Element
+	}
+	if hp, ok := source.(asciidoc.HasPosition); ok {
+		line, _, _ = hp.Position()
+		if p := hp.Path(); p != "" {
+			path = relativePath(b.doc, p)
+		}
+	}
+	return
+}
+
+// relativePath makes a path from an element's position relative to the root of the spec the document belongs to
+func relativePath(doc *spec.Doc, path string) string {
+	if path == doc.Path.Absolute {
+		return doc.Path.Relative
+	}
+	root, ok := strings.CutSuffix(doc.Path.Absolute, doc.Path.Relative)
+	if !ok || !filepath.IsAbs(path) {
+		return path
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
+
+// stringLine estimates the line of an offset in a string, starting from the line of its parent and counting the lines that precede it
+func stringLine(parent any, line int, siblings asciidoc.Set, index int, s *asciidoc.String, offset int) int {
+	if s, ok := parent.(*spec.Section); ok {
+		// Section positions start at their attributes, and their elements start after the title
+		line += len(s.Base.AttributeList) + 1
+	}
+	for _, e := range siblings[:index] {
+		if be, ok := e.(parse.HasBase); ok {
+			e = be.GetBase()
+		}
+		switch e := e.(type) {
+		case *asciidoc.String:
+			line += strings.Count(e.Value, "\n")
+		case *asciidoc.NewLine, asciidoc.EmptyLine:
+			line++
+		case asciidoc.HasPosition:
+			line, _, _ = e.Position()
+		}
+	}
+	return line + strings.Count(s.Value[:offset], "\n")
+}
+
+type Linter struct {
+	discoOptions []Option
+}
+
+func NewLinter(discoOptions []Option) Linter {
+	return Linter{discoOptions: discoOptions}
+}
+
+func (r Linter) Name() string {
+	return "Linting"
+}
+
+func (r Linter) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeIndividual
+}
+
+func (r Linter) Process(cxt context.Context, input *pipeline.Data[*spec.Doc], index int32, total int32) (outputs []*pipeline.Data[[]Finding], extras []*pipeline.Data[*spec.Doc], err error) {
+	b := NewBall(input.Content)
+	for _, option := range r.discoOptions {
+		option(b)
+	}
+	b.options.lint = true
+	err = b.disco(cxt)
+	if err != nil {
+		if err == ErrEmptyDoc {
+			err = nil
+			return
+		}
+		slog.Warn("Error linting document", "path", input.Path, "error", err)
+		err = nil
+	}
+	outputs = append(outputs, pipeline.NewData(input.Path, b.findings))
+	return
+}
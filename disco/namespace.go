@@ -0,0 +1,90 @@
+package disco
+
+import (
+	"slices"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/errata"
+	"github.com/project-chip/alchemy/internal/parse"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+func (b *Ball) organizeNamespaceSections(cxt *discoContext, dp *docParse) (err error) {
+	for _, namespace := range dp.namespaces {
+		for _, table := range parse.Skim[*asciidoc.Table](namespace.Elements()) {
+			var ti *spec.TableInfo
+			ti, err = spec.ReadTable(dp.doc, table)
+			if err != nil {
+				return
+			}
+			if ti.ColumnMap == nil {
+				continue
+			}
+			var tableType matter.TableType
+			switch {
+			case ti.ColumnMap.HasAll(matter.TableColumnID, matter.TableColumnNamespace):
+				tableType = matter.TableTypeNamespace
+			case ti.ColumnMap.HasAll(matter.TableColumnID, matter.TableColumnName):
+				tableType = matter.TableTypeNamespaceTags
+			default:
+				continue
+			}
+			if b.options.normalizeNamespaceTables {
+				err = b.renameTableHeaderCells(dp.doc, namespace, ti, matter.Tables[tableType].ColumnNames)
+				if err != nil {
+					return
+				}
+			}
+			if tableType == matter.TableTypeNamespaceTags {
+				b.sortNamespaceTags(namespace, ti)
+			}
+			if b.options.normalizeNamespaceTables {
+				err = b.reorderColumns(dp.doc, namespace, ti, tableType)
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
+	return
+}
+
+func (b *Ball) sortNamespaceTags(section *spec.Section, ti *spec.TableInfo) {
+	if !b.options.sortNamespaceTags {
+		return
+	}
+	if b.errata.IgnoreSection(section.Name, errata.DiscoPurposeTableSortRows) {
+		return
+	}
+	body := ti.Rows[ti.HeaderRowIndex+1:]
+	ids := make(map[*asciidoc.TableRow]*matter.Number, len(body))
+	for _, row := range body {
+		id, err := ti.ReadID(row, matter.TableColumnID)
+		if err != nil || !id.Valid() {
+			// Can't sort a table we can't read
+			return
+		}
+		ids[row] = id
+	}
+	sorted := slices.Clone(body)
+	slices.SortStableFunc(sorted, func(a *asciidoc.TableRow, b *asciidoc.TableRow) int {
+		return ids[a].Compare(ids[b])
+	})
+	if slices.Equal(sorted, body) {
+		return
+	}
+	if b.violation(RuleSortNamespaceTags, ti, "tags in namespace %s are not sorted by ID", section.Name) {
+		return
+	}
+	var i int
+	for j, el := range ti.Element.Set {
+		row, ok := el.(*asciidoc.TableRow)
+		if !ok || ids[row] == nil {
+			continue
+		}
+		ti.Element.Set[j] = sorted[i]
+		i++
+	}
+	copy(body, sorted)
+}
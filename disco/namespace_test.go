@@ -0,0 +1,43 @@
+package disco
+
+import (
+	"strings"
+	"testing"
+)
+
+var namespaceDoc = `[[ref_TestNamespace]]
+= Test Namespace
+
+This namespace has tags.
+
+|===
+| ID   | Namespace
+| 0x42 | Test
+|===
+
+|===
+| Description | ID   | Name
+| Third       | 0x02 | Three
+| First       | 0x00 | One
+| Second      | 0x01 | Two
+|===
+`
+
+func TestNamespaceTables(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []Option
+		expected string
+	}{
+		{"default", nil, "| Description | ID | Name\n| Third | 0x02 | Three\n| First | 0x00 | One\n| Second | 0x01 | Two"},
+		{"sorted", []Option{SortNamespaceTags(true)}, "| Description | ID | Name\n| First | 0x00 | One\n| Second | 0x01 | Two\n| Third | 0x02 | Three"},
+		{"normalized", []Option{NormalizeNamespaceTables(true)}, "| ID | Name | Summary\n| 0x02 | Three | Third\n| 0x00 | One | First\n| 0x01 | Two | Second"},
+		{"sorted and normalized", []Option{SortNamespaceTags(true), NormalizeNamespaceTables(true)}, "| ID | Name | Summary\n| 0x00 | One | First\n| 0x01 | Two | Second\n| 0x02 | Three | Third"},
+	}
+	for _, tt := range tests {
+		out := discoBallDoc(t, "src/namespaces/Namespace-Test.adoc", namespaceDoc, tt.options...)
+		if !strings.Contains(squeezeSpaces(out), tt.expected) {
+			t.Errorf("%s: expected namespace tags:\n%s\ngot:\n%s", tt.name, tt.expected, out)
+		}
+	}
+}
@@ -18,6 +18,20 @@ type options struct {
 	removeExtraSpaces             bool
 	normalizeFeatureNames         bool
 	disambiguateConformanceChoice bool
+	canonicalConformance          bool
+	crossCheckClusters            bool
+	linkClusterNames              bool
+	sortNamespaceTags             bool
+	normalizeDeviceTypeTables     bool
+	normalizeNamespaceTables      bool
+	normalizeAnchors              bool
+	formatConformance             bool
+	formatConstraint              bool
+	canonicalizeDataTypeNames     bool
+	fixBitmapRanges               bool
+	normalizeSectionNames         bool
+
+	lint bool
 }
 
 var defaultOptions = options{
@@ -36,6 +50,18 @@ var defaultOptions = options{
 	removeExtraSpaces:             true,
 	normalizeFeatureNames:         true,
 	disambiguateConformanceChoice: false,
+	canonicalConformance:          false,
+	crossCheckClusters:            false,
+	linkClusterNames:              false,
+	sortNamespaceTags:             false,
+	normalizeDeviceTypeTables:     false,
+	normalizeNamespaceTables:      false,
+	normalizeAnchors:              true,
+	formatConformance:             true,
+	formatConstraint:              true,
+	canonicalizeDataTypeNames:     true,
+	fixBitmapRanges:               true,
+	normalizeSectionNames:         true,
 }
 
 func LinkIndexTables(link bool) Option {
@@ -127,3 +153,75 @@ func DisambiguateConformanceChoice(add bool) Option {
 		b.options.disambiguateConformanceChoice = add
 	}
 }
+
+func CanonicalConformance(canonical bool) Option {
+	return func(b *Ball) {
+		b.options.canonicalConformance = canonical
+	}
+}
+
+func CrossCheckClusters(check bool) Option {
+	return func(b *Ball) {
+		b.options.crossCheckClusters = check
+	}
+}
+
+func LinkClusterNames(link bool) Option {
+	return func(b *Ball) {
+		b.options.linkClusterNames = link
+	}
+}
+
+func SortNamespaceTags(sort bool) Option {
+	return func(b *Ball) {
+		b.options.sortNamespaceTags = sort
+	}
+}
+
+func NormalizeDeviceTypeTables(on bool) Option {
+	return func(b *Ball) {
+		b.options.normalizeDeviceTypeTables = on
+	}
+}
+
+func NormalizeNamespaceTables(on bool) Option {
+	return func(b *Ball) {
+		b.options.normalizeNamespaceTables = on
+	}
+}
+
+func NormalizeAnchors(on bool) Option {
+	return func(b *Ball) {
+		b.options.normalizeAnchors = on
+	}
+}
+
+func FormatConformance(on bool) Option {
+	return func(b *Ball) {
+		b.options.formatConformance = on
+	}
+}
+
+func FormatConstraint(on bool) Option {
+	return func(b *Ball) {
+		b.options.formatConstraint = on
+	}
+}
+
+func CanonicalizeDataTypeNames(on bool) Option {
+	return func(b *Ball) {
+		b.options.canonicalizeDataTypeNames = on
+	}
+}
+
+func FixBitmapRanges(on bool) Option {
+	return func(b *Ball) {
+		b.options.fixBitmapRanges = on
+	}
+}
+
+func NormalizeSectionNames(on bool) Option {
+	return func(b *Ball) {
+		b.options.normalizeSectionNames = on
+	}
+}
@@ -32,6 +32,13 @@ type docParse struct {
 	commands []*subSection
 	events   []*subSection
 
+	conditions                     []*subSection
+	clusterRequirements            []*subSection
+	elementRequirements            []*subSection
+	composedDeviceTypeRequirements []*subSection
+
+	namespaces []*spec.Section
+
 	tableCache       map[*asciidoc.Table]*spec.TableInfo
 	conformanceCache map[asciidoc.Element]conformance.Set
 }
@@ -65,10 +72,39 @@ func (b *Ball) parseDoc(doc *spec.Doc, docType matter.DocType, topLevelSection *
 		conformanceCache: make(map[asciidoc.Element]conformance.Set),
 		tableCache:       make(map[*asciidoc.Table]*spec.TableInfo),
 	}
+	if topLevelSection.SecType == matter.SectionNamespace {
+		dp.namespaces = append(dp.namespaces, topLevelSection)
+	}
 	for _, section := range parse.FindAll[*spec.Section](topLevelSection.Elements()) {
 		switch section.SecType {
 		case matter.SectionCluster:
 			dp.clusters[section] = &clusterInfo{}
+		case matter.SectionNamespace:
+			dp.namespaces = append(dp.namespaces, section)
+		case matter.SectionConditions:
+			var conditions *subSection
+			conditions, err = newSubSection(dp, section)
+			if err == nil {
+				dp.conditions = append(dp.conditions, conditions)
+			}
+		case matter.SectionClusterRequirements:
+			var clusterRequirements *subSection
+			clusterRequirements, err = newSubSection(dp, section)
+			if err == nil {
+				dp.clusterRequirements = append(dp.clusterRequirements, clusterRequirements)
+			}
+		case matter.SectionElementRequirements:
+			var elementRequirements *subSection
+			elementRequirements, err = newSubSection(dp, section)
+			if err == nil {
+				dp.elementRequirements = append(dp.elementRequirements, elementRequirements)
+			}
+		case matter.SectionComposedDeviceTypeRequirements:
+			var composedRequirements *subSection
+			composedRequirements, err = newSubSection(dp, section)
+			if err == nil {
+				dp.composedDeviceTypeRequirements = append(dp.composedDeviceTypeRequirements, composedRequirements)
+			}
 		case matter.SectionAttributes:
 			switch docType {
 			case matter.DocTypeCluster:
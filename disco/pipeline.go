@@ -13,15 +13,106 @@ import (
 
 func Pipeline(cxt context.Context, specRoot string, docPaths []string, pipelineOptions pipeline.Options, discoOptions []Option, renderOptions []render.Option, writer files.Writer[string]) (err error) {
 
+	specRoot = deriveSpecRoot(cxt, specRoot, docPaths)
+
+	var docs pipeline.Map[string, *pipeline.Data[*spec.Doc]]
+	docs, err = loadDocs(cxt, specRoot, docPaths, pipelineOptions)
+	if err != nil {
+		return err
+	}
+
+	discoOptions, err = withConfig(specRoot, discoOptions)
+	if err != nil {
+		return err
+	}
+
+	baller := NewBaller(discoOptions, pipelineOptions)
+
+	var balledDocs pipeline.Map[string, *pipeline.Data[*spec.Doc]]
+	balledDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, baller, docs)
+	if err != nil {
+		return err
+	}
+
+	anchorNormalizer := newAnchorNormalizer(discoOptions)
+	var normalizedDocs pipeline.Map[string, *pipeline.Data[render.InputDocument]]
+	normalizedDocs, err = pipeline.Process[*spec.Doc, render.InputDocument](cxt, pipelineOptions, anchorNormalizer, balledDocs)
+	if err != nil {
+		return err
+	}
+
+	renderer := render.NewRenderer(renderOptions...)
+	var renders pipeline.Map[string, *pipeline.Data[string]]
+	renders, err = pipeline.Process[render.InputDocument, string](cxt, pipelineOptions, renderer, normalizedDocs)
+	if err != nil {
+		return err
+	}
+
+	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, writer, renders)
+	return
+}
+
+// Lint runs the disco ball rules against the documents without rewriting them, and returns every violation found
+func Lint(cxt context.Context, specRoot string, docPaths []string, pipelineOptions pipeline.Options, discoOptions []Option) (findings []Finding, err error) {
+
+	specRoot = deriveSpecRoot(cxt, specRoot, docPaths)
+
 	var docs pipeline.Map[string, *pipeline.Data[*spec.Doc]]
+	docs, err = loadDocs(cxt, specRoot, docPaths, pipelineOptions)
+	if err != nil {
+		return
+	}
+
+	discoOptions, err = withConfig(specRoot, discoOptions)
+	if err != nil {
+		return
+	}
+
+	linter := NewLinter(discoOptions)
 
+	var docFindings pipeline.Map[string, *pipeline.Data[[]Finding]]
+	docFindings, err = pipeline.Process[*spec.Doc, []Finding](cxt, pipelineOptions, linter, docs)
+	if err != nil {
+		return
+	}
+	docFindings.Range(func(path string, value *pipeline.Data[[]Finding]) bool {
+		findings = append(findings, value.Content...)
+		return true
+	})
+
+	var anchorFindings []Finding
+	anchorFindings, err = newAnchorNormalizer(discoOptions).lint(pipeline.DataMapToSlice(docs))
+	if err != nil {
+		return
+	}
+	findings = append(findings, anchorFindings...)
+	SortFindings(findings)
+	return
+}
+
+func deriveSpecRoot(cxt context.Context, specRoot string, docPaths []string) string {
 	if specRoot == "" {
-		allPaths, e := files.PathsTargeter(docPaths...)(cxt)
-		if e == nil {
+		allPaths, err := files.PathsTargeter(docPaths...)(cxt)
+		if err == nil {
 			specRoot = spec.DeriveSpecPathFromPaths(allPaths)
 		}
 	}
+	return specRoot
+}
 
+// withConfig puts the spec repo's rule configuration ahead of the given options, so explicit options win
+func withConfig(specRoot string, discoOptions []Option) ([]Option, error) {
+	config, err := LoadConfig(specRoot)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return discoOptions, nil
+	}
+	return append([]Option{WithConfig(config)}, discoOptions...), nil
+}
+
+func loadDocs(cxt context.Context, specRoot string, docPaths []string, pipelineOptions pipeline.Options) (docs pipeline.Map[string, *pipeline.Data[*spec.Doc]], err error) {
 	errata.LoadErrataConfig(specRoot)
 
 	if specRoot != "" {
@@ -31,76 +122,49 @@ func Pipeline(cxt context.Context, specRoot string, docPaths []string, pipelineO
 		var inputs pipeline.Map[string, *pipeline.Data[struct{}]]
 		inputs, err = pipeline.Start[struct{}](cxt, specTargeter)
 		if err != nil {
-			return err
+			return
 		}
 
-		docReader, err := spec.NewReader("Reading spec docs", specRoot)
+		var docReader spec.Reader
+		docReader, err = spec.NewReader("Reading spec docs", specRoot)
 		if err != nil {
-			return err
+			return
 		}
 		docs, err = pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docReader, inputs)
 		if err != nil {
-			return err
+			return
 		}
 
 		specBuilder := spec.NewBuilder()
 		docs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, &specBuilder, docs)
 		if err != nil {
-			return err
+			return
 		}
 		if len(docPaths) > 0 {
 			filter := files.NewPathFilter[*spec.Doc](docPaths)
 			docs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, filter, docs)
 			if err != nil {
-				return err
+				return
 			}
 		}
 	} else if len(docPaths) > 0 {
 		var inputs pipeline.Map[string, *pipeline.Data[struct{}]]
 		inputs, err = pipeline.Start[struct{}](cxt, files.PathsTargeter(docPaths...))
 		if err != nil {
-			return err
+			return
 		}
 
-		docReader, err := spec.NewReader("Reading docs", specRoot)
+		var docReader spec.Reader
+		docReader, err = spec.NewReader("Reading docs", specRoot)
 		if err != nil {
-			return err
+			return
 		}
 		docs, err = pipeline.Process[struct{}, *spec.Doc](cxt, pipelineOptions, docReader, inputs)
 		if err != nil {
-			return err
+			return
 		}
 	} else {
 		err = fmt.Errorf("disco ball requires spec root or document paths")
-		return
 	}
-
-	if err != nil {
-		return err
-	}
-
-	baller := NewBaller(discoOptions, pipelineOptions)
-
-	var balledDocs pipeline.Map[string, *pipeline.Data[*spec.Doc]]
-	balledDocs, err = pipeline.Process[*spec.Doc, *spec.Doc](cxt, pipelineOptions, baller, docs)
-	if err != nil {
-		return err
-	}
-
-	anchorNormalizer := newAnchorNormalizer(discoOptions)
-	var normalizedDocs pipeline.Map[string, *pipeline.Data[render.InputDocument]]
-	normalizedDocs, err = pipeline.Process[*spec.Doc, render.InputDocument](cxt, pipelineOptions, anchorNormalizer, balledDocs)
-	if err != nil {
-		return err
-	}
-
-	renderer := render.NewRenderer(renderOptions...)
-	var renders pipeline.Map[string, *pipeline.Data[string]]
-	renders, err = pipeline.Process[render.InputDocument, string](cxt, pipelineOptions, renderer, normalizedDocs)
-	if err != nil {
-		return err
-	}
-
-	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, writer, renders)
 	return
 }
@@ -0,0 +1,411 @@
+package disco
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/asciidoc/render"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/parse"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+// Promote moves a cluster's bitmap, enum or struct into the global data types document, and links every cluster
+// that uses it to the new definition; it returns the paths of the cluster documents affected, so their ZAP output
+// can be regenerated
+func Promote(cxt context.Context, specRoot string, entity string, target string, pipelineOptions pipeline.Options, renderOptions []render.Option, writer files.Writer[string]) (clusterPaths []string, err error) {
+	if specRoot == "" {
+		return nil, fmt.Errorf("promote requires a spec root")
+	}
+
+	var docs pipeline.Map[string, *pipeline.Data[*spec.Doc]]
+	docs, err = loadDocs(cxt, specRoot, nil, pipelineOptions)
+	if err != nil {
+		return
+	}
+	var s *spec.Specification
+	docs.Range(func(path string, value *pipeline.Data[*spec.Doc]) bool {
+		s = value.Content.Spec()
+		return s == nil
+	})
+	if s == nil {
+		return nil, fmt.Errorf("no spec docs found in %s", specRoot)
+	}
+
+	var rt *renameTarget
+	rt, err = resolveRenameTarget(s, entity)
+	if err != nil {
+		return
+	}
+	if !rt.dataType || len(rt.path) != 2 {
+		return nil, fmt.Errorf("only cluster bitmaps, enums and structs can be promoted; %s is not one", entity)
+	}
+	for o := range s.GlobalObjects {
+		if dataTypeName(o) == rt.name {
+			return nil, fmt.Errorf("there is already a global data type named %s in %s", rt.name, s.DocRefs[o].Path.Relative)
+		}
+	}
+
+	var targetDoc *spec.Doc
+	targetDoc, err = findGlobalDataTypesDoc(s, docs, target)
+	if err != nil {
+		return
+	}
+	if targetDoc.Group() != rt.doc.Group() {
+		return nil, fmt.Errorf("%s and %s are not in the same document group", targetDoc.Path.Relative, rt.doc.Path.Relative)
+	}
+
+	changedDocs := map[*spec.Doc]struct{}{rt.doc: {}, targetDoc: {}}
+	var id string
+	id, err = promoteDataType(rt, targetDoc, changedDocs)
+	if err != nil {
+		return
+	}
+
+	clusterDocs := map[*spec.Doc]struct{}{rt.doc: {}}
+	if clusters, ok := s.ClusterRefs.Get(rt.entity); ok {
+		for c := range clusters {
+			if doc, ok := s.DocRefs[c]; ok {
+				clusterDocs[doc] = struct{}{}
+			}
+		}
+	}
+	for doc := range clusterDocs {
+		var linked bool
+		linked, err = linkTypeCells(doc, rt.name, id)
+		if err != nil {
+			return
+		}
+		if linked {
+			changedDocs[doc] = struct{}{}
+		}
+		clusterPaths = append(clusterPaths, doc.Path.Absolute)
+	}
+	slices.Sort(clusterPaths)
+
+	promotedDocs := pipeline.NewMap[string, *pipeline.Data[render.InputDocument]]()
+	for doc := range changedDocs {
+		promotedDocs.Store(doc.Path.Absolute, pipeline.NewData[render.InputDocument](doc.Path.Absolute, doc))
+	}
+
+	renderer := render.NewRenderer(renderOptions...)
+	var renders pipeline.Map[string, *pipeline.Data[string]]
+	renders, err = pipeline.Process[render.InputDocument, string](cxt, pipelineOptions, renderer, promotedDocs)
+	if err != nil {
+		return
+	}
+
+	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, writer, renders)
+	return
+}
+
+// findGlobalDataTypesDoc finds the document at the target path or, if there is none, the document defining the most
+// global data types
+func findGlobalDataTypesDoc(s *spec.Specification, docs pipeline.Map[string, *pipeline.Data[*spec.Doc]], target string) (targetDoc *spec.Doc, err error) {
+	if target != "" {
+		var abs string
+		abs, err = filepath.Abs(target)
+		if err != nil {
+			return
+		}
+		docs.Range(func(path string, value *pipeline.Data[*spec.Doc]) bool {
+			if value.Content.Path.Absolute == abs || value.Content.Path.Relative == target {
+				targetDoc = value.Content
+				return false
+			}
+			return true
+		})
+		if targetDoc == nil {
+			err = fmt.Errorf("unable to find global data types document %s", target)
+		}
+		return
+	}
+	counts := make(map[*spec.Doc]int)
+	for o := range s.GlobalObjects {
+		if dataTypeName(o) == "" {
+			continue
+		}
+		if doc, ok := s.DocRefs[o]; ok {
+			counts[doc]++
+		}
+	}
+	for doc, count := range counts {
+		if targetDoc == nil || count > counts[targetDoc] || (count == counts[targetDoc] && doc.Path.Relative < targetDoc.Path.Relative) {
+			targetDoc = doc
+		}
+	}
+	if targetDoc == nil {
+		err = fmt.Errorf("unable to find the global data types document; specify it with --target")
+	}
+	return
+}
+
+// promoteDataType moves the data type's section next to the global data types of the same kind in the target
+// document, and returns the ID of its anchor
+func promoteDataType(rt *renameTarget, targetDoc *spec.Doc, changedDocs map[*spec.Doc]struct{}) (id string, err error) {
+	top := parse.FindFirst[*spec.Section](rt.doc.Elements())
+	if top == nil {
+		return "", fmt.Errorf("%s has no sections", rt.doc.Path.Relative)
+	}
+	definitions := rt.definitions([]*spec.Section{top})
+	switch len(definitions) {
+	case 0:
+		return "", fmt.Errorf("unable to find the definition of %s in %s", strings.Join(rt.path, "."), rt.doc.Path.Relative)
+	case 1:
+	default:
+		return "", fmt.Errorf("%s is defined more than once in %s", strings.Join(rt.path, "."), rt.doc.Path.Relative)
+	}
+	section := definitions[0]
+
+	targetTop := parse.FindFirst[*spec.Section](targetDoc.Elements())
+	if targetTop == nil {
+		return "", fmt.Errorf("%s has no sections", targetDoc.Path.Relative)
+	}
+	var sibling *spec.Section
+	for _, s := range parse.FindAll[*spec.Section](targetTop.Elements()) {
+		switch s.SecType {
+		case matter.SectionDataTypeBitmap, matter.SectionDataTypeEnum, matter.SectionDataTypeStruct:
+			if sibling == nil || s.SecType == section.SecType || sibling.SecType != section.SecType {
+				sibling = s
+			}
+		}
+	}
+	if sibling == nil {
+		return "", fmt.Errorf("%s has no global data types to put %s alongside", targetDoc.Path.Relative, rt.name)
+	}
+	targetParent, ok := sibling.Parent.(parse.HasElements)
+	if !ok {
+		return "", fmt.Errorf("unexpected parent of %s in %s", sibling.Name, targetDoc.Path.Relative)
+	}
+	sourceParent, ok := section.Parent.(parse.HasElements)
+	if !ok {
+		return "", fmt.Errorf("unexpected parent of %s in %s", section.Name, rt.doc.Path.Relative)
+	}
+
+	id, err = promotedAnchor(rt, section, changedDocs)
+	if err != nil {
+		return
+	}
+
+	err = removeElement(sourceParent, section)
+	if err != nil {
+		return
+	}
+	if dataTypes, ok := sourceParent.(*spec.Section); ok && dataTypes.SecType == matter.SectionDataTypes && isBlank(dataTypes) {
+		if grandparent, ok := dataTypes.Parent.(parse.HasElements); ok {
+			err = removeElement(grandparent, dataTypes)
+			if err != nil {
+				return
+			}
+		}
+	}
+	err = removeIndexRows(rt.doc, top, rt.name)
+	if err != nil {
+		return
+	}
+
+	delta := sibling.Base.Level - section.Base.Level
+	for _, s := range append([]*spec.Section{section}, parse.FindAll[*spec.Section](section.Elements())...) {
+		s.Base.Level += delta
+		s.Doc = targetDoc
+	}
+	section.Parent = targetParent
+
+	err = ensureTrailingEmptyLine(sibling)
+	if err != nil {
+		return
+	}
+	elements := targetParent.Elements()
+	index := slices.IndexFunc(elements, func(e asciidoc.Element) bool { return e == sibling })
+	elements = slices.Insert(slices.Clone(elements), index+1, asciidoc.Element(section))
+	err = targetParent.SetElements(elements)
+	return
+}
+
+// promotedAnchor makes sure the data type's section has an anchor that is unique in its document group, and returns its ID
+func promotedAnchor(rt *renameTarget, section *spec.Section, changedDocs map[*spec.Doc]struct{}) (id string, err error) {
+	anchors, _ := rt.doc.Anchors()
+	var anchor *spec.Anchor
+	for _, as := range anchors {
+		for _, a := range as {
+			if a.Element == section.Base {
+				anchor = a
+			}
+		}
+	}
+	preferredID := "ref_" + rt.name
+	group := rt.doc.Group()
+	unique := func(id string) bool {
+		if group == nil {
+			return true
+		}
+		for _, a := range group.Anchors(id) {
+			if a != anchor {
+				return false
+			}
+		}
+		return true
+	}
+	if anchor == nil {
+		if !unique(preferredID) {
+			return "", fmt.Errorf("anchor %s is already in use", preferredID)
+		}
+		section.Base.AppendAttribute(asciidoc.NewAnchorAttribute(asciidoc.NewString(preferredID), asciidoc.Set{asciidoc.NewString(rt.name)}))
+		return preferredID, nil
+	}
+	if anchor.ID == preferredID {
+		return anchor.ID, nil
+	}
+	if !unique(preferredID) {
+		if unique(anchor.ID) && properAnchorPattern.MatchString(anchor.ID) {
+			return anchor.ID, nil
+		}
+		return "", fmt.Errorf("anchor %s is already in use", preferredID)
+	}
+	if labelText(anchor.LabelElements) == rt.name {
+		// The label only disambiguated the cluster's anchor
+		anchor.LabelElements = nil
+	}
+	from := anchor.ID
+	anchor.SyncToDoc(preferredID)
+	var xrefs []*spec.CrossReference
+	if group != nil {
+		xrefs = group.CrossReferences(from)
+	} else {
+		xrefs = rt.doc.CrossReferences()[from]
+	}
+	// We're going to be modifying the underlying array, so we need to make a copy of the slice
+	xrefsToChange := make([]*spec.CrossReference, len(xrefs))
+	copy(xrefsToChange, xrefs)
+	for _, xref := range xrefsToChange {
+		xref.SyncToDoc(preferredID)
+		changedDocs[xref.Document] = struct{}{}
+	}
+	return preferredID, nil
+}
+
+func removeElement(parent parse.HasElements, element asciidoc.Element) error {
+	elements := slices.DeleteFunc(slices.Clone(parent.Elements()), func(e asciidoc.Element) bool { return e == element })
+	return parent.SetElements(elements)
+}
+
+// isBlank reports whether a section has nothing left in it but empty lines
+func isBlank(s *spec.Section) bool {
+	for _, e := range s.Elements() {
+		if he, ok := e.(parse.HasBase); ok {
+			e = he.GetBase()
+		}
+		if _, ok := e.(asciidoc.EmptyLine); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ensureTrailingEmptyLine makes sure there is an empty line between the end of a section and whatever follows it
+func ensureTrailingEmptyLine(s *spec.Section) error {
+	elements := s.Elements()
+	if len(elements) > 0 {
+		last := elements[len(elements)-1]
+		if he, ok := last.(parse.HasBase); ok {
+			last = he.GetBase()
+		}
+		switch last := last.(type) {
+		case asciidoc.EmptyLine:
+			return nil
+		case *spec.Section:
+			return ensureTrailingEmptyLine(last)
+		}
+	}
+	return s.SetElements(append(slices.Clone(elements), asciidoc.NewEmptyLine("")))
+}
+
+// removeIndexRows removes the data type from any summary table in the cluster's Data Types section
+func removeIndexRows(doc *spec.Doc, top *spec.Section, name string) (err error) {
+	dataTypes := spec.FindSectionByType(top, matter.SectionDataTypes)
+	if dataTypes == nil {
+		return
+	}
+	table := spec.FindFirstTable(dataTypes)
+	if table == nil {
+		return
+	}
+	var ti *spec.TableInfo
+	ti, err = spec.ReadTable(doc, table)
+	if err != nil {
+		return
+	}
+	if _, ok := ti.ColumnIndex(matter.TableColumnName); !ok {
+		return
+	}
+	var rows []*asciidoc.TableRow
+	for row := range ti.Body() {
+		var value string
+		value, err = ti.ReadString(row, matter.TableColumnName)
+		if err != nil {
+			return
+		}
+		if strings.TrimSpace(value) == name {
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+	return table.SetElements(slices.DeleteFunc(slices.Clone(table.Elements()), func(e asciidoc.Element) bool {
+		row, ok := e.(*asciidoc.TableRow)
+		return ok && slices.Contains(rows, row)
+	}))
+}
+
+// linkTypeCells replaces plain text mentions of the data type in type columns with cross references to its anchor
+func linkTypeCells(doc *spec.Doc, name string, id string) (linked bool, err error) {
+	for _, table := range parse.FindAll[*asciidoc.Table](doc.Elements()) {
+		var ti *spec.TableInfo
+		ti, err = spec.ReadTable(doc, table)
+		if err != nil {
+			return
+		}
+		index, ok := ti.ColumnIndex(matter.TableColumnType)
+		if !ok {
+			continue
+		}
+		for row := range ti.Body() {
+			cell := row.Cell(index)
+			var value strings.Builder
+			plain := true
+			for _, el := range cell.Elements() {
+				s, ok := el.(*asciidoc.String)
+				if !ok {
+					plain = false
+					break
+				}
+				value.WriteString(s.Value)
+			}
+			if !plain {
+				continue
+			}
+			v := strings.TrimSpace(value.String())
+			var elements asciidoc.Set
+			if list, ok := strings.CutPrefix(v, "list["); ok && strings.TrimSuffix(list, "]") == name {
+				elements = asciidoc.Set{asciidoc.NewString("list["), asciidoc.NewCrossReference(id), asciidoc.NewString("]")}
+			} else if v == name {
+				elements = asciidoc.Set{asciidoc.NewCrossReference(id)}
+			} else {
+				continue
+			}
+			err = setCellValue(cell, elements)
+			if err != nil {
+				return
+			}
+			linked = true
+		}
+	}
+	return
+}
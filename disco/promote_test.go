@@ -0,0 +1,83 @@
+package disco
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+)
+
+var promoteTests = []struct {
+	Path     string
+	Expected []string
+	Unwanted []string
+}{
+	// The struct is gone from the cluster and its summary table, and references to it follow its new anchor
+	{"src/app_clusters/Alpha.adoc", []string{"| ModeEnum | The modes", "=== ModeEnum Type", "| 0x0000 | Levels | list[<<ref_LevelStruct>>] |", "Each entry is a <<ref_LevelStruct>>."}, []string{"LevelStruct Type", "ref_AlphaLevelStruct", "| LevelStruct"}},
+	// Another cluster's plain text mention of the type is linked to it
+	{"src/app_clusters/Beta.adoc", []string{"| 0x0000 | Level | <<ref_LevelStruct>>    |"}, nil},
+	// The struct is put with the other global structs, without the anchor label that disambiguated it in the cluster
+	{"src/data_model/Data-Model-Types.adoc", []string{"|===\n\n[[ref_LevelStruct]]\n=== LevelStruct Type\n", "==== Min Field\n\nThe minimum.\n"}, nil},
+}
+
+func TestPromote(t *testing.T) {
+	specRoot := t.TempDir()
+	err := os.CopyFS(specRoot, os.DirFS("testdata/promote"))
+	if err != nil {
+		t.Fatalf("failed copying spec: %v", err)
+	}
+	clusterPaths, err := Promote(context.Background(), specRoot, "Alpha.LevelStruct", "", pipeline.Options{NoProgress: true}, nil, files.NewWriter[string]("Writing promoted docs", files.Options{}))
+	if err != nil {
+		t.Fatalf("failed promoting Alpha.LevelStruct: %v", err)
+	}
+	expectedPaths := []string{filepath.Join(specRoot, "src/app_clusters/Alpha.adoc"), filepath.Join(specRoot, "src/app_clusters/Beta.adoc")}
+	if !slices.Equal(clusterPaths, expectedPaths) {
+		t.Errorf("expected cluster paths %v, got %v", expectedPaths, clusterPaths)
+	}
+	for _, pt := range promoteTests {
+		b, err := os.ReadFile(filepath.Join(specRoot, pt.Path))
+		if err != nil {
+			t.Errorf("failed reading %s: %v", pt.Path, err)
+			continue
+		}
+		doc := string(b)
+		for _, e := range pt.Expected {
+			if !strings.Contains(doc, e) {
+				t.Errorf("expected %s to contain %q, got:\n%s", pt.Path, e, doc)
+			}
+		}
+		for _, u := range pt.Unwanted {
+			if strings.Contains(doc, u) {
+				t.Errorf("expected %s not to contain %q, got:\n%s", pt.Path, u, doc)
+			}
+		}
+	}
+}
+
+func TestPromoteErrors(t *testing.T) {
+	tests := []struct {
+		entity string
+		target string
+		err    string
+	}{
+		{"Alpha.Levels", "", "only cluster bitmaps, enums and structs can be promoted"},
+		{"Alpha.ModeEnum", "src/app_clusters/Beta.adoc", "Beta.adoc has no global data types to put ModeEnum alongside"},
+		{"Alpha.ModeEnum", "src/data_model/Missing.adoc", "unable to find global data types document"},
+	}
+	for _, tt := range tests {
+		specRoot := t.TempDir()
+		err := os.CopyFS(specRoot, os.DirFS("testdata/promote"))
+		if err != nil {
+			t.Fatalf("failed copying spec: %v", err)
+		}
+		_, err = Promote(context.Background(), specRoot, tt.entity, tt.target, pipeline.Options{NoProgress: true}, nil, files.NewWriter[string]("Writing promoted docs", files.Options{}))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("expected promoting %s to fail with %q, got %v", tt.entity, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,615 @@
+package disco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/asciidoc/render"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/parse"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// Rename renames a data type, attribute, command, event, field or feature, given as "Cluster.Name" or
+// "Cluster.Name.Field" (or just "Name" for global data types), and rewrites its section titles, anchors, table rows,
+// cross references, type columns and conformance to match
+func Rename(cxt context.Context, specRoot string, entity string, to string, pipelineOptions pipeline.Options, renderOptions []render.Option, writer files.Writer[string]) (err error) {
+	if specRoot == "" {
+		return fmt.Errorf("rename requires a spec root")
+	}
+	if !properAnchorNamePattern.MatchString(to) {
+		return fmt.Errorf("invalid name %q", to)
+	}
+
+	var docs pipeline.Map[string, *pipeline.Data[*spec.Doc]]
+	docs, err = loadDocs(cxt, specRoot, nil, pipelineOptions)
+	if err != nil {
+		return
+	}
+	var s *spec.Specification
+	var allDocs []*spec.Doc
+	docs.Range(func(path string, value *pipeline.Data[*spec.Doc]) bool {
+		allDocs = append(allDocs, value.Content)
+		if s == nil {
+			s = value.Content.Spec()
+		}
+		return true
+	})
+	if s == nil {
+		return fmt.Errorf("no spec docs found in %s", specRoot)
+	}
+
+	var rt *renameTarget
+	rt, err = resolveRenameTarget(s, entity)
+	if err != nil {
+		return
+	}
+	renamedPath := strings.Join(slices.Concat(rt.path[:len(rt.path)-1], []string{to}), ".")
+	if existing, e := resolveRenameTarget(s, renamedPath); e == nil && existing.entity != rt.entity {
+		return fmt.Errorf("%s already exists", renamedPath)
+	}
+
+	var changedDocs map[*spec.Doc]struct{}
+	changedDocs, err = rt.rename(s, allDocs, to)
+	if err != nil {
+		return
+	}
+
+	renamedDocs := pipeline.NewMap[string, *pipeline.Data[render.InputDocument]]()
+	for doc := range changedDocs {
+		renamedDocs.Store(doc.Path.Absolute, pipeline.NewData[render.InputDocument](doc.Path.Absolute, doc))
+	}
+
+	renderer := render.NewRenderer(renderOptions...)
+	var renders pipeline.Map[string, *pipeline.Data[string]]
+	renders, err = pipeline.Process[render.InputDocument, string](cxt, pipelineOptions, renderer, renamedDocs)
+	if err != nil {
+		return
+	}
+
+	_, err = pipeline.Process[string, struct{}](cxt, pipelineOptions, writer, renders)
+	return
+}
+
+var properAnchorNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+
+type renameTarget struct {
+	path    []string
+	doc     *spec.Doc
+	entity  types.Entity
+	name    string
+	parent  *renameTarget
+	cluster *matter.Cluster
+
+	// Sections titled after the entity
+	sectionTypes []matter.Section
+	// Sections whose tables have a row for the entity, and the columns naming it
+	indexSectionTypes []matter.Section
+	indexColumns      []matter.TableColumn
+
+	// Whether conformance expressions and type columns may refer to the entity by name
+	identifier bool
+	dataType   bool
+}
+
+func resolveRenameTarget(s *spec.Specification, entity string) (rt *renameTarget, err error) {
+	path := strings.Split(entity, ".")
+	if len(path) == 1 {
+		for o := range s.GlobalObjects {
+			if name := dataTypeName(o); name != "" && name == path[0] {
+				rt = &renameTarget{path: path, doc: s.DocRefs[o], entity: o, name: name, sectionTypes: dataTypeSections(o), dataType: true}
+				break
+			}
+		}
+		if rt == nil || rt.doc == nil {
+			return nil, fmt.Errorf("unknown global data type %s", path[0])
+		}
+		return
+	}
+	if len(path) > 3 {
+		return nil, fmt.Errorf("invalid entity %q; expected Cluster.Name or Cluster.Name.Field", entity)
+	}
+	cluster := findCluster(s, path[0])
+	if cluster == nil {
+		return nil, fmt.Errorf("unknown cluster %s", path[0])
+	}
+	doc, ok := s.DocRefs[cluster]
+	if !ok {
+		return nil, fmt.Errorf("unable to find document for cluster %s", cluster.Name)
+	}
+	rt = clusterRenameTarget(cluster, path[1])
+	if rt == nil {
+		return nil, fmt.Errorf("cluster %s has no data type, attribute, command, event or feature named %s", cluster.Name, path[1])
+	}
+	rt.path = path[:2]
+	rt.doc = doc
+	rt.cluster = cluster
+	if len(path) == 2 {
+		return
+	}
+	var fields matter.FieldSet
+	switch e := rt.entity.(type) {
+	case *matter.Struct:
+		fields = e.Fields
+	case *matter.Command:
+		fields = e.Fields
+	case *matter.Event:
+		fields = e.Fields
+	default:
+		return nil, fmt.Errorf("%s.%s has no fields", cluster.Name, rt.name)
+	}
+	for _, f := range fields {
+		if f.Name == path[2] {
+			return &renameTarget{
+				path:              path,
+				doc:               doc,
+				entity:            f,
+				name:              f.Name,
+				parent:            rt,
+				cluster:           cluster,
+				sectionTypes:      []matter.Section{matter.SectionField},
+				indexSectionTypes: rt.sectionTypes,
+				indexColumns:      []matter.TableColumn{matter.TableColumnName},
+				identifier:        true,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("%s.%s has no field named %s", cluster.Name, rt.name, path[2])
+}
+
+func findCluster(s *spec.Specification, name string) *matter.Cluster {
+	if c, ok := s.ClustersByName[name]; ok {
+		return c
+	}
+	name = strings.TrimSuffix(name, " Cluster")
+	for n, c := range s.ClustersByName {
+		if strings.EqualFold(n, name) || strings.EqualFold(strings.ReplaceAll(n, " ", ""), name) {
+			return c
+		}
+	}
+	return nil
+}
+
+func clusterRenameTarget(cluster *matter.Cluster, name string) *renameTarget {
+	for _, bm := range cluster.Bitmaps {
+		if bm.Name == name {
+			return &renameTarget{entity: bm, name: name, sectionTypes: dataTypeSections(bm), indexSectionTypes: []matter.Section{matter.SectionDataTypes}, indexColumns: []matter.TableColumn{matter.TableColumnName}, dataType: true}
+		}
+	}
+	for _, e := range cluster.Enums {
+		if e.Name == name {
+			return &renameTarget{entity: e, name: name, sectionTypes: dataTypeSections(e), indexSectionTypes: []matter.Section{matter.SectionDataTypes}, indexColumns: []matter.TableColumn{matter.TableColumnName}, dataType: true}
+		}
+	}
+	for _, s := range cluster.Structs {
+		if s.Name == name {
+			return &renameTarget{entity: s, name: name, sectionTypes: dataTypeSections(s), indexSectionTypes: []matter.Section{matter.SectionDataTypes}, indexColumns: []matter.TableColumn{matter.TableColumnName}, dataType: true}
+		}
+	}
+	for _, a := range cluster.Attributes {
+		if a.Name == name {
+			return &renameTarget{entity: a, name: name, sectionTypes: []matter.Section{matter.SectionAttribute}, indexSectionTypes: []matter.Section{matter.SectionAttributes}, indexColumns: []matter.TableColumn{matter.TableColumnName}, identifier: true}
+		}
+	}
+	for _, c := range cluster.Commands {
+		if c.Name == name {
+			return &renameTarget{entity: c, name: name, sectionTypes: []matter.Section{matter.SectionCommand}, indexSectionTypes: []matter.Section{matter.SectionCommands}, indexColumns: []matter.TableColumn{matter.TableColumnName}, identifier: true}
+		}
+	}
+	for _, e := range cluster.Events {
+		if e.Name == name {
+			return &renameTarget{entity: e, name: name, sectionTypes: []matter.Section{matter.SectionEvent}, indexSectionTypes: []matter.Section{matter.SectionEvents}, indexColumns: []matter.TableColumn{matter.TableColumnName}, identifier: true}
+		}
+	}
+	if cluster.Features != nil {
+		for _, b := range cluster.Features.Bits {
+			f, ok := b.(*matter.Feature)
+			if !ok {
+				continue
+			}
+			switch name {
+			case f.Code:
+				// Conformance refers to features by their code
+				return &renameTarget{entity: f, name: name, indexSectionTypes: []matter.Section{matter.SectionFeatures}, indexColumns: []matter.TableColumn{matter.TableColumnCode}, identifier: true}
+			case f.Name():
+				return &renameTarget{entity: f, name: name, sectionTypes: []matter.Section{matter.SectionFeature}, indexSectionTypes: []matter.Section{matter.SectionFeatures}, indexColumns: []matter.TableColumn{matter.TableColumnFeature, matter.TableColumnName}}
+			}
+		}
+	}
+	return nil
+}
+
+func dataTypeName(entity types.Entity) string {
+	switch entity := entity.(type) {
+	case *matter.Bitmap:
+		return entity.Name
+	case *matter.Enum:
+		return entity.Name
+	case *matter.Struct:
+		return entity.Name
+	}
+	return ""
+}
+
+func dataTypeSections(entity types.Entity) []matter.Section {
+	switch entity.(type) {
+	case *matter.Bitmap:
+		return []matter.Section{matter.SectionDataTypeBitmap}
+	case *matter.Enum:
+		return []matter.Section{matter.SectionDataTypeEnum}
+	case *matter.Struct:
+		return []matter.Section{matter.SectionDataTypeStruct}
+	}
+	return nil
+}
+
+// scopes returns the sections the entity is defined in; fields are scoped to their parent's sections, everything else
+// to the whole document
+func (rt *renameTarget) scopes(top *spec.Section) []*spec.Section {
+	if rt.parent == nil {
+		return []*spec.Section{top}
+	}
+	return rt.parent.definitions(rt.parent.scopes(top))
+}
+
+func (rt *renameTarget) definitions(scopes []*spec.Section) (sections []*spec.Section) {
+	for _, scope := range scopes {
+		for _, s := range parse.FindAll[*spec.Section](scope.Elements()) {
+			if slices.Contains(rt.sectionTypes, s.SecType) && sectionEntityName(s.Name) == rt.name {
+				sections = append(sections, s)
+			}
+		}
+	}
+	return
+}
+
+func sectionEntityName(name string) string {
+	return strings.TrimSuffix(matter.StripTypeSuffixes(strings.TrimSpace(name)), " Feature")
+}
+
+func (rt *renameTarget) rename(s *spec.Specification, docs []*spec.Doc, to string) (changedDocs map[*spec.Doc]struct{}, err error) {
+	top := parse.FindFirst[*spec.Section](rt.doc.Elements())
+	if top == nil {
+		return nil, fmt.Errorf("%s has no sections", rt.doc.Path.Relative)
+	}
+	changedDocs = make(map[*spec.Doc]struct{})
+	pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(rt.name) + `\b`)
+	scopes := rt.scopes(top)
+
+	var found bool
+	for _, section := range rt.definitions(scopes) {
+		found = true
+		renameStrings(section.Base.Title, pattern, to)
+		section.Name = pattern.ReplaceAllString(section.Name, to)
+		rt.renameAnchors(section.Base, pattern, to, changedDocs)
+	}
+
+	for _, scope := range scopes {
+		for _, section := range append([]*spec.Section{scope}, parse.FindAll[*spec.Section](scope.Elements())...) {
+			if !slices.Contains(rt.indexSectionTypes, section.SecType) {
+				continue
+			}
+			var renamed bool
+			renamed, err = renameIndexRows(rt.doc, section, rt.indexColumns, rt.name, pattern, to)
+			if err != nil {
+				return
+			}
+			found = found || renamed
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unable to find the definition of %s in %s", strings.Join(rt.path, "."), rt.doc.Path.Relative)
+	}
+	changedDocs[rt.doc] = struct{}{}
+
+	if rt.identifier {
+		for _, scope := range scopes {
+			_, err = renameConformance(rt.doc, scope, rt.name, to)
+			if err != nil {
+				return
+			}
+		}
+		if rt.parent == nil && rt.cluster != nil {
+			for _, doc := range docs {
+				if doc == rt.doc {
+					continue
+				}
+				var renamed bool
+				renamed, err = rt.renameReferences(s, doc, pattern, to)
+				if err != nil {
+					return
+				}
+				if renamed {
+					changedDocs[doc] = struct{}{}
+				}
+			}
+		}
+	}
+
+	if rt.dataType {
+		docs := map[*spec.Doc]struct{}{rt.doc: {}}
+		if clusters, ok := s.ClusterRefs.Get(rt.entity); ok {
+			for c := range clusters {
+				if doc, ok := s.DocRefs[c]; ok {
+					docs[doc] = struct{}{}
+				}
+			}
+		}
+		for doc := range docs {
+			var renamed bool
+			renamed, err = renameTypeCells(doc, rt.name, pattern, to)
+			if err != nil {
+				return
+			}
+			if renamed {
+				changedDocs[doc] = struct{}{}
+			}
+		}
+	}
+	return
+}
+
+// renameAnchors moves the anchors on a renamed section to their new IDs, along with every cross reference to them
+func (rt *renameTarget) renameAnchors(element asciidoc.Element, pattern *regexp.Regexp, to string, changedDocs map[*spec.Doc]struct{}) {
+	anchors, _ := rt.doc.Anchors()
+	var renamed []*spec.Anchor
+	for _, as := range anchors {
+		for _, a := range as {
+			if a.Element == element {
+				renamed = append(renamed, a)
+			}
+		}
+	}
+	for _, a := range renamed {
+		from := a.ID
+		id := renameAnchorID(from, rt.name, to)
+		renameStrings(a.LabelElements, pattern, to)
+		a.SyncToDoc(id)
+		var xrefs []*spec.CrossReference
+		if group := a.Document.Group(); group != nil {
+			xrefs = group.CrossReferences(from)
+		} else {
+			xrefs = a.Document.CrossReferences()[from]
+		}
+		// We're going to be modifying the underlying array, so we need to make a copy of the slice
+		xrefsToChange := make([]*spec.CrossReference, len(xrefs))
+		copy(xrefsToChange, xrefs)
+		for _, xref := range xrefsToChange {
+			xref.SyncToDoc(id)
+			renameStrings(xref.Reference.Set, pattern, to)
+			changedDocs[xref.Document] = struct{}{}
+		}
+		slog.Debug("renamed anchor", "from", from, "to", id, "references", len(xrefsToChange))
+	}
+}
+
+func renameAnchorID(id string, name string, to string) string {
+	if prefix, ok := strings.CutSuffix(id, name); ok {
+		return prefix + to
+	}
+	return strings.Replace(id, name, to, 1)
+}
+
+// renameIndexRows renames the entity's rows in every table of an index section, including alternate tables behind
+// ifdefs
+func renameIndexRows(doc *spec.Doc, section *spec.Section, columns []matter.TableColumn, name string, pattern *regexp.Regexp, to string) (renamed bool, err error) {
+	for _, table := range parse.Skim[*asciidoc.Table](section.Elements()) {
+		var ti *spec.TableInfo
+		ti, err = spec.ReadTable(doc, table)
+		if err != nil {
+			return
+		}
+		index, ok := ti.ColumnIndex(columns...)
+		if !ok {
+			continue
+		}
+		for row := range ti.Body() {
+			var value string
+			value, err = ti.ReadString(row, columns...)
+			if err != nil {
+				return
+			}
+			if strings.TrimSpace(value) != name {
+				continue
+			}
+			cell := row.Cell(index)
+			if renameStrings(cell.Elements(), pattern, to) {
+				renamed = true
+			}
+		}
+	}
+	return
+}
+
+func renameConformance(doc *spec.Doc, scope *spec.Section, name string, to string) (renamed bool, err error) {
+	for _, table := range parse.FindAll[*asciidoc.Table](scope.Elements()) {
+		var ti *spec.TableInfo
+		ti, err = spec.ReadTable(doc, table)
+		if err != nil {
+			return
+		}
+		index, ok := ti.ColumnIndex(matter.TableColumnConformance)
+		if !ok {
+			continue
+		}
+		for row := range ti.Body() {
+			var r bool
+			r, err = renameConformanceCell(row.Cell(index), name, to)
+			if err != nil {
+				return
+			}
+			renamed = renamed || r
+		}
+	}
+	return
+}
+
+// renameConformanceCell renames the identifier in a conformance cell that refers to it, replacing just the name so the
+// rest of the cell is left as it was written
+func renameConformanceCell(cell *asciidoc.TableCell, name string, to string) (renamed bool, err error) {
+	var vc string
+	vc, err = spec.RenderTableCell(cell)
+	if err != nil {
+		return
+	}
+	if !conformance.RenameIdentifier(conformance.ParseConformance(vc), name, to) {
+		return
+	}
+	return renameStrings(cell.Elements(), regexp.MustCompile(`\b`+regexp.QuoteMeta(name)+`\b`), to), nil
+}
+
+// renameReferences renames the entity in a document other than the one defining it: everywhere in the documents of
+// clusters derived from its cluster, and in the element requirement rows of other documents, like device types, that
+// refer to its cluster
+func (rt *renameTarget) renameReferences(s *spec.Specification, doc *spec.Doc, pattern *regexp.Regexp, to string) (renamed bool, err error) {
+	top := parse.FindFirst[*spec.Section](doc.Elements())
+	if top == nil {
+		return
+	}
+	var r bool
+	if rt.derivedDoc(s, doc) {
+		for _, section := range append([]*spec.Section{top}, parse.FindAll[*spec.Section](top.Elements())...) {
+			if !slices.Contains(rt.indexSectionTypes, section.SecType) {
+				continue
+			}
+			r, err = renameIndexRows(doc, section, rt.indexColumns, rt.name, pattern, to)
+			if err != nil {
+				return
+			}
+			renamed = renamed || r
+		}
+		r, err = renameConformance(doc, top, rt.name, to)
+		if err != nil {
+			return
+		}
+		return renamed || r, nil
+	}
+	return rt.renameRequirements(s, doc, top, pattern, to)
+}
+
+func (rt *renameTarget) derivedDoc(s *spec.Specification, doc *spec.Doc) bool {
+	for c := range s.Clusters {
+		if c.Parent == rt.cluster && s.DocRefs[c] == doc {
+			return true
+		}
+	}
+	return false
+}
+
+func (rt *renameTarget) renameRequirements(s *spec.Specification, doc *spec.Doc, top *spec.Section, pattern *regexp.Regexp, to string) (renamed bool, err error) {
+	var element string
+	switch rt.entity.(type) {
+	case *matter.Field:
+		element = "Attribute"
+	case *matter.Command:
+		element = "Command"
+	case *matter.Event:
+		element = "Event"
+	case *matter.Feature:
+		element = "Feature"
+	}
+	for _, table := range parse.FindAll[*asciidoc.Table](top.Elements()) {
+		var ti *spec.TableInfo
+		ti, err = spec.ReadTable(doc, table)
+		if err != nil {
+			return
+		}
+		if _, ok := ti.ColumnIndex(matter.TableColumnElement); !ok {
+			continue
+		}
+		nameIndex, hasName := ti.ColumnIndex(matter.TableColumnName)
+		conformanceIndex, hasConformance := ti.ColumnIndex(matter.TableColumnConformance)
+		for row := range ti.Body() {
+			if !rt.requiresCluster(s, ti, row) {
+				continue
+			}
+			if hasName {
+				e, _ := ti.ReadString(row, matter.TableColumnElement)
+				name, _ := ti.ReadString(row, matter.TableColumnName)
+				if strings.EqualFold(strings.TrimSpace(e), element) && strings.TrimSpace(name) == rt.name && renameStrings(row.Cell(nameIndex).Elements(), pattern, to) {
+					renamed = true
+				}
+			}
+			if hasConformance {
+				var r bool
+				r, err = renameConformanceCell(row.Cell(conformanceIndex), rt.name, to)
+				if err != nil {
+					return
+				}
+				renamed = renamed || r
+			}
+		}
+	}
+	return
+}
+
+// requiresCluster checks whether a requirement row is for the entity's cluster, by its ID or else its name
+func (rt *renameTarget) requiresCluster(s *spec.Specification, ti *spec.TableInfo, row *asciidoc.TableRow) bool {
+	id, _ := ti.ReadID(row, matter.TableColumnClusterID, matter.TableColumnID)
+	if id.Valid() && rt.cluster.ID.Valid() {
+		return id.Value() == rt.cluster.ID.Value()
+	}
+	name, _, err := ti.ReadName(row, matter.TableColumnCluster)
+	if err != nil || name == "" {
+		return false
+	}
+	return findCluster(s, name) == rt.cluster
+}
+
+func renameTypeCells(doc *spec.Doc, name string, pattern *regexp.Regexp, to string) (renamed bool, err error) {
+	for _, table := range parse.FindAll[*asciidoc.Table](doc.Elements()) {
+		var ti *spec.TableInfo
+		ti, err = spec.ReadTable(doc, table)
+		if err != nil {
+			return
+		}
+		index, ok := ti.ColumnIndex(matter.TableColumnType)
+		if !ok {
+			continue
+		}
+		for row := range ti.Body() {
+			cell := row.Cell(index)
+			var value string
+			value, err = spec.RenderTableCell(cell)
+			if err != nil {
+				return
+			}
+			value = strings.TrimSpace(value)
+			if list, ok := strings.CutPrefix(value, "list["); ok {
+				value = strings.TrimSuffix(list, "]")
+			}
+			if value != name {
+				continue
+			}
+			if renameStrings(cell.Elements(), pattern, to) {
+				renamed = true
+			}
+		}
+	}
+	return
+}
+
+// renameStrings replaces the name in the plain strings of a set, leaving cross references and formatting alone
+func renameStrings(set asciidoc.Set, pattern *regexp.Regexp, to string) (renamed bool) {
+	for _, el := range set {
+		s, ok := el.(*asciidoc.String)
+		if !ok {
+			continue
+		}
+		value := pattern.ReplaceAllString(s.Value, to)
+		if value != s.Value {
+			s.Value = value
+			renamed = true
+		}
+	}
+	return
+}
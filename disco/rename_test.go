@@ -0,0 +1,60 @@
+package disco
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+)
+
+var renameReferenceTests = []struct {
+	Entity   string
+	To       string
+	Path     string
+	Expected []string
+	Unwanted []string
+}{
+	// Both the in-progress and the released attribute tables
+	{"Widget.Speed", "Velocity", "src/app_clusters/Widget.adoc", []string{"| 0x0001 | Velocity | uint8 | max 100 ", "| 0x0001 | Velocity | uint8 | max 200 ", "=== Velocity Attribute"}, []string{"| Speed | uint8"}},
+	// A cluster derived from the renamed attribute's cluster
+	{"Widget.Speed", "Velocity", "src/app_clusters/FancyWidget.adoc", []string{"| 0x0001 | Velocity |", "| [!(Velocity)]"}, []string{"Speed"}},
+	// A device type's element requirements, leaving another cluster's attribute of the same name alone
+	{"Widget.Speed", "Velocity", "src/device_types/WidgetDevice.adoc", []string{"| 0xFFF1 | Widget  | Attribute | Velocity |", "| Velocity, O", "| 0xFFF2 | Gadget  | Attribute | Speed    |", "| Speed, O"}, nil},
+	{"Widget.Speed", "Velocity", "src/app_clusters/Gadget.adoc", []string{"| 0x0001 | Speed | uint8 | max 100 "}, []string{"Velocity"}},
+	{"Widget.SPD", "SPE", "src/device_types/WidgetDevice.adoc", []string{"| 0xFFF1 | Widget  | Attribute | Speed |         |            |        | SPE", "| 0xFFF2 | Gadget  | Attribute | Speed |         |            |        | SPD"}, nil},
+}
+
+func TestRenameReferences(t *testing.T) {
+	for _, rt := range renameReferenceTests {
+		specRoot := t.TempDir()
+		err := os.CopyFS(specRoot, os.DirFS("testdata/rename"))
+		if err != nil {
+			t.Fatalf("failed copying spec: %v", err)
+		}
+		err = Rename(context.Background(), specRoot, rt.Entity, rt.To, pipeline.Options{NoProgress: true}, nil, files.NewWriter[string]("Writing renamed docs", files.Options{}))
+		if err != nil {
+			t.Errorf("failed renaming %s to %s: %v", rt.Entity, rt.To, err)
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(specRoot, rt.Path))
+		if err != nil {
+			t.Errorf("failed reading %s: %v", rt.Path, err)
+			continue
+		}
+		doc := string(b)
+		for _, e := range rt.Expected {
+			if !strings.Contains(doc, e) {
+				t.Errorf("renaming %s to %s: expected %s to contain %q, got:\n%s", rt.Entity, rt.To, rt.Path, e, doc)
+			}
+		}
+		for _, u := range rt.Unwanted {
+			if strings.Contains(doc, u) {
+				t.Errorf("renaming %s to %s: expected %s not to contain %q, got:\n%s", rt.Entity, rt.To, rt.Path, u, doc)
+			}
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package disco
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sync"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/goccy/go-yaml"
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/internal/parse"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+// Rule is a disco-ball transformation that lives outside of this package, e.g. a house style rule
+type Rule interface {
+	Name() string
+	// Apply runs the rule against a document; each violation should be reported through RuleContext.Violation, and the document only changed if that returns false
+	Apply(cxt *RuleContext) error
+}
+
+type RuleContext struct {
+	context.Context
+
+	Doc      *spec.Doc
+	Settings RuleSettings
+
+	ball *Ball
+	top  *spec.Section
+	rule string
+}
+
+// Violation records a finding for the rule, and returns true if the ball is only linting, in which case the rule should not change anything
+func (rc *RuleContext) Violation(source any, format string, args ...any) (lintOnly bool) {
+	return rc.ball.violation(rc.rule, source, format, args...)
+}
+
+// ReplaceStrings reports every match of the pattern in the document's text, and replaces each match using fix unless the ball
+// is only linting; matches listed in the rule's ignore setting are left alone
+func (rc *RuleContext) ReplaceStrings(pattern *regexp.Regexp, description string, fix func(s string) string) {
+	parse.Traverse(rc.top, rc.top.Elements(), func(t *asciidoc.String, parent parse.HasElements, index int) parse.SearchShould {
+		rc.ball.cleanUpString(t, parent, parent.Elements(), index, rc.rule, pattern, description, fix)
+		return parse.SearchShouldContinue
+	})
+}
+
+// RuleSettings are the settings for a rule from the rule configuration
+type RuleSettings map[string]any
+
+// textRuleSettings are the settings shared by every rule that fixes matches in the document's text
+type textRuleSettings struct {
+	// Ignore lists matches the rule should leave alone, e.g. product names
+	Ignore []string `yaml:"ignore"`
+}
+
+// Decode unmarshals the settings into a struct with yaml tags
+func (rs RuleSettings) Decode(v any) error {
+	if len(rs) == 0 {
+		return nil
+	}
+	b, err := yaml.Marshal(rs)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(b, v)
+}
+
+var ruleRegistry = struct {
+	sync.RWMutex
+	rules map[string]Rule
+}{rules: make(map[string]Rule)}
+
+// RegisterRule adds a rule to the registry; registered rules are enabled unless the rule configuration disables them
+func RegisterRule(rule Rule) error {
+	name := rule.Name()
+	if _, ok := builtinRules[name]; ok {
+		return fmt.Errorf("rule name %s is already used by a built-in rule", name)
+	}
+	ruleRegistry.Lock()
+	defer ruleRegistry.Unlock()
+	if _, ok := ruleRegistry.rules[name]; ok {
+		return fmt.Errorf("rule %s is already registered", name)
+	}
+	ruleRegistry.rules[name] = rule
+	return nil
+}
+
+func registeredRules() []Rule {
+	ruleRegistry.RLock()
+	defer ruleRegistry.RUnlock()
+	rules := make([]Rule, 0, len(ruleRegistry.rules))
+	for _, r := range ruleRegistry.rules {
+		rules = append(rules, r)
+	}
+	slices.SortFunc(rules, func(a Rule, b Rule) int {
+		if a.Name() < b.Name() {
+			return -1
+		}
+		if a.Name() > b.Name() {
+			return 1
+		}
+		return 0
+	})
+	return rules
+}
+
+var builtinRules = map[string]func(o *options) *bool{
+	RuleLinkIndexTables:               func(o *options) *bool { return &o.linkIndexTables },
+	RuleAddMissingColumns:             func(o *options) *bool { return &o.addMissingColumns },
+	RuleReorderColumns:                func(o *options) *bool { return &o.reorderColumns },
+	RuleRenameTableHeaders:            func(o *options) *bool { return &o.renameTableHeaders },
+	RuleFormatAccess:                  func(o *options) *bool { return &o.formatAccess },
+	RulePromoteDataTypes:              func(o *options) *bool { return &o.promoteDataTypes },
+	RuleReorderSections:               func(o *options) *bool { return &o.reorderSections },
+	RuleNormalizeTableOptions:         func(o *options) *bool { return &o.normalizeTableOptions },
+	RuleFixCommandDirection:           func(o *options) *bool { return &o.fixCommandDirection },
+	RuleAppendSubsectionTypes:         func(o *options) *bool { return &o.appendSubsectionTypes },
+	RuleUppercaseHex:                  func(o *options) *bool { return &o.uppercaseHex },
+	RuleAddSpaceAfterPunctuation:      func(o *options) *bool { return &o.addSpaceAfterPunctuation },
+	RuleRemoveExtraSpaces:             func(o *options) *bool { return &o.removeExtraSpaces },
+	RuleNormalizeFeatureNames:         func(o *options) *bool { return &o.normalizeFeatureNames },
+	RuleDisambiguateConformanceChoice: func(o *options) *bool { return &o.disambiguateConformanceChoice },
+	RuleCanonicalConformance:          func(o *options) *bool { return &o.canonicalConformance },
+	RuleCrossCheckClusters:            func(o *options) *bool { return &o.crossCheckClusters },
+	RuleLinkClusterNames:              func(o *options) *bool { return &o.linkClusterNames },
+	RuleSortNamespaceTags:             func(o *options) *bool { return &o.sortNamespaceTags },
+	RuleNormalizeDeviceTypeTables:     func(o *options) *bool { return &o.normalizeDeviceTypeTables },
+	RuleNormalizeNamespaceTables:      func(o *options) *bool { return &o.normalizeNamespaceTables },
+	RuleNormalizeAnchors:              func(o *options) *bool { return &o.normalizeAnchors },
+	RuleFormatConformance:             func(o *options) *bool { return &o.formatConformance },
+	RuleFormatConstraint:              func(o *options) *bool { return &o.formatConstraint },
+	RuleCanonicalizeDataTypeNames:     func(o *options) *bool { return &o.canonicalizeDataTypeNames },
+	RuleFixBitmapRanges:               func(o *options) *bool { return &o.fixBitmapRanges },
+	RuleNormalizeSectionNames:         func(o *options) *bool { return &o.normalizeSectionNames },
+}
+
+// Config is the rule configuration checked into the spec repo
+type Config struct {
+	Rules map[string]RuleConfig `yaml:"rules"`
+}
+
+type RuleConfig struct {
+	// Enabled overrides the rule's default for all documents
+	Enabled *bool `yaml:"enabled,omitempty"`
+	// Enable and Disable are globs of document paths, relative to the spec root, that the rule is turned on or off for; Disable wins
+	Enable   []string     `yaml:"enable,omitempty"`
+	Disable  []string     `yaml:"disable,omitempty"`
+	Settings RuleSettings `yaml:"settings,omitempty"`
+}
+
+func ConfigPath(specRoot string) string {
+	return filepath.Join(specRoot, ".github/alchemy/disco.yaml")
+}
+
+// LoadConfig reads the rule configuration from the spec root; a missing file is an empty configuration
+func LoadConfig(specRoot string) (*Config, error) {
+	if specRoot == "" {
+		return nil, nil
+	}
+	path := ConfigPath(specRoot)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var c Config
+	err = yaml.Unmarshal(b, &c)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	err = c.validate()
+	if err != nil {
+		return nil, fmt.Errorf("error in %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+func (c *Config) validate() error {
+	registered := make(map[string]struct{})
+	for _, r := range registeredRules() {
+		registered[r.Name()] = struct{}{}
+	}
+	for name, rc := range c.Rules {
+		_, builtin := builtinRules[name]
+		_, house := registered[name]
+		if !builtin && !house {
+			slog.Warn("disco configuration refers to unknown rule", slog.String("rule", name))
+		}
+		for _, pattern := range slices.Concat(rc.Enable, rc.Disable) {
+			if !doublestar.ValidatePattern(pattern) {
+				return fmt.Errorf("invalid document pattern for rule %s: %s", name, pattern)
+			}
+		}
+		var settings textRuleSettings
+		if err := rc.Settings.Decode(&settings); err != nil {
+			return fmt.Errorf("invalid settings for rule %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// WithConfig applies the rule configuration to each document; options passed after it take precedence
+func WithConfig(c *Config) Option {
+	return func(b *Ball) {
+		b.config = c
+		if c == nil || b.doc == nil {
+			return
+		}
+		for name, field := range builtinRules {
+			on := field(&b.options)
+			*on = c.enabled(name, b.doc.Path.Relative, *on)
+		}
+	}
+}
+
+func (c *Config) enabled(name string, path string, on bool) bool {
+	if c == nil {
+		return on
+	}
+	rc, ok := c.Rules[name]
+	if !ok {
+		return on
+	}
+	if rc.Enabled != nil {
+		on = *rc.Enabled
+	}
+	path = filepath.ToSlash(path)
+	if matchesAny(rc.Enable, path) {
+		on = true
+	}
+	if matchesAny(rc.Disable, path) {
+		on = false
+	}
+	return on
+}
+
+func (c *Config) settings(name string) RuleSettings {
+	if c == nil {
+		return nil
+	}
+	return c.Rules[name].Settings
+}
+
+// ignored checks whether the rule's ignore setting lists the matched text
+func (c *Config) ignored(name string, match string) bool {
+	var settings textRuleSettings
+	if c.settings(name).Decode(&settings) != nil {
+		return false
+	}
+	return slices.Contains(settings.Ignore, match)
+}
+
+func matchesAny(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := doublestar.Match(pattern, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Ball) applyRules(cxt context.Context, top *spec.Section) error {
+	for _, rule := range registeredRules() {
+		name := rule.Name()
+		if !b.config.enabled(name, b.doc.Path.Relative, true) {
+			continue
+		}
+		err := rule.Apply(&RuleContext{Context: cxt, Doc: b.doc, Settings: b.config.settings(name), ball: b, top: top, rule: name})
+		if err != nil {
+			return fmt.Errorf("error applying rule %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package disco
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/asciidoc/render"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+)
+
+func TestConfigEnabled(t *testing.T) {
+	off := false
+	config := &Config{Rules: map[string]RuleConfig{
+		"someRule": {
+			Enabled: &off,
+			Enable:  []string{"src/app_clusters/**"},
+			Disable: []string{"src/app_clusters/Gadget.adoc"},
+		},
+		"otherRule": {
+			Disable: []string{"src/device_types/**"},
+		},
+	}}
+	tests := []struct {
+		rule     string
+		path     string
+		on       bool
+		expected bool
+	}{
+		{"someRule", "src/device_types/WidgetDevice.adoc", true, false},
+		{"someRule", "src/app_clusters/Widget.adoc", false, true},
+		// Disable wins over enable
+		{"someRule", "src/app_clusters/Gadget.adoc", true, false},
+		{"otherRule", "src/app_clusters/Widget.adoc", true, true},
+		{"otherRule", "src/app_clusters/Widget.adoc", false, false},
+		{"otherRule", "src/device_types/WidgetDevice.adoc", true, false},
+		{"unconfiguredRule", "src/app_clusters/Widget.adoc", true, true},
+		{"unconfiguredRule", "src/app_clusters/Widget.adoc", false, false},
+	}
+	for _, tt := range tests {
+		if on := config.enabled(tt.rule, tt.path, tt.on); on != tt.expected {
+			t.Errorf("expected %s to be %v for %s, got %v", tt.rule, tt.expected, tt.path, on)
+		}
+	}
+}
+
+// placeholders is a house rule for testing that replaces a placeholder with configured text
+type placeholders struct{}
+
+type placeholderSettings struct {
+	Replacement string `yaml:"replacement"`
+}
+
+var placeholderPattern = regexp.MustCompile(`\bTBD\b`)
+
+func init() {
+	err := RegisterRule(placeholders{})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (placeholders) Name() string {
+	return "placeholders"
+}
+
+func (placeholders) Apply(cxt *RuleContext) error {
+	var settings placeholderSettings
+	err := cxt.Settings.Decode(&settings)
+	if err != nil || settings.Replacement == "" {
+		return err
+	}
+	cxt.ReplaceStrings(placeholderPattern, "placeholder", func(s string) string {
+		return placeholderPattern.ReplaceAllLiteralString(s, settings.Replacement)
+	})
+	return nil
+}
+
+var ruleConfig = `rules:
+  placeholders:
+    disable:
+      - src/app_clusters/Gadget.adoc
+    settings:
+      replacement: to be determined
+      ignore:
+        - TBD
+  uppercaseHex:
+    enabled: false
+    enable:
+      - src/app_clusters/**
+    disable:
+      - src/app_clusters/Gadget.adoc
+    settings:
+      ignore:
+        - "0xab"
+`
+
+func TestRules(t *testing.T) {
+	specRoot := t.TempDir()
+	err := os.CopyFS(specRoot, os.DirFS("testdata/rename"))
+	if err != nil {
+		t.Fatalf("failed copying spec: %v", err)
+	}
+	err = os.MkdirAll(filepath.Dir(ConfigPath(specRoot)), 0755)
+	if err != nil {
+		t.Fatalf("failed creating config directory: %v", err)
+	}
+	var paths []string
+	for _, name := range []string{"Widget", "Gadget"} {
+		path := filepath.Join(specRoot, "src/app_clusters", name+".adoc")
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed reading %s: %v", path, err)
+		}
+		doc := strings.Replace(string(b), "== Revision History\n", "The value is TBD, and one of 0xab or 0xcd.\n\n== Revision History\n", 1)
+		err = os.WriteFile(path, []byte(doc), 0644)
+		if err != nil {
+			t.Fatalf("failed writing %s: %v", path, err)
+		}
+		paths = append(paths, path)
+	}
+
+	pipelineOptions := pipeline.Options{NoProgress: true}
+	lint := func(config string, expected ...string) {
+		err := os.WriteFile(ConfigPath(specRoot), []byte(config), 0644)
+		if err != nil {
+			t.Fatalf("failed writing config: %v", err)
+		}
+		findings, err := Lint(context.Background(), specRoot, paths, pipelineOptions, nil)
+		if err != nil {
+			t.Fatalf("failed linting: %v", err)
+		}
+		var ruleFindings []string
+		for _, f := range findings {
+			if f.Rule == "placeholders" || f.Rule == RuleUppercaseHex {
+				ruleFindings = append(ruleFindings, f.String())
+			}
+		}
+		if strings.Join(ruleFindings, "\n") != strings.Join(expected, "\n") {
+			t.Errorf("unexpected findings:\n%s\nexpected:\n%s", strings.Join(ruleFindings, "\n"), strings.Join(expected, "\n"))
+		}
+	}
+	hexFinding := `src/app_clusters/Widget.adoc:4: lowercase hex value: "0xcd" (uppercaseHex)`
+	// Matches listed in the ignore setting are left alone by built-in and house rules alike
+	lint(ruleConfig, hexFinding)
+	lint(strings.Replace(ruleConfig, "      ignore:\n        - TBD\n", "", 1), `src/app_clusters/Widget.adoc:4: placeholder: "TBD" (placeholders)`, hexFinding)
+
+	err = Pipeline(context.Background(), specRoot, paths, pipelineOptions, nil, []render.Option{}, files.NewWriter[string]("Writing disco-balled docs", files.Options{}))
+	if err != nil {
+		t.Fatalf("failed disco-balling: %v", err)
+	}
+	for path, line := range map[string]string{
+		paths[0]: "The value is to be determined, and one of 0xab or 0xCD.",
+		paths[1]: "The value is TBD, and one of 0xab or 0xcd.",
+	} {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed reading %s: %v", path, err)
+		}
+		if !strings.Contains(string(b), line) {
+			t.Errorf("expected %s to contain %q, got:\n%s", path, line, string(b))
+		}
+	}
+}
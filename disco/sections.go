@@ -3,6 +3,7 @@ package disco
 import (
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/project-chip/alchemy/asciidoc"
@@ -26,6 +27,9 @@ func (b *Ball) organizeSubSections(dc *discoContext, dp *docParse) (err error) {
 		b.organizeStructSections,
 		b.organizeCommandsSection,
 		b.organizeEventsSection,
+		b.organizeConditionsSection,
+		b.organizeRequirementsSections,
+		b.organizeNamespaceSections,
 	}
 	for _, organizer := range organizers {
 		err = organizer(dc, dp)
@@ -36,7 +40,7 @@ func (b *Ball) organizeSubSections(dc *discoContext, dp *docParse) (err error) {
 	return
 }
 
-func reorderSection(sec *spec.Section, sectionOrder []matter.Section) error {
+func (b *Ball) reorderSection(sec *spec.Section, sectionOrder []matter.Section) error {
 	validSectionTypes := make(map[matter.Section]struct{}, len(sectionOrder)+1)
 	for _, st := range sectionOrder {
 		validSectionTypes[st] = struct{}{}
@@ -55,6 +59,9 @@ func reorderSection(sec *spec.Section, sectionOrder []matter.Section) error {
 		return fmt.Errorf("non-empty section list after reordering")
 	}
 
+	if !slices.Equal(parse.Skim[*spec.Section](newOrder), parse.Skim[*spec.Section](sec.Elements())) && b.violation(RuleReorderSections, sec, "subsections of section %s are not in the standard order", sec.Name) {
+		return nil
+	}
 	return sec.SetElements(newOrder)
 }
 
@@ -129,6 +136,9 @@ func (b *Ball) appendSubsectionTypes(section *spec.Section, columnMap spec.Colum
 				ss.SecType = subsectionType
 			}
 			if !strings.HasSuffix(strings.ToLower(ss.Name), strings.ToLower(suffix)) {
+				if b.violation(RuleAppendSubsectionTypes, ss, "section %s should be named %s", ss.Name, ss.Name+suffix) {
+					continue
+				}
 				setSectionTitle(ss, ss.Name+suffix)
 			}
 		}
@@ -1,11 +1,13 @@
 package disco
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
 	"github.com/project-chip/alchemy/asciidoc"
 	"github.com/project-chip/alchemy/internal/parse"
+	"github.com/project-chip/alchemy/matter/spec"
 )
 
 var missingSpaceAfterPunctuationPattern = regexp.MustCompile(`([a-z])([.?!,])([A-Z])`)
@@ -20,21 +22,50 @@ func precleanStrings(els asciidoc.Set) {
 	})
 }
 
-func (b *Ball) postCleanUpStrings(els asciidoc.Set) {
-	parse.Search(els, func(t *asciidoc.String) parse.SearchShould {
+func (b *Ball) postCleanUpStrings(top *spec.Section) {
+	parse.Traverse(top, top.Elements(), func(t *asciidoc.String, parent parse.HasElements, index int) parse.SearchShould {
 		if b.options.addSpaceAfterPunctuation {
-			t.Value = missingSpaceAfterPunctuationPattern.ReplaceAllString(t.Value, "$1$2 $3")
+			b.cleanUpString(t, parent, parent.Elements(), index, RuleAddSpaceAfterPunctuation, missingSpaceAfterPunctuationPattern, "missing space after punctuation", func(s string) string {
+				return missingSpaceAfterPunctuationPattern.ReplaceAllString(s, "$1$2 $3")
+			})
 		}
 		if b.options.removeExtraSpaces {
-			t.Value = multipleSpacesPattern.ReplaceAllString(t.Value, "$1 $2")
+			b.cleanUpString(t, parent, parent.Elements(), index, RuleRemoveExtraSpaces, multipleSpacesPattern, "extra spaces", func(s string) string {
+				return multipleSpacesPattern.ReplaceAllString(s, "$1 $2")
+			})
 		}
 		if b.options.uppercaseHex {
-			t.Value = lowercaseHexPattern.ReplaceAllStringFunc(t.Value, func(s string) string {
-				return lowercasePattern.ReplaceAllStringFunc(s, func(s string) string {
-					return strings.ToUpper(s)
+			b.cleanUpString(t, parent, parent.Elements(), index, RuleUppercaseHex, lowercaseHexPattern, "lowercase hex value", func(s string) string {
+				return lowercaseHexPattern.ReplaceAllStringFunc(s, func(s string) string {
+					return lowercasePattern.ReplaceAllStringFunc(s, func(s string) string {
+						return strings.ToUpper(s)
+					})
 				})
 			})
 		}
 		return parse.SearchShouldContinue
 	})
 }
+
+func (b *Ball) cleanUpString(t *asciidoc.String, parent parse.HasElements, siblings asciidoc.Set, index int, rule string, pattern *regexp.Regexp, description string, fix func(s string) string) {
+	var lintOnly, fixed bool
+	var value strings.Builder
+	var last int
+	for _, match := range pattern.FindAllStringIndex(t.Value, -1) {
+		s := t.Value[match[0]:match[1]]
+		if b.config.ignored(rule, s) {
+			continue
+		}
+		path, line := b.origin(parent)
+		line = stringLine(parent, line, siblings, index, t, match[0])
+		lintOnly = b.report(Finding{Rule: rule, Path: path, Line: line, Message: fmt.Sprintf("%s: %q", description, s)})
+		value.WriteString(t.Value[last:match[0]])
+		value.WriteString(fix(s))
+		last = match[1]
+		fixed = true
+	}
+	if fixed && !lintOnly {
+		value.WriteString(t.Value[last:])
+		t.Value = value.String()
+	}
+}
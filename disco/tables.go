@@ -3,7 +3,9 @@ package disco
 import (
 	"fmt"
 	"log/slog"
+	"maps"
 	"slices"
+	"strings"
 
 	"github.com/project-chip/alchemy/asciidoc"
 	"github.com/project-chip/alchemy/errata"
@@ -25,16 +27,27 @@ func (b *Ball) ensureTableOptions(els asciidoc.Set) {
 				continue
 			}
 			if _, ok := matter.AllowedTableAttributes[na.Name]; !ok {
+				if b.violation(RuleNormalizeTableOptions, t, "table should not have %s", na.Name) {
+					continue
+				}
 				excludedIndexes = append(excludedIndexes, i)
 			}
 		}
 		for i := len(excludedIndexes) - 1; i >= 0; i-- {
-			t.AttributeList = slices.Delete(t.AttributeList, i, i)
+			t.AttributeList = slices.Delete(t.AttributeList, excludedIndexes[i], excludedIndexes[i]+1)
 		}
-		for k, v := range matter.AllowedTableAttributes {
-			if v != nil {
-				t.SetAttribute(k, v)
+		for _, k := range slices.Sorted(maps.Keys(matter.AllowedTableAttributes)) {
+			v := matter.AllowedTableAttributes[k]
+			if v == nil {
+				continue
 			}
+			existing := t.GetAttributeByName(k)
+			if existing == nil || asciidoc.AttributeAsciiDocString(existing.Val) != asciidoc.AttributeAsciiDocString(v) {
+				if b.violation(RuleNormalizeTableOptions, t, "table should have %s=%s", k, asciidoc.AttributeAsciiDocString(v)) {
+					continue
+				}
+			}
+			t.SetAttribute(k, v)
 		}
 		return parse.SearchShouldContinue
 	})
@@ -48,19 +61,29 @@ func (b *Ball) addMissingColumns(section *spec.Section, ti *spec.TableInfo, tabl
 	if b.errata.IgnoreSection(section.Name, errata.DiscoPurposeTableAddMissingColumns) {
 		return
 	}
-	ti.Element.DeleteAttribute(asciidoc.AttributeNameColumns)
 	var order []matter.TableColumn
 	if len(tableTemplate.RequiredColumns) > 0 {
 		order = tableTemplate.RequiredColumns
 	} else {
 		order = tableTemplate.ColumnOrder
 	}
+	var missing []matter.TableColumn
 	for _, column := range order {
 		if _, ok := ti.ColumnMap[column]; !ok {
-			_, err = b.appendColumn(ti, column, entityType)
-			if err != nil {
-				return
-			}
+			missing = append(missing, column)
+		}
+	}
+	for _, column := range missing {
+		b.violation(RuleAddMissingColumns, ti, "table in section %s is missing %s column", section.Name, columnName(column))
+	}
+	if b.options.lint {
+		return
+	}
+	ti.Element.DeleteAttribute(asciidoc.AttributeNameColumns)
+	for _, column := range missing {
+		_, err = b.appendColumn(ti, column, entityType)
+		if err != nil {
+			return
 		}
 	}
 	return
@@ -210,6 +233,9 @@ func (b *Ball) reorderColumns(doc *spec.Doc, section *spec.Section, ti *spec.Tab
 			index++
 		}
 	}
+	if b.reportColumnOrder(section, ti, newColumnIndexes) {
+		return
+	}
 	for i, v := range newColumnIndexes {
 		if v != -2 {
 			continue
@@ -270,6 +296,40 @@ func (b *Ball) reorderColumns(doc *spec.Doc, section *spec.Section, ti *spec.Tab
 	return
 }
 
+func columnName(column matter.TableColumn) string {
+	if name, ok := matter.TableColumnNames[column]; ok {
+		return name
+	}
+	return column.String()
+}
+
+// reportColumnOrder records findings for banned or out of order columns, and returns true if the table should not be rewritten
+func (b *Ball) reportColumnOrder(section *spec.Section, ti *spec.TableInfo, newColumnIndexes []int) (lintOnly bool) {
+	names := make(map[int]string, len(ti.ColumnMap))
+	for tc, i := range ti.ColumnMap {
+		names[i] = columnName(tc)
+	}
+	expected := make([]string, len(newColumnIndexes))
+	last := -1
+	var reordered bool
+	for i, v := range newColumnIndexes {
+		if v == -2 {
+			lintOnly = b.violation(RuleReorderColumns, ti, "table in section %s has disallowed %s column", section.Name, names[i])
+			continue
+		}
+		if v < last {
+			reordered = true
+		}
+		last = v
+		expected[v] = names[i]
+	}
+	if reordered {
+		expected = slices.DeleteFunc(expected, func(s string) bool { return s == "" })
+		lintOnly = b.violation(RuleReorderColumns, ti, "columns of table in section %s are not in the standard order: %s", section.Name, strings.Join(expected, ", "))
+	}
+	return
+}
+
 func setCellString(cell *asciidoc.TableCell, v string) (err error) {
 	se := asciidoc.NewString(v)
 	err = cell.SetElements(asciidoc.Set{se})
@@ -330,12 +390,19 @@ func (b *Ball) renameTableHeaderCells(doc *spec.Doc, section *spec.Section, tabl
 		}
 		name, ok := matter.GetColumnName(tc, overrides)
 		if ok {
+			existing, _ := spec.RenderTableCell(cell)
+			if strings.TrimSpace(existing) != name && b.violation(RuleRenameTableHeaders, cell, "table header %q in section %s should be %q", strings.TrimSpace(existing), section.Name, name) {
+				continue
+			}
 			err = setCellString(cell, name)
 			if err != nil {
 				return
 			}
 		}
 	}
+	if b.options.lint {
+		return
+	}
 	err = table.Rescan(doc)
 	return
 }
@@ -0,0 +1,91 @@
+package disco
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/asciidoc/render"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+)
+
+func TestTableOptions(t *testing.T) {
+	specRoot := t.TempDir()
+	err := os.CopyFS(specRoot, os.DirFS("testdata/rename"))
+	if err != nil {
+		t.Fatalf("failed copying spec: %v", err)
+	}
+	path := filepath.Join(specRoot, "src/app_clusters/Widget.adoc")
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed reading %s: %v", path, err)
+	}
+	doc := strings.Replace(string(b), "== Revision History\n\n[options=\"header\",valign=\"middle\"]", "== Revision History\n\n[options=\"header\"]", 1)
+	doc = strings.Replace(doc, "== Classification\n\n[options=\"header\",valign=\"middle\"]", "== Classification\n\n[frame=\"all\",width=\"100%\"]", 1)
+	err = os.WriteFile(path, []byte(doc), 0644)
+	if err != nil {
+		t.Fatalf("failed writing %s: %v", path, err)
+	}
+
+	pipelineOptions := pipeline.Options{NoProgress: true}
+	expected := []string{
+		"Widget.adoc:6: table should have valign=middle (normalizeTableOptions)",
+		"Widget.adoc:14: table should not have frame (normalizeTableOptions)",
+		"Widget.adoc:14: table should not have width (normalizeTableOptions)",
+		"Widget.adoc:14: table should have options=header (normalizeTableOptions)",
+		"Widget.adoc:14: table should have valign=middle (normalizeTableOptions)",
+	}
+	findings, err := Lint(context.Background(), specRoot, []string{path}, pipelineOptions, nil)
+	if err != nil {
+		t.Fatalf("failed linting: %v", err)
+	}
+	var tableFindings []string
+	for _, f := range findings {
+		if f.Rule == RuleNormalizeTableOptions {
+			tableFindings = append(tableFindings, f.String())
+		}
+	}
+	if len(tableFindings) != len(expected) {
+		t.Errorf("expected %d table option findings, got %d: %v", len(expected), len(tableFindings), tableFindings)
+	}
+	for _, e := range expected {
+		var found bool
+		for _, f := range tableFindings {
+			if strings.HasSuffix(f, e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected finding %q, got %v", e, tableFindings)
+		}
+	}
+
+	err = Pipeline(context.Background(), specRoot, []string{path}, pipelineOptions, nil, []render.Option{}, files.NewWriter[string]("Writing disco-balled docs", files.Options{}))
+	if err != nil {
+		t.Fatalf("failed disco-balling: %v", err)
+	}
+	b, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed reading %s: %v", path, err)
+	}
+	doc = string(b)
+	for _, s := range []string{"== Revision History\n\n[options=\"header\",valign=\"middle\"]", "== Classification\n\n[options=\"header\",valign=\"middle\"]"} {
+		if !strings.Contains(doc, s) {
+			t.Errorf("expected disco-balled doc to contain %q, got:\n%s", s, doc)
+		}
+	}
+
+	findings, err = Lint(context.Background(), specRoot, []string{path}, pipelineOptions, nil)
+	if err != nil {
+		t.Fatalf("failed linting: %v", err)
+	}
+	for _, f := range findings {
+		if f.Rule == RuleNormalizeTableOptions {
+			t.Errorf("unexpected finding after disco-balling: %s", f.String())
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package disco
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// terminology is a house rule that replaces discouraged terms with preferred ones; it does nothing until terms are configured
+type terminology struct{}
+
+type terminologySettings struct {
+	// Terms maps discouraged terms to their replacements; terms are matched as whole words
+	Terms map[string]string `yaml:"terms"`
+}
+
+func init() {
+	err := RegisterRule(terminology{})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (terminology) Name() string {
+	return "terminology"
+}
+
+func (terminology) Apply(cxt *RuleContext) error {
+	var settings terminologySettings
+	err := cxt.Settings.Decode(&settings)
+	if err != nil {
+		return fmt.Errorf("invalid terminology settings: %w", err)
+	}
+	terms := make([]string, 0, len(settings.Terms))
+	for term := range settings.Terms {
+		terms = append(terms, term)
+	}
+	// Longest terms first, so a term that contains another is replaced whole
+	slices.SortFunc(terms, func(a string, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return strings.Compare(a, b)
+	})
+	for _, term := range terms {
+		replacement := settings.Terms[term]
+		pattern, err := regexp.Compile(`\b` + regexp.QuoteMeta(term) + `\b`)
+		if err != nil {
+			return err
+		}
+		cxt.ReplaceStrings(pattern, fmt.Sprintf("use %q instead of", replacement), func(s string) string {
+			return pattern.ReplaceAllLiteralString(s, replacement)
+		})
+	}
+	return nil
+}
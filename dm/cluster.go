@@ -138,8 +138,18 @@ func (p *Renderer) renderAppCluster(doc *spec.Doc, entity types.Entity) (output
 	_, err = x.WriteTo(&b)
 	output = b.String()
 
-	p.clustersLock.Lock()
-	p.clusters = append(p.clusters, clusters...)
-	p.clustersLock.Unlock()
+	if extendedCluster(doc, cluster) == nil { // Vendor extensions aren't clusters of their own
+		p.clustersLock.Lock()
+		p.clusters = append(p.clusters, clusters...)
+		p.clustersLock.Unlock()
+	}
 	return
 }
+
+// extendedCluster returns the standard cluster a vendor cluster extension adds to, or nil if it's not an extension
+func extendedCluster(doc *spec.Doc, cluster *matter.Cluster) *matter.Cluster {
+	if doc.Spec() == nil {
+		return nil
+	}
+	return doc.Spec().ClusterExtensions[cluster]
+}
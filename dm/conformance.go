@@ -41,13 +41,10 @@ func RenderConformanceElement(doc *spec.Doc, identifierStore conformance.Identif
 func renderConformance(doc *spec.Doc, identifierStore conformance.IdentifierStore, con conformance.Conformance, parent *etree.Element) (err error) {
 	switch con := con.(type) {
 	case *conformance.Mandatory:
-		_, isEquality := con.Expression.(*conformance.EqualityExpression)
-		if !isEquality {
-			mc := parent.CreateElement("mandatoryConform")
-			err = renderConformanceExpression(doc, identifierStore, con.Expression, mc)
-			if err != nil {
-				return
-			}
+		mc := parent.CreateElement("mandatoryConform")
+		err = renderConformanceExpression(doc, identifierStore, con.Expression, mc)
+		if err != nil {
+			return
 		}
 	case *conformance.Provisional:
 		parent.CreateElement("provisionalConform")
@@ -78,6 +75,7 @@ func renderConformance(doc *spec.Doc, identifierStore conformance.IdentifierStor
 	case *conformance.Deprecated:
 		parent.CreateElement("deprecateConform")
 	case *conformance.Described:
+		parent.CreateElement("describedConform")
 	case *conformance.Generic:
 	case conformance.Set:
 		for _, con := range con {
@@ -140,33 +138,7 @@ func renderConformanceExpression(doc *spec.Doc, identifierStore conformance.Iden
 		if e.Not {
 			parent = parent.CreateElement("notTerm")
 		}
-		if doc == nil {
-			parent.CreateElement("condition").CreateAttr("name", e.Reference)
-		} else {
-			entity, ok := doc.Reference(e.Reference)
-			if !ok {
-				parent.CreateElement("condition").CreateAttr("name", e.Reference)
-			} else {
-				switch entity := entity.(type) {
-				case *matter.Field:
-					switch entity.EntityType() {
-					case types.EntityTypeAttribute:
-						parent.CreateElement("attribute").CreateAttr("name", entity.Name)
-					case types.EntityTypeStructField:
-						parent.CreateElement("field").CreateAttr("name", entity.Name)
-					}
-				case *matter.Command:
-					parent.CreateElement("command").CreateAttr("name", entity.Name)
-				default:
-					switch entity.EntityType() {
-					case types.EntityTypeCondition:
-						parent.CreateElement("attribute").CreateAttr("name", e.Reference)
-					default:
-						parent.CreateElement("condition").CreateAttr("name", e.Reference)
-					}
-				}
-			}
-		}
+		renderReference(doc, parent, e.Reference)
 	case *conformance.ComparisonExpression:
 		switch e.Op {
 		case conformance.ComparisonOperatorGreaterThan:
@@ -180,11 +152,11 @@ func renderConformanceExpression(doc *spec.Doc, identifierStore conformance.Iden
 		default:
 			return fmt.Errorf("unexpected comparison expression operator: %s", e.Op.String())
 		}
-		err := renderComparisonValue(identifierStore, e.Left, parent)
+		err := renderComparisonValue(doc, identifierStore, e.Left, parent)
 		if err != nil {
 			return err
 		}
-		err = renderComparisonValue(identifierStore, e.Right, parent)
+		err = renderComparisonValue(doc, identifierStore, e.Right, parent)
 		if err != nil {
 			return err
 		}
@@ -216,12 +188,44 @@ func renderIdentifier(identifierStore conformance.IdentifierStore, parent *etree
 	}
 }
 
-func renderComparisonValue(identifierStore conformance.IdentifierStore, value conformance.ComparisonValue, parent *etree.Element) (err error) {
+func renderReference(doc *spec.Doc, parent *etree.Element, reference string) {
+	if doc == nil {
+		parent.CreateElement("condition").CreateAttr("name", reference)
+		return
+	}
+	entity, ok := doc.Reference(reference)
+	if !ok {
+		parent.CreateElement("condition").CreateAttr("name", reference)
+		return
+	}
+	switch entity := entity.(type) {
+	case *matter.Field:
+		switch entity.EntityType() {
+		case types.EntityTypeAttribute:
+			parent.CreateElement("attribute").CreateAttr("name", entity.Name)
+		case types.EntityTypeStructField:
+			parent.CreateElement("field").CreateAttr("name", entity.Name)
+		}
+	case *matter.Command:
+		parent.CreateElement("command").CreateAttr("name", entity.Name)
+	default:
+		switch entity.EntityType() {
+		case types.EntityTypeCondition:
+			parent.CreateElement("attribute").CreateAttr("name", reference)
+		default:
+			parent.CreateElement("condition").CreateAttr("name", reference)
+		}
+	}
+}
+
+func renderComparisonValue(doc *spec.Doc, identifierStore conformance.IdentifierStore, value conformance.ComparisonValue, parent *etree.Element) (err error) {
 	switch value := value.(type) {
 	case *conformance.FeatureValue:
 		parent.CreateElement("feature").CreateAttr("name", value.Feature)
 	case *conformance.IdentifierValue:
 		renderIdentifier(identifierStore, parent, value.ID)
+	case *conformance.ReferenceValue:
+		renderReference(doc, parent, value.Reference)
 	case *conformance.IntValue:
 		parent.CreateElement("literal").CreateAttr("value", strconv.FormatInt(value.Int, 10))
 	case *conformance.FloatValue:
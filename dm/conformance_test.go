@@ -0,0 +1,45 @@
+package dm
+
+import (
+	"testing"
+
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/matter/conformance"
+)
+
+var conformanceTests = []struct {
+	conformance string
+	xml         string
+}{
+	{"M", `<mandatoryConform/>`},
+	{"AB", `<mandatoryConform><feature name="AB"/></mandatoryConform>`},
+	{"Foo == Bar", `<mandatoryConform><equalTerm><condition name="Foo"/><condition name="Bar"/></equalTerm></mandatoryConform>`},
+	{"Foo > 3", `<mandatoryConform><greaterTerm><condition name="Foo"/><literal value="3"/></greaterTerm></mandatoryConform>`},
+	{"desc", `<describedConform/>`},
+	{"AB, desc", `<otherwiseConform><mandatoryConform><feature name="AB"/></mandatoryConform><describedConform/></otherwiseConform>`},
+}
+
+func TestRenderConformance(t *testing.T) {
+	for _, ct := range conformanceTests {
+		doc := etree.NewDocument()
+		root := doc.CreateElement("attribute")
+		err := RenderConformanceElement(nil, nil, conformance.ParseConformance(ct.conformance), root)
+		if err != nil {
+			t.Errorf("failed rendering conformance %q: %v", ct.conformance, err)
+			continue
+		}
+		var actual string
+		for _, el := range root.ChildElements() {
+			d := etree.NewDocument()
+			d.SetRoot(el.Copy())
+			s, err := d.WriteToString()
+			if err != nil {
+				t.Fatalf("failed writing conformance %q: %v", ct.conformance, err)
+			}
+			actual += s
+		}
+		if actual != ct.xml {
+			t.Errorf("unexpected XML for conformance %q:\n%s\nexpected:\n%s", ct.conformance, actual, ct.xml)
+		}
+	}
+}
@@ -43,6 +43,7 @@ const (
 	DiscoPurposeDataTypeCommandFixDirection              = 1 << (iota - 1)
 	DiscoPurposeDataTypePromoteInline                    = 1 << (iota - 1)
 	DiscoPurposeNormalizeAnchor                          = 1 << (iota - 1)
+	DiscoPurposeTableSortRows                            = 1 << (iota - 1)
 
 	DiscoPurposeAll DiscoPurpose = DiscoPurposeTableAccess | DiscoPurposeTableConformance | DiscoPurposeTableConstraint | DiscoPurposeTableLinkIndexes | DiscoPurposeTableRenameHeaders | DiscoPurposeTableAddMissingColumns | DiscoPurposeTableReorderColumns | DiscoPurposeDataTypeAppendSuffix | DiscoPurposeDataTypeRename
 )
@@ -61,6 +62,7 @@ var discoPurposes = map[string]DiscoPurpose{
 	"data-type-command-fix-direction": DiscoPurposeDataTypeCommandFixDirection,
 	"data-type-promote-inline":        DiscoPurposeDataTypePromoteInline,
 	"normalize-anchor":                DiscoPurposeNormalizeAnchor,
+	"table-sort-rows":                 DiscoPurposeTableSortRows,
 	"all":                             DiscoPurposeAll,
 }
 
@@ -0,0 +1,336 @@
+package idl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/zap"
+)
+
+type cluster struct {
+	name        string
+	id          *matter.Number
+	description string
+	revision    string
+
+	features   *matter.Features
+	bitmaps    matter.BitmapSet
+	enums      matter.EnumSet
+	structs    matter.StructSet
+	attributes matter.FieldSet
+	commands   matter.CommandSet
+	events     matter.EventSet
+}
+
+func newCluster(c *matter.Cluster) *cluster {
+	ic := &cluster{
+		name:        c.Name,
+		id:          c.ID,
+		description: c.Description,
+		features:    c.Features,
+		bitmaps:     c.Bitmaps,
+		enums:       c.Enums,
+		structs:     c.Structs,
+		attributes:  c.Attributes,
+		commands:    c.Commands,
+		events:      c.Events,
+	}
+	if len(c.Revisions) > 0 {
+		ic.revision = c.Revisions[len(c.Revisions)-1].Number
+	}
+	return ic
+}
+
+func (ic *cluster) extend(extension *matter.Cluster) {
+	ic.bitmaps = append(ic.bitmaps[:len(ic.bitmaps):len(ic.bitmaps)], extension.Bitmaps...)
+	ic.enums = append(ic.enums[:len(ic.enums):len(ic.enums)], extension.Enums...)
+	ic.structs = append(ic.structs[:len(ic.structs):len(ic.structs)], extension.Structs...)
+	ic.attributes = append(ic.attributes[:len(ic.attributes):len(ic.attributes)], extension.Attributes...)
+	ic.commands = append(ic.commands[:len(ic.commands):len(ic.commands)], extension.Commands...)
+	ic.events = append(ic.events[:len(ic.events):len(ic.events)], extension.Events...)
+}
+
+func (ic *cluster) render(sb *strings.Builder) error {
+	if !ic.id.Valid() {
+		return fmt.Errorf("cluster %s has no valid ID", ic.name)
+	}
+	writeDescription(sb, "", ic.description)
+	fmt.Fprintf(sb, "cluster %s = %s {\n", idlTypeName(ic.name), ic.id.IntString())
+	if ic.revision != "" {
+		fmt.Fprintf(sb, "  revision %s;\n", ic.revision)
+	}
+	for _, e := range ic.enums {
+		sb.WriteRune('\n')
+		renderEnum(sb, e)
+	}
+	if ic.features != nil && len(ic.features.Bits) > 0 {
+		sb.WriteRune('\n')
+		renderBits(sb, "Feature", "bitmap32", ic.features.Bits)
+	}
+	for _, bm := range ic.bitmaps {
+		sb.WriteRune('\n')
+		renderBits(sb, idlTypeName(bm.Name), baseTypeName(bm.Type, "bitmap8"), bm.Bits)
+	}
+	for _, s := range ic.structs {
+		sb.WriteRune('\n')
+		renderStruct(sb, s)
+	}
+	for _, e := range ic.events {
+		if excluded(ic.attributes, e.Conformance) {
+			continue
+		}
+		sb.WriteRune('\n')
+		renderEvent(sb, e)
+	}
+	sb.WriteRune('\n')
+	ic.renderAttributes(sb)
+	for _, c := range ic.commands {
+		if excluded(ic.attributes, c.Conformance) {
+			continue
+		}
+		renderCommandFields(sb, c)
+	}
+	var first = true
+	for _, c := range ic.commands {
+		if c.Direction != matter.InterfaceServer || excluded(ic.attributes, c.Conformance) {
+			continue
+		}
+		if first {
+			sb.WriteRune('\n')
+			first = false
+		}
+		renderCommand(sb, c)
+	}
+	sb.WriteString("}\n")
+	return nil
+}
+
+func (ic *cluster) renderAttributes(sb *strings.Builder) {
+	for _, a := range ic.attributes {
+		if excluded(ic.attributes, a.Conformance) {
+			continue
+		}
+		sb.WriteString("  ")
+		if a.Access.Write == matter.PrivilegeUnknown {
+			sb.WriteString("readonly ")
+		}
+		if a.Access.IsTimed() {
+			sb.WriteString("timedwrite ")
+		}
+		sb.WriteString("attribute ")
+		var access []string
+		if a.Access.Read != matter.PrivilegeUnknown && a.Access.Read != matter.PrivilegeView {
+			access = append(access, "read: "+privilegeName(a.Access.Read))
+		}
+		if a.Access.Write != matter.PrivilegeUnknown && a.Access.Write != matter.PrivilegeOperate {
+			access = append(access, "write: "+privilegeName(a.Access.Write))
+		}
+		if len(access) > 0 {
+			fmt.Fprintf(sb, "access(%s) ", strings.Join(access, ", "))
+		}
+		sb.WriteString(fieldDeclaration(ic.attributes, a))
+		sb.WriteString(";\n")
+	}
+	sb.WriteString("  readonly attribute command_id generatedCommandList[] = 65528;\n")
+	sb.WriteString("  readonly attribute command_id acceptedCommandList[] = 65529;\n")
+	sb.WriteString("  readonly attribute attrib_id attributeList[] = 65531;\n")
+	sb.WriteString("  readonly attribute bitmap32 featureMap = 65532;\n")
+	sb.WriteString("  readonly attribute int16u clusterRevision = 65533;\n")
+}
+
+func renderEnum(sb *strings.Builder, e *matter.Enum) {
+	fmt.Fprintf(sb, "  enum %s : %s {\n", idlTypeName(e.Name), baseTypeName(e.Type, "enum8"))
+	for _, v := range e.Values {
+		if !v.Value.Valid() || conformance.IsDisallowed(v.Conformance) {
+			continue
+		}
+		fmt.Fprintf(sb, "    k%s = %s;\n", idlTypeName(v.Name), v.Value.IntString())
+	}
+	sb.WriteString("  }\n")
+}
+
+func renderBits(sb *strings.Builder, name string, baseType string, bits matter.BitSet) {
+	fmt.Fprintf(sb, "  bitmap %s : %s {\n", name, baseType)
+	for _, b := range bits {
+		if conformance.IsDisallowed(b.Conformance()) {
+			continue
+		}
+		mask, err := b.Mask()
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(sb, "    k%s = 0x%X;\n", idlTypeName(b.Name()), mask)
+	}
+	sb.WriteString("  }\n")
+}
+
+func renderStruct(sb *strings.Builder, s *matter.Struct) {
+	sb.WriteString("  ")
+	if s.FabricScoping == matter.FabricScopingScoped {
+		sb.WriteString("fabric_scoped ")
+	}
+	fmt.Fprintf(sb, "struct %s {\n", idlTypeName(s.Name))
+	renderFields(sb, s.Fields)
+	if s.FabricScoping == matter.FabricScopingScoped && !hasFabricIndex(s.Fields) {
+		sb.WriteString("    fabric_idx fabricIndex = 254;\n")
+	}
+	sb.WriteString("  }\n")
+}
+
+func renderEvent(sb *strings.Builder, e *matter.Event) {
+	writeDescription(sb, "  ", e.Description)
+	sb.WriteString("  ")
+	if e.Access.IsFabricSensitive() {
+		sb.WriteString("fabric_sensitive ")
+	}
+	priority := strings.ToLower(e.Priority)
+	if priority == "" {
+		priority = "info"
+	}
+	fmt.Fprintf(sb, "%s event ", priority)
+	if e.Access.Read != matter.PrivilegeUnknown && e.Access.Read != matter.PrivilegeView {
+		fmt.Fprintf(sb, "access(read: %s) ", privilegeName(e.Access.Read))
+	}
+	fmt.Fprintf(sb, "%s = %s {\n", idlTypeName(e.Name), e.ID.IntString())
+	renderFields(sb, e.Fields)
+	sb.WriteString("  }\n")
+}
+
+// renderCommandFields writes the request or response struct for a command, if it has one
+func renderCommandFields(sb *strings.Builder, c *matter.Command) {
+	switch c.Direction {
+	case matter.InterfaceServer:
+		if len(c.Fields) == 0 {
+			return
+		}
+		fmt.Fprintf(sb, "\n  request struct %s {\n", requestName(c))
+	case matter.InterfaceClient:
+		fmt.Fprintf(sb, "\n  response struct %s = %s {\n", idlTypeName(c.Name), c.ID.IntString())
+	default:
+		return
+	}
+	renderFields(sb, c.Fields)
+	sb.WriteString("  }\n")
+}
+
+func renderCommand(sb *strings.Builder, c *matter.Command) {
+	writeDescription(sb, "  ", c.Description)
+	sb.WriteString("  ")
+	if c.Access.IsTimed() {
+		sb.WriteString("timed ")
+	}
+	if c.Access.IsFabricScoped() {
+		sb.WriteString("fabric ")
+	}
+	sb.WriteString("command ")
+	if c.Access.Invoke != matter.PrivilegeUnknown && c.Access.Invoke != matter.PrivilegeOperate {
+		fmt.Fprintf(sb, "access(invoke: %s) ", privilegeName(c.Access.Invoke))
+	}
+	var request string
+	if len(c.Fields) > 0 {
+		request = requestName(c)
+	}
+	response := "DefaultSuccess"
+	if c.Response != nil && c.Response.Name != "Y" && c.Response.Name != "N" {
+		response = idlTypeName(c.Response.Name)
+	}
+	fmt.Fprintf(sb, "%s(%s): %s = %s;\n", idlTypeName(c.Name), request, response, c.ID.IntString())
+}
+
+func renderFields(sb *strings.Builder, fs matter.FieldSet) {
+	for _, f := range fs {
+		if excluded(fs, f.Conformance) {
+			continue
+		}
+		fmt.Fprintf(sb, "    %s;\n", fieldDeclaration(fs, f))
+	}
+}
+
+func fieldDeclaration(fs matter.FieldSet, f *matter.Field) string {
+	var sb strings.Builder
+	if !conformance.IsMandatory(f.Conformance) {
+		sb.WriteString("optional ")
+	}
+	if f.Quality.Has(matter.QualityNullable) {
+		sb.WriteString("nullable ")
+	}
+	if f.Access.IsFabricSensitive() {
+		sb.WriteString("fabric_sensitive ")
+	}
+	sb.WriteString(fieldType(fs, f))
+	sb.WriteRune(' ')
+	sb.WriteString(strcase.ToLowerCamel(zap.CleanName(f.Name)))
+	if f.Type != nil && f.Type.IsArray() {
+		sb.WriteString("[]")
+	}
+	sb.WriteString(" = ")
+	sb.WriteString(f.ID.IntString())
+	return sb.String()
+}
+
+func fieldType(fs matter.FieldSet, f *matter.Field) string {
+	name := zap.FieldToZapDataType(fs, f)
+	switch name {
+	case "char_string", "octet_string", "long_char_string", "long_octet_string":
+		if f.Constraint == nil {
+			break
+		}
+		max := f.Constraint.Max(&matter.ConstraintContext{Field: f, Fields: fs})
+		switch max.Type {
+		case types.DataTypeExtremeTypeInt64:
+			if max.Int64 > 0 {
+				name = fmt.Sprintf("%s<%d>", name, max.Int64)
+			}
+		case types.DataTypeExtremeTypeUInt64:
+			if max.UInt64 > 0 {
+				name = fmt.Sprintf("%s<%d>", name, max.UInt64)
+			}
+		}
+	}
+	return name
+}
+
+func baseTypeName(dt *types.DataType, defaultName string) string {
+	if dt == nil {
+		return defaultName
+	}
+	return zap.DataTypeName(dt)
+}
+
+func requestName(c *matter.Command) string {
+	return idlTypeName(c.Name) + "Request"
+}
+
+func hasFabricIndex(fs matter.FieldSet) bool {
+	for _, f := range fs {
+		if f.ID.Valid() && f.ID.Value() == 0xFE {
+			return true
+		}
+	}
+	return false
+}
+
+// excluded returns true for elements the SDK never generates: Zigbee-only, disallowed or deprecated ones
+func excluded(store conformance.IdentifierStore, c conformance.Set) bool {
+	return conformance.IsZigbee(store, c) || conformance.IsDisallowed(c) || conformance.IsDeprecated(c)
+}
+
+func privilegeName(p matter.Privilege) string {
+	return strings.ToLower(p.String())
+}
+
+func idlTypeName(name string) string {
+	return strcase.ToCamel(zap.CleanName(name))
+}
+
+func writeDescription(sb *strings.Builder, indent string, description string) {
+	description = strings.Join(strings.Fields(description), " ")
+	if description == "" {
+		return
+	}
+	fmt.Fprintf(sb, "%s/** %s */\n", indent, strings.ReplaceAll(description, "*/", "* /"))
+}
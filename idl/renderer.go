@@ -0,0 +1,97 @@
+package idl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+// Renderer writes the clusters in each doc as Matter IDL, the .matter format the SDK's code generators read
+type Renderer struct {
+	idlRoot string
+}
+
+func NewRenderer(idlRoot string) *Renderer {
+	return &Renderer{idlRoot: idlRoot}
+}
+
+func (p *Renderer) Name() string {
+	return "Rendering IDL"
+}
+
+func (p *Renderer) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeIndividual
+}
+
+func (p *Renderer) Process(cxt context.Context, input *pipeline.Data[*spec.Doc], index int32, total int32) (outputs []*pipeline.Data[string], extra []*pipeline.Data[*spec.Doc], err error) {
+	doc := input.Content
+	entities, err := doc.Entities()
+	if err != nil {
+		slog.ErrorContext(cxt, "error converting doc to entities", "doc", doc.Path, "error", err)
+		err = nil
+		return
+	}
+	var clusters []*matter.Cluster
+	for _, e := range entities {
+		switch e := e.(type) {
+		case *matter.ClusterGroup:
+			clusters = append(clusters, e.Clusters...)
+		case *matter.Cluster:
+			clusters = append(clusters, e)
+		}
+	}
+	if len(clusters) == 0 {
+		return
+	}
+	var idl string
+	idl, err = renderClusters(cxt, doc.Spec(), clusters)
+	if err != nil {
+		err = fmt.Errorf("failed rendering IDL for %s: %w", doc.Path, err)
+		return
+	}
+	if idl == "" {
+		return
+	}
+	outputs = append(outputs, pipeline.NewData(getIDLPath(p.idlRoot, doc.Path), idl))
+	return
+}
+
+func renderClusters(cxt context.Context, s *spec.Specification, clusters []*matter.Cluster) (string, error) {
+	var sb strings.Builder
+	for _, c := range clusters {
+		ic := newCluster(c)
+		if s != nil {
+			if base, ok := s.ClusterExtensions[c]; ok {
+				// IDL has no notion of extensions, so an extension is written out as the cluster it extends, with the
+				// extension's elements added
+				ic = newCluster(base)
+				ic.extend(c)
+			}
+		}
+		if !ic.id.Valid() {
+			// Base clusters have no ID of their own, and IDL has no way to declare a cluster without one
+			slog.WarnContext(cxt, "Skipping IDL for cluster with no valid ID", slog.String("cluster", ic.name))
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteRune('\n')
+		}
+		err := ic.render(&sb)
+		if err != nil {
+			return "", err
+		}
+	}
+	return sb.String(), nil
+}
+
+func getIDLPath(idlRoot string, path asciidoc.Path) string {
+	file := strings.TrimSuffix(path.Base(), path.Ext())
+	return filepath.Join(idlRoot, file+".matter")
+}
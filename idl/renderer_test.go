@@ -0,0 +1,57 @@
+package idl
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestRenderClusters(t *testing.T) {
+	widget := matter.NewCluster(nil)
+	widget.Name = "Widget Control"
+	widget.ID = matter.NewNumber(0xFFF1)
+	speed := matter.NewAttribute(nil)
+	speed.ID = matter.NewNumber(0)
+	speed.Name = "Speed"
+	speed.Type = types.NewDataType(types.BaseDataTypeUInt8, false)
+	speed.Access = matter.Access{Read: matter.PrivilegeView, Write: matter.PrivilegeOperate}
+	speed.Conformance = conformance.Set{&conformance.Mandatory{}}
+	widget.Attributes = matter.FieldSet{speed}
+
+	// A base cluster, which has no ID of its own
+	base := matter.NewCluster(nil)
+	base.Name = "Widget Base"
+
+	idl, err := renderClusters(context.Background(), nil, []*matter.Cluster{base, widget})
+	if err != nil {
+		t.Fatalf("failed rendering IDL: %v", err)
+	}
+	expected := []string{
+		"cluster WidgetControl = 65521 {\n",
+		"  attribute int8u speed = 0;\n",
+		"  readonly attribute bitmap32 featureMap = 65532;\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(idl, e) {
+			t.Errorf("expected IDL to contain %q, got:\n%s", e, idl)
+		}
+	}
+	if strings.Contains(idl, "WidgetBase") {
+		t.Errorf("expected cluster with no ID to be skipped, got:\n%s", idl)
+	}
+	if !strings.HasPrefix(idl, "cluster ") {
+		t.Errorf("expected IDL to start with the cluster, got:\n%s", idl)
+	}
+
+	idl, err = renderClusters(context.Background(), nil, []*matter.Cluster{base})
+	if err != nil {
+		t.Fatalf("failed rendering IDL: %v", err)
+	}
+	if idl != "" {
+		t.Errorf("expected no IDL for a cluster with no ID, got:\n%s", idl)
+	}
+}
@@ -0,0 +1,102 @@
+package files
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
+	"github.com/hexops/gotextdiff/span"
+	"github.com/project-chip/alchemy/internal/pipeline"
+)
+
+// Checker compares rendered output against the files on disk without writing anything, listing the files that would change
+type Checker[T string | []byte] struct {
+	writer
+
+	out      io.Writer
+	semantic bool
+}
+
+func NewChecker[T string | []byte](name string, out io.Writer) Writer[T] {
+	return &Checker[T]{writer: writer{name: name}, out: out}
+}
+
+// NewSemanticChecker lists the element-level differences in changed XML and JSON files, rather than counting changed lines
+func NewSemanticChecker[T string | []byte](name string, out io.Writer) Writer[T] {
+	return &Checker[T]{writer: writer{name: name}, out: out, semantic: true}
+}
+
+func (sp *Checker[T]) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeCollective
+}
+
+func (sp *Checker[T]) Process(cxt context.Context, inputs []*pipeline.Data[T]) (outputs []*pipeline.Data[struct{}], err error) {
+	pipeline.SortData[T](inputs)
+	var changed int
+	for _, i := range inputs {
+		var existing string
+		existing, err = readExisting(i.Path)
+		if err != nil {
+			return
+		}
+		edits := myers.ComputeEdits(span.URIFromPath(i.Path), existing, string(i.Content))
+		if len(edits) == 0 {
+			continue
+		}
+		changed++
+		if sp.semantic && sp.writeSemanticDiff(i.Path, existing, string(i.Content)) {
+			continue
+		}
+		var inserted, deleted int
+		for _, h := range gotextdiff.ToUnified(i.Path, i.Path, existing, edits).Hunks {
+			for _, l := range h.Lines {
+				switch l.Kind {
+				case gotextdiff.Insert:
+					inserted++
+				case gotextdiff.Delete:
+					deleted++
+				}
+			}
+		}
+		fmt.Fprintf(sp.out, "%s: +%d -%d\n", i.Path, inserted, deleted)
+	}
+	if changed > 0 {
+		err = fmt.Errorf("%d of %d files would be changed", changed, len(inputs))
+	}
+	return
+}
+
+func (sp *Checker[T]) writeSemanticDiff(path string, existing string, rendered string) bool {
+	if existing == "" {
+		fmt.Fprintf(sp.out, "%s: new file\n", path)
+		return true
+	}
+	diffs, ok := SemanticDiff(path, existing, rendered)
+	if !ok {
+		return false
+	}
+	if len(diffs) == 0 {
+		fmt.Fprintf(sp.out, "%s: formatting only\n", path)
+		return true
+	}
+	fmt.Fprintf(sp.out, "%s:\n", path)
+	for _, d := range diffs {
+		fmt.Fprintf(sp.out, "\t%s\n", d)
+	}
+	return true
+}
+
+func readExisting(path string) (string, error) {
+	exists, err := Exists(path)
+	if err != nil || !exists {
+		return "", err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,54 @@
+package files
+
+import (
+	"strings"
+
+	"github.com/hexops/gotextdiff"
+)
+
+// Hunk is a replacement of a run of lines in an existing file
+type Hunk struct {
+	Path string
+	// Start and End are the first and last lines replaced, counting from 1
+	Start int
+	End   int
+
+	Original    string
+	Replacement string
+}
+
+// toHunks turns the line edits to a file into hunks; insertions are widened to include a neighboring line, so each hunk replaces at least one line
+func toHunks(path string, existing string, edits []gotextdiff.TextEdit) (hunks []Hunk) {
+	lines := strings.SplitAfter(existing, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for _, group := range groupEdits(edits) {
+		start, end := group[0].Span.Start().Line(), group[len(group)-1].Span.End().Line()
+		var replacement strings.Builder
+		for _, e := range group {
+			replacement.WriteString(e.NewText)
+		}
+		var before, after string
+		if start == end {
+			if start > 1 {
+				start--
+				before = lines[start-1]
+			} else if end <= len(lines) {
+				after = lines[end-1]
+				end++
+			} else {
+				// Inserting into an empty file
+				continue
+			}
+		}
+		hunks = append(hunks, Hunk{
+			Path:        path,
+			Start:       start,
+			End:         end - 1,
+			Original:    strings.Join(lines[start-1:end-1], ""),
+			Replacement: before + replacement.String() + after,
+		})
+	}
+	return
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/hexops/gotextdiff"
 	"github.com/hexops/gotextdiff/myers"
@@ -16,36 +15,55 @@ type Patcher[T string | []byte] struct {
 	writer
 
 	out io.Writer
+
+	scope      LineScope
+	outOfScope io.Writer
+
+	hunks []Hunk
 }
 
-func NewPatcher[T string | []byte](name string, out io.Writer) Writer[T] {
+func NewPatcher[T string | []byte](name string, out io.Writer) *Patcher[T] {
 	return &Patcher[T]{writer: writer{name: name}, out: out}
 }
 
+// NewScopedPatcher only writes the hunks that overlap the changed lines in scope to out; the rest are written to outOfScope, if it's not nil
+func NewScopedPatcher[T string | []byte](name string, out io.Writer, scope LineScope, outOfScope io.Writer) *Patcher[T] {
+	return &Patcher[T]{writer: writer{name: name}, out: out, scope: scope, outOfScope: outOfScope}
+}
+
 func (sp *Patcher[T]) Type() pipeline.ProcessorType {
 	return pipeline.ProcessorTypeCollective
 }
 
 func (sp *Patcher[T]) Process(cxt context.Context, inputs []*pipeline.Data[T]) (outputs []*pipeline.Data[struct{}], err error) {
 	for _, i := range inputs {
-		var exists bool
-		exists, err = Exists(i.Path)
+		var existing string
+		existing, err = readExisting(i.Path)
 		if err != nil {
 			return
 		}
-		var existing string
-		if exists {
-			var eb []byte
-			eb, err = os.ReadFile(i.Path)
-			if err != nil {
-				return
-			}
-			existing = string(eb)
-		}
 		edits := myers.ComputeEdits(span.URIFromPath(i.Path), existing, string(i.Content))
-		if len(edits) > 0 {
+		if len(edits) == 0 {
+			continue
+		}
+		if sp.scope == nil {
 			fmt.Fprintln(sp.out, gotextdiff.ToUnified(i.Path, i.Path, existing, edits))
+			sp.hunks = append(sp.hunks, toHunks(i.Path, existing, edits)...)
+			continue
+		}
+		in, out := sp.scope.splitEdits(i.Path, edits)
+		if len(in) > 0 {
+			sp.hunks = append(sp.hunks, toHunks(i.Path, existing, in)...)
+			fmt.Fprintln(sp.out, gotextdiff.ToUnified(i.Path, i.Path, existing, in))
+		}
+		if len(out) > 0 && sp.outOfScope != nil {
+			fmt.Fprintln(sp.outOfScope, gotextdiff.ToUnified(i.Path, i.Path, existing, out))
 		}
 	}
 	return
 }
+
+// Hunks returns the changes written to the patch so far
+func (sp *Patcher[T]) Hunks() []Hunk {
+	return sp.hunks
+}
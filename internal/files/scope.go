@@ -0,0 +1,128 @@
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hexops/gotextdiff"
+)
+
+// LineRange is an inclusive range of 1-based line numbers
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// LineScope is the set of changed lines in each file, keyed by absolute path
+type LineScope map[string][]LineRange
+
+func (ls LineScope) overlaps(path string, start int, end int) bool {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	for _, r := range ls[path] {
+		if start <= r.End && end >= r.Start {
+			return true
+		}
+	}
+	return false
+}
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// ChangedLines finds the lines in the working tree of the git repository containing dir that have changed since it diverged from the base ref
+func ChangedLines(cxt context.Context, dir string, base string) (LineScope, error) {
+	root, err := git(cxt, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root = strings.TrimSpace(root)
+	mergeBase, err := git(cxt, dir, "merge-base", base, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	diff, err := git(cxt, dir, "diff", "--unified=0", "--no-color", "--no-ext-diff", strings.TrimSpace(mergeBase))
+	if err != nil {
+		return nil, err
+	}
+	scope := make(LineScope)
+	var path string
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if name, ok := strings.CutPrefix(line, "+++ "); ok {
+			path = ""
+			if name, ok = strings.CutPrefix(name, "b/"); ok {
+				path = filepath.Join(root, filepath.FromSlash(name))
+			}
+			continue
+		}
+		match := hunkHeaderPattern.FindStringSubmatch(line)
+		if match == nil || path == "" {
+			continue
+		}
+		start, _ := strconv.Atoi(match[1])
+		count := 1
+		if match[2] != "" {
+			count, _ = strconv.Atoi(match[2])
+		}
+		if count == 0 {
+			// Lines were only removed, after the start line; the lines on either side count as changed
+			scope[path] = append(scope[path], LineRange{Start: start, End: start + 1})
+			continue
+		}
+		scope[path] = append(scope[path], LineRange{Start: start, End: start + count - 1})
+	}
+	return scope, scanner.Err()
+}
+
+func git(cxt context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(cxt, "git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// splitEdits divides the edits to a file into those that touch its changed lines, and those that don't; adjacent
+// edits, like the deletion and insertion that make up a replacement, are kept together
+func (ls LineScope) splitEdits(path string, edits []gotextdiff.TextEdit) (in []gotextdiff.TextEdit, out []gotextdiff.TextEdit) {
+	for _, group := range groupEdits(edits) {
+		start, last := group[0].Span.Start().Line(), group[len(group)-1].Span.End().Line()-1
+		if last < start {
+			// Only insertions, between two lines
+			start, last = start-1, start
+		}
+		if ls.overlaps(path, start, last) {
+			in = append(in, group...)
+		} else {
+			out = append(out, group...)
+		}
+	}
+	return
+}
+
+// groupEdits splits line edits into runs of adjacent edits
+func groupEdits(edits []gotextdiff.TextEdit) (groups [][]gotextdiff.TextEdit) {
+	for len(edits) > 0 {
+		end := 1
+		for end < len(edits) && edits[end].Span.Start().Line() <= edits[end-1].Span.End().Line() {
+			end++
+		}
+		groups = append(groups, edits[:end])
+		edits = edits[end:]
+	}
+	return
+}
@@ -0,0 +1,243 @@
+package files
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+// SemanticDiff lists the differences between two XML or JSON documents element by element, ignoring formatting,
+// comments and the order of attributes, elements and object keys; ok is false if the path is neither XML nor JSON,
+// or either document can't be parsed
+func SemanticDiff(path string, existing string, rendered string) (diffs []string, ok bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xml":
+		a, b := etree.NewDocument(), etree.NewDocument()
+		if a.ReadFromString(existing) != nil || b.ReadFromString(rendered) != nil || a.Root() == nil || b.Root() == nil {
+			return nil, false
+		}
+		if a.Root().Tag != b.Root().Tag {
+			return []string{fmt.Sprintf("~ /: root element %s -> %s", a.Root().Tag, b.Root().Tag)}, true
+		}
+		diffXMLElements("/"+a.Root().Tag, a.Root(), b.Root(), &diffs)
+		return diffs, true
+	case ".json":
+		var a, b any
+		if json.Unmarshal([]byte(existing), &a) != nil || json.Unmarshal([]byte(rendered), &b) != nil {
+			return nil, false
+		}
+		diffJSON("", a, b, &diffs)
+		return diffs, true
+	}
+	return nil, false
+}
+
+// xmlKeyAttributes are the attributes that identify an element among its siblings, in order of preference
+var xmlKeyAttributes = []string{"code", "id", "fieldId", "name", "bit", "value", "ref", "cluster", "op", "type"}
+
+// xmlKeyElements are the child elements that identify an element among its siblings, when it has no key attributes
+var xmlKeyElements = []string{"name", "code", "typeName", "deviceId"}
+
+func diffXMLElements(path string, a *etree.Element, b *etree.Element, diffs *[]string) {
+	attrs := make(map[string]string, len(a.Attr))
+	for _, attr := range a.Attr {
+		attrs[attr.FullKey()] = attr.Value
+	}
+	for _, attr := range b.Attr {
+		key := attr.FullKey()
+		existing, ok := attrs[key]
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("+ %s: %s=%q", path, key, attr.Value))
+		} else if existing != attr.Value {
+			*diffs = append(*diffs, fmt.Sprintf("~ %s: %s %q -> %q", path, key, existing, attr.Value))
+		}
+		delete(attrs, key)
+	}
+	removed := make([]string, 0, len(attrs))
+	for key := range attrs {
+		removed = append(removed, key)
+	}
+	slices.Sort(removed)
+	for _, key := range removed {
+		*diffs = append(*diffs, fmt.Sprintf("- %s: %s=%q", path, key, attrs[key]))
+	}
+
+	if at, bt := normalizeText(a.Text()), normalizeText(b.Text()); at != bt {
+		*diffs = append(*diffs, fmt.Sprintf("~ %s: text %q -> %q", path, at, bt))
+	}
+
+	aKeys, aChildren := keyXMLChildren(a)
+	bKeys, bChildren := keyXMLChildren(b)
+	for _, key := range aKeys {
+		if _, ok := bChildren[key]; !ok {
+			*diffs = append(*diffs, fmt.Sprintf("- %s/%s", path, key))
+		}
+	}
+	for _, key := range bKeys {
+		ac, ok := aChildren[key]
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("+ %s/%s", path, key))
+			continue
+		}
+		diffXMLElements(path+"/"+key, ac, bChildren[key], diffs)
+	}
+}
+
+// keyXMLChildren returns the child elements of el by a key that identifies them regardless of their order
+func keyXMLChildren(el *etree.Element) (keys []string, children map[string]*etree.Element) {
+	children = make(map[string]*etree.Element)
+	counts := make(map[string]int)
+	for _, c := range el.ChildElements() {
+		key := xmlElementKey(c)
+		counts[key]++
+		if n := counts[key]; n > 1 {
+			key = fmt.Sprintf("%s#%d", key, n)
+		}
+		keys = append(keys, key)
+		children[key] = c
+	}
+	return
+}
+
+func xmlElementKey(el *etree.Element) string {
+	for _, name := range xmlKeyAttributes {
+		if attr := el.SelectAttr(name); attr != nil {
+			return fmt.Sprintf("%s[%s=%s]", el.Tag, name, attr.Value)
+		}
+	}
+	for _, name := range xmlKeyElements {
+		if c := el.SelectElement(name); c != nil {
+			return fmt.Sprintf("%s[%s=%s]", el.Tag, name, normalizeText(c.Text()))
+		}
+	}
+	if len(el.ChildElements()) == 0 && len(el.Attr) == 0 {
+		if text := normalizeText(el.Text()); text != "" {
+			// Simple elements like requireAttribute are identified by their value
+			return fmt.Sprintf("%s[%s]", el.Tag, text)
+		}
+	}
+	return el.Tag
+}
+
+func normalizeText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func diffJSON(path string, a any, b any, diffs *[]string) {
+	switch a := a.(type) {
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(a)+len(b))
+		for key := range a {
+			keys = append(keys, key)
+		}
+		for key := range b {
+			if _, ok := a[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			av, inA := a[key]
+			bv, inB := b[key]
+			switch {
+			case !inB:
+				*diffs = append(*diffs, fmt.Sprintf("- %s/%s", path, key))
+			case !inA:
+				*diffs = append(*diffs, fmt.Sprintf("+ %s/%s", path, key))
+			default:
+				diffJSON(path+"/"+key, av, bv, diffs)
+			}
+		}
+		return
+	case []any:
+		b, ok := b.([]any)
+		if !ok {
+			break
+		}
+		if scalarValues(a) && scalarValues(b) {
+			diffJSONValues(path, a, b, diffs)
+			return
+		}
+		for i := 0; i < max(len(a), len(b)); i++ {
+			switch {
+			case i >= len(b):
+				*diffs = append(*diffs, fmt.Sprintf("- %s/%d", path, i))
+			case i >= len(a):
+				*diffs = append(*diffs, fmt.Sprintf("+ %s/%d", path, i))
+			default:
+				diffJSON(path+"/"+strconv.Itoa(i), a[i], b[i], diffs)
+			}
+		}
+		return
+	default:
+		if a == b {
+			return
+		}
+	}
+	*diffs = append(*diffs, fmt.Sprintf("~ %s: %s -> %s", jsonPath(path), jsonString(a), jsonString(b)))
+}
+
+// diffJSONValues compares lists of plain values, like the lists of XML files in zcl.json, as sets
+func diffJSONValues(path string, a []any, b []any, diffs *[]string) {
+	count := make(map[string]int)
+	for _, v := range a {
+		count[jsonString(v)]++
+	}
+	for _, v := range b {
+		count[jsonString(v)]--
+	}
+	var changed bool
+	for _, v := range a {
+		s := jsonString(v)
+		if count[s] > 0 {
+			*diffs = append(*diffs, fmt.Sprintf("- %s: %s", jsonPath(path), s))
+			count[s]--
+			changed = true
+		}
+	}
+	for _, v := range b {
+		s := jsonString(v)
+		if count[s] < 0 {
+			*diffs = append(*diffs, fmt.Sprintf("+ %s: %s", jsonPath(path), s))
+			count[s]++
+			changed = true
+		}
+	}
+	if !changed && !slices.EqualFunc(a, b, func(av any, bv any) bool { return av == bv }) {
+		*diffs = append(*diffs, fmt.Sprintf("~ %s: order changed", jsonPath(path)))
+	}
+}
+
+func scalarValues(values []any) bool {
+	for _, v := range values {
+		switch v.(type) {
+		case map[string]any, []any:
+			return false
+		}
+	}
+	return true
+}
+
+func jsonPath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func jsonString(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,63 @@
+package files
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+const semanticXML = `<?xml version="1.0"?>
+<configurator>
+  <cluster>
+    <name>Widget</name>
+    <code>0xFFF1</code>
+    <attribute side="server" code="0x0000" define="MODE" type="int8u">Mode</attribute>
+    <attribute side="server" code="0x0001" define="SPEED" type="int8u">Speed</attribute>
+  </cluster>
+</configurator>
+`
+
+func TestSemanticDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		existing string
+		rendered string
+		ok       bool
+		diffs    []string
+	}{
+		{"identical", "widget.xml", semanticXML, semanticXML, true, nil},
+		{"reordered attributes", "widget.xml", semanticXML,
+			strings.Replace(semanticXML, `side="server" code="0x0000" define="MODE" type="int8u"`, `type="int8u" define="MODE" code="0x0000" side="server"`, 1), true, nil},
+		{"reordered elements", "widget.xml", semanticXML,
+			strings.Replace(semanticXML, "    <name>Widget</name>\n    <code>0xFFF1</code>\n", "    <code>0xFFF1</code>\n    <name>Widget</name>\n", 1), true, nil},
+		{"whitespace only", "widget.xml", semanticXML,
+			strings.NewReplacer("  ", "\t", "<name>Widget</name>", "<name>\n      Widget\n    </name>").Replace(semanticXML) + "<!-- trailing comment -->\n", true, nil},
+		{"changed value", "widget.xml", semanticXML,
+			strings.Replace(semanticXML, `define="SPEED" type="int8u"`, `define="SPEED" type="int16u"`, 1), true,
+			[]string{`~ /configurator/cluster[name=Widget]/attribute[code=0x0001]: type "int8u" -> "int16u"`}},
+		{"changed text", "widget.xml", semanticXML,
+			strings.Replace(semanticXML, ">Speed<", ">Velocity<", 1), true,
+			[]string{`~ /configurator/cluster[name=Widget]/attribute[code=0x0001]: text "Speed" -> "Velocity"`}},
+		{"added and removed", "widget.xml", semanticXML,
+			strings.Replace(semanticXML, `code="0x0001" define="SPEED"`, `code="0x0002" define="SPEED"`, 1), true,
+			[]string{"- /configurator/cluster[name=Widget]/attribute[code=0x0001]", "+ /configurator/cluster[name=Widget]/attribute[code=0x0002]"}},
+		{"JSON key order and whitespace", "zcl.json", `{"a": 1, "b": ["x.xml", "y.xml"]}`, "{\n  \"b\": [\"x.xml\", \"y.xml\"],\n  \"a\": 1\n}", true, nil},
+		{"JSON value change", "zcl.json", `{"a": 1, "b": ["x.xml", "y.xml"]}`, `{"a": 2, "b": ["x.xml", "z.xml"]}`, true,
+			[]string{"~ /a: 1 -> 2", `- /b: "y.xml"`, `+ /b: "z.xml"`}},
+		{"JSON list order", "zcl.json", `{"b": ["x.xml", "y.xml"]}`, `{"b": ["y.xml", "x.xml"]}`, true, []string{"~ /b: order changed"}},
+		{"unparseable", "widget.xml", semanticXML, "<configurator>", false, nil},
+		{"unsupported", "widget.adoc", "a", "b", false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs, ok := SemanticDiff(tt.path, tt.existing, tt.rendered)
+			if ok != tt.ok {
+				t.Fatalf("expected ok to be %v, got %v", tt.ok, ok)
+			}
+			if !slices.Equal(diffs, tt.diffs) {
+				t.Errorf("unexpected diffs:\n%s\nexpected:\n%s", strings.Join(diffs, "\n"), strings.Join(tt.diffs, "\n"))
+			}
+		})
+	}
+}
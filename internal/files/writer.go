@@ -26,6 +26,9 @@ func (w *writer) SetName(name string) {
 }
 
 func NewWriter[T string | []byte](name string, options Options) Writer[T] {
+	if options.Check {
+		return NewChecker[T](name, os.Stdout)
+	}
 	if options.DryRun {
 		return &DryRun[T]{writer: writer{name: name}}
 	}
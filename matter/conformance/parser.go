@@ -889,19 +889,19 @@ var g = &grammar{
 						run: (*parser).callonOperator2,
 						expr: &litMatcher{
 							pos:        position{line: 199, col: 7, offset: 4033},
-							val:        "<",
+							val:        "<=",
 							ignoreCase: false,
-							want:       "\"<\"",
+							want:       "\"<=\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 200, col: 8, offset: 4089},
+						pos: position{line: 200, col: 8, offset: 4096},
 						run: (*parser).callonOperator4,
 						expr: &litMatcher{
-							pos:        position{line: 200, col: 8, offset: 4089},
-							val:        "<=",
+							pos:        position{line: 200, col: 8, offset: 4096},
+							val:        "<",
 							ignoreCase: false,
-							want:       "\"<=\"",
+							want:       "\"<\"",
 						},
 					},
 					&actionExpr{
@@ -909,19 +909,19 @@ var g = &grammar{
 						run: (*parser).callonOperator6,
 						expr: &litMatcher{
 							pos:        position{line: 201, col: 8, offset: 4152},
-							val:        ">",
+							val:        ">=",
 							ignoreCase: false,
-							want:       "\">\"",
+							want:       "\">=\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 202, col: 8, offset: 4211},
+						pos: position{line: 202, col: 8, offset: 4218},
 						run: (*parser).callonOperator8,
 						expr: &litMatcher{
-							pos:        position{line: 202, col: 8, offset: 4211},
-							val:        ">=",
+							pos:        position{line: 202, col: 8, offset: 4218},
+							val:        ">",
 							ignoreCase: false,
-							want:       "\">=\"",
+							want:       "\">\"",
 						},
 					},
 				},
@@ -1502,26 +1502,26 @@ var g = &grammar{
 		},
 		{
 			name: "ChoiceMin",
-			pos:  position{line: 327, col: 1, offset: 7221},
+			pos:  position{line: 327, col: 1, offset: 7235},
 			expr: &actionExpr{
-				pos: position{line: 327, col: 14, offset: 7234},
+				pos: position{line: 327, col: 14, offset: 7248},
 				run: (*parser).callonChoiceMin1,
 				expr: &seqExpr{
-					pos: position{line: 327, col: 14, offset: 7234},
+					pos: position{line: 327, col: 14, offset: 7248},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 327, col: 14, offset: 7234},
+							pos:   position{line: 327, col: 14, offset: 7248},
 							label: "limit",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 327, col: 20, offset: 7240},
+								pos: position{line: 327, col: 20, offset: 7254},
 								expr: &ruleRefExpr{
-									pos:    position{line: 327, col: 20, offset: 7240},
+									pos:    position{line: 327, col: 20, offset: 7254},
 									offset: 15,
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 327, col: 29, offset: 7249},
+							pos:        position{line: 327, col: 29, offset: 7263},
 							val:        "+",
 							ignoreCase: false,
 							want:       "\"+\"",
@@ -1532,26 +1532,26 @@ var g = &grammar{
 		},
 		{
 			name: "ChoiceMax",
-			pos:  position{line: 338, col: 1, offset: 7492},
+			pos:  position{line: 338, col: 1, offset: 7513},
 			expr: &actionExpr{
-				pos: position{line: 338, col: 14, offset: 7505},
+				pos: position{line: 338, col: 14, offset: 7526},
 				run: (*parser).callonChoiceMax1,
 				expr: &seqExpr{
-					pos: position{line: 338, col: 14, offset: 7505},
+					pos: position{line: 338, col: 14, offset: 7526},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 338, col: 14, offset: 7505},
+							pos:   position{line: 338, col: 14, offset: 7526},
 							label: "limit",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 338, col: 20, offset: 7511},
+								pos: position{line: 338, col: 20, offset: 7532},
 								expr: &ruleRefExpr{
-									pos:    position{line: 338, col: 20, offset: 7511},
+									pos:    position{line: 338, col: 20, offset: 7532},
 									offset: 15,
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 338, col: 29, offset: 7520},
+							pos:        position{line: 338, col: 29, offset: 7541},
 							val:        "-",
 							ignoreCase: false,
 							want:       "\"-\"",
@@ -1562,15 +1562,15 @@ var g = &grammar{
 		},
 		{
 			name: "ChoiceExact",
-			pos:  position{line: 349, col: 1, offset: 7761},
+			pos:  position{line: 349, col: 1, offset: 7789},
 			expr: &actionExpr{
-				pos: position{line: 349, col: 16, offset: 7776},
+				pos: position{line: 349, col: 16, offset: 7804},
 				run: (*parser).callonChoiceExact1,
 				expr: &labeledExpr{
-					pos:   position{line: 349, col: 16, offset: 7776},
+					pos:   position{line: 349, col: 16, offset: 7804},
 					label: "limit",
 					expr: &ruleRefExpr{
-						pos:    position{line: 349, col: 22, offset: 7782},
+						pos:    position{line: 349, col: 22, offset: 7810},
 						offset: 15,
 					},
 				},
@@ -1578,30 +1578,30 @@ var g = &grammar{
 		},
 		{
 			name: "ChoiceLimit",
-			pos:  position{line: 357, col: 1, offset: 7953},
+			pos:  position{line: 357, col: 1, offset: 7988},
 			expr: &actionExpr{
-				pos: position{line: 357, col: 16, offset: 7968},
+				pos: position{line: 357, col: 16, offset: 8003},
 				run: (*parser).callonChoiceLimit1,
 				expr: &labeledExpr{
-					pos:   position{line: 357, col: 16, offset: 7968},
+					pos:   position{line: 357, col: 16, offset: 8003},
 					label: "limit",
 					expr: &choiceExpr{
-						pos: position{line: 357, col: 23, offset: 7975},
+						pos: position{line: 357, col: 23, offset: 8010},
 						alternatives: []any{
 							&ruleRefExpr{
-								pos:    position{line: 357, col: 23, offset: 7975},
+								pos:    position{line: 357, col: 23, offset: 8010},
 								offset: 33,
 							},
 							&ruleRefExpr{
-								pos:    position{line: 357, col: 37, offset: 7989},
+								pos:    position{line: 357, col: 37, offset: 8024},
 								offset: 34,
 							},
 							&ruleRefExpr{
-								pos:    position{line: 357, col: 49, offset: 8001},
+								pos:    position{line: 357, col: 49, offset: 8036},
 								offset: 35,
 							},
 							&ruleRefExpr{
-								pos:    position{line: 357, col: 61, offset: 8013},
+								pos:    position{line: 357, col: 61, offset: 8048},
 								offset: 36,
 							},
 						},
@@ -1611,14 +1611,14 @@ var g = &grammar{
 		},
 		{
 			name: "Set",
-			pos:  position{line: 361, col: 1, offset: 8067},
+			pos:  position{line: 361, col: 1, offset: 8102},
 			expr: &actionExpr{
-				pos: position{line: 361, col: 8, offset: 8074},
+				pos: position{line: 361, col: 8, offset: 8109},
 				run: (*parser).callonSet1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 361, col: 8, offset: 8074},
+					pos: position{line: 361, col: 8, offset: 8109},
 					expr: &charClassMatcher{
-						pos:        position{line: 361, col: 8, offset: 8074},
+						pos:        position{line: 361, col: 8, offset: 8109},
 						val:        "[a-z]",
 						ranges:     []rune{'a', 'z'},
 						ignoreCase: false,
@@ -1629,34 +1629,34 @@ var g = &grammar{
 		},
 		{
 			name: "Choice",
-			pos:  position{line: 365, col: 1, offset: 8117},
+			pos:  position{line: 365, col: 1, offset: 8152},
 			expr: &actionExpr{
-				pos: position{line: 365, col: 11, offset: 8127},
+				pos: position{line: 365, col: 11, offset: 8162},
 				run: (*parser).callonChoice1,
 				expr: &seqExpr{
-					pos: position{line: 365, col: 11, offset: 8127},
+					pos: position{line: 365, col: 11, offset: 8162},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 365, col: 11, offset: 8127},
+							pos:        position{line: 365, col: 11, offset: 8162},
 							val:        ".",
 							ignoreCase: false,
 							want:       "\".\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 365, col: 15, offset: 8131},
+							pos:   position{line: 365, col: 15, offset: 8166},
 							label: "set",
 							expr: &ruleRefExpr{
-								pos:    position{line: 365, col: 19, offset: 8135},
+								pos:    position{line: 365, col: 19, offset: 8170},
 								offset: 38,
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 365, col: 23, offset: 8139},
+							pos:   position{line: 365, col: 23, offset: 8174},
 							label: "limit",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 365, col: 29, offset: 8145},
+								pos: position{line: 365, col: 29, offset: 8180},
 								expr: &ruleRefExpr{
-									pos:    position{line: 365, col: 29, offset: 8145},
+									pos:    position{line: 365, col: 29, offset: 8180},
 									offset: 37,
 								},
 							},
@@ -1667,24 +1667,24 @@ var g = &grammar{
 		},
 		{
 			name: "PascalCase",
-			pos:  position{line: 381, col: 1, offset: 8460},
+			pos:  position{line: 381, col: 1, offset: 8495},
 			expr: &actionExpr{
-				pos: position{line: 381, col: 15, offset: 8474},
+				pos: position{line: 381, col: 15, offset: 8509},
 				run: (*parser).callonPascalCase1,
 				expr: &seqExpr{
-					pos: position{line: 381, col: 15, offset: 8474},
+					pos: position{line: 381, col: 15, offset: 8509},
 					exprs: []any{
 						&oneOrMoreExpr{
-							pos: position{line: 381, col: 15, offset: 8474},
+							pos: position{line: 381, col: 15, offset: 8509},
 							expr: &ruleRefExpr{
-								pos:    position{line: 381, col: 15, offset: 8474},
+								pos:    position{line: 381, col: 15, offset: 8509},
 								offset: 41,
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 381, col: 28, offset: 8487},
+							pos: position{line: 381, col: 28, offset: 8522},
 							expr: &ruleRefExpr{
-								pos:    position{line: 381, col: 28, offset: 8487},
+								pos:    position{line: 381, col: 28, offset: 8522},
 								offset: 41,
 							},
 						},
@@ -1694,24 +1694,24 @@ var g = &grammar{
 		},
 		{
 			name: "Capitalized",
-			pos:  position{line: 386, col: 1, offset: 8538},
+			pos:  position{line: 386, col: 1, offset: 8573},
 			expr: &actionExpr{
-				pos: position{line: 386, col: 16, offset: 8553},
+				pos: position{line: 386, col: 16, offset: 8588},
 				run: (*parser).callonCapitalized1,
 				expr: &seqExpr{
-					pos: position{line: 386, col: 16, offset: 8553},
+					pos: position{line: 386, col: 16, offset: 8588},
 					exprs: []any{
 						&oneOrMoreExpr{
-							pos: position{line: 386, col: 16, offset: 8553},
+							pos: position{line: 386, col: 16, offset: 8588},
 							expr: &ruleRefExpr{
-								pos:    position{line: 386, col: 16, offset: 8553},
+								pos:    position{line: 386, col: 16, offset: 8588},
 								offset: 42,
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 386, col: 27, offset: 8564},
+							pos: position{line: 386, col: 27, offset: 8599},
 							expr: &ruleRefExpr{
-								pos:    position{line: 386, col: 27, offset: 8564},
+								pos:    position{line: 386, col: 27, offset: 8599},
 								offset: 43,
 							},
 						},
@@ -1721,12 +1721,12 @@ var g = &grammar{
 		},
 		{
 			name: "Uppercase",
-			pos:  position{line: 390, col: 1, offset: 8612},
+			pos:  position{line: 390, col: 1, offset: 8647},
 			expr: &actionExpr{
-				pos: position{line: 390, col: 14, offset: 8625},
+				pos: position{line: 390, col: 14, offset: 8660},
 				run: (*parser).callonUppercase1,
 				expr: &charClassMatcher{
-					pos:        position{line: 390, col: 14, offset: 8625},
+					pos:        position{line: 390, col: 14, offset: 8660},
 					val:        "[A-Z]",
 					ranges:     []rune{'A', 'Z'},
 					ignoreCase: false,
@@ -1736,12 +1736,12 @@ var g = &grammar{
 		},
 		{
 			name: "Lowercase",
-			pos:  position{line: 394, col: 1, offset: 8668},
+			pos:  position{line: 394, col: 1, offset: 8703},
 			expr: &actionExpr{
-				pos: position{line: 394, col: 14, offset: 8681},
+				pos: position{line: 394, col: 14, offset: 8716},
 				run: (*parser).callonLowercase1,
 				expr: &charClassMatcher{
-					pos:        position{line: 394, col: 14, offset: 8681},
+					pos:        position{line: 394, col: 14, offset: 8716},
 					val:        "[a-z]",
 					ranges:     []rune{'a', 'z'},
 					ignoreCase: false,
@@ -1751,14 +1751,14 @@ var g = &grammar{
 		},
 		{
 			name: "SameLineString",
-			pos:  position{line: 398, col: 1, offset: 8724},
+			pos:  position{line: 398, col: 1, offset: 8759},
 			expr: &actionExpr{
-				pos: position{line: 398, col: 19, offset: 8742},
+				pos: position{line: 398, col: 19, offset: 8777},
 				run: (*parser).callonSameLineString1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 398, col: 19, offset: 8742},
+					pos: position{line: 398, col: 19, offset: 8777},
 					expr: &charClassMatcher{
-						pos:        position{line: 398, col: 19, offset: 8742},
+						pos:        position{line: 398, col: 19, offset: 8777},
 						val:        "[^\\n]",
 						chars:      []rune{'\n'},
 						ignoreCase: false,
@@ -1770,11 +1770,11 @@ var g = &grammar{
 		{
 			name:        "_",
 			displayName: "\"whitespace\"",
-			pos:         position{line: 402, col: 1, offset: 8789},
+			pos:         position{line: 402, col: 1, offset: 8824},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 402, col: 19, offset: 8807},
+				pos: position{line: 402, col: 19, offset: 8842},
 				expr: &charClassMatcher{
-					pos:        position{line: 402, col: 19, offset: 8807},
+					pos:        position{line: 402, col: 19, offset: 8842},
 					val:        "[ \\t\\r\\n]",
 					chars:      []rune{' ', '\t', '\r', '\n'},
 					ignoreCase: false,
@@ -1784,9 +1784,9 @@ var g = &grammar{
 		},
 		{
 			name: "Comma",
-			pos:  position{line: 404, col: 1, offset: 8819},
+			pos:  position{line: 404, col: 1, offset: 8854},
 			expr: &litMatcher{
-				pos:        position{line: 404, col: 10, offset: 8828},
+				pos:        position{line: 404, col: 10, offset: 8863},
 				val:        ",",
 				ignoreCase: false,
 				want:       "\",\"",
@@ -1794,11 +1794,11 @@ var g = &grammar{
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 406, col: 1, offset: 8834},
+			pos:  position{line: 406, col: 1, offset: 8869},
 			expr: &notExpr{
-				pos: position{line: 406, col: 8, offset: 8841},
+				pos: position{line: 406, col: 8, offset: 8876},
 				expr: &anyMatcher{
-					line: 406, col: 9, offset: 8842,
+					line: 406, col: 9, offset: 8877,
 				},
 			},
 		},
@@ -2142,7 +2142,7 @@ func (p *parser) callonDigits1() (any, error) {
 }
 
 func (c *current) onOperator2() (any, error) {
-	return ComparisonOperatorLessThan, nil
+	return ComparisonOperatorLessThanOrEqual, nil
 }
 
 func (p *parser) callonOperator2() (any, error) {
@@ -2152,7 +2152,7 @@ func (p *parser) callonOperator2() (any, error) {
 }
 
 func (c *current) onOperator4() (any, error) {
-	return ComparisonOperatorLessThanOrEqual, nil
+	return ComparisonOperatorLessThan, nil
 }
 
 func (p *parser) callonOperator4() (any, error) {
@@ -2162,7 +2162,7 @@ func (p *parser) callonOperator4() (any, error) {
 }
 
 func (c *current) onOperator6() (any, error) {
-	return ComparisonOperatorGreaterThan, nil
+	return ComparisonOperatorGreaterThanOrEqual, nil
 }
 
 func (p *parser) callonOperator6() (any, error) {
@@ -2172,7 +2172,7 @@ func (p *parser) callonOperator6() (any, error) {
 }
 
 func (c *current) onOperator8() (any, error) {
-	return ComparisonOperatorGreaterThanOrEqual, nil
+	return ComparisonOperatorGreaterThan, nil
 }
 
 func (p *parser) callonOperator8() (any, error) {
@@ -2411,11 +2411,11 @@ func (p *parser) callonFeature1() (any, error) {
 }
 
 func (c *current) onChoiceRange1(lower, upper any) (any, error) {
-	minVal := lower.(int)
+	minVal := int(lower.(int64))
 	if minVal <= 0 {
 		return nil, fmt.Errorf("invalid minimum: %d", minVal)
 	}
-	maxVal := upper.(int)
+	maxVal := int(upper.(int64))
 	if maxVal <= 0 && minVal > maxVal {
 		return nil, fmt.Errorf("invalid maximum: %d", maxVal)
 	}
@@ -2432,7 +2432,7 @@ func (c *current) onChoiceMin1(limit any) (any, error) {
 	if limit == nil {
 		return &ChoiceMinLimit{Min: 1}, nil
 	}
-	minVal := limit.(int)
+	minVal := int(limit.(int64))
 	if minVal <= 0 {
 		return nil, fmt.Errorf("invalid minimum: %d", minVal)
 	}
@@ -2449,7 +2449,7 @@ func (c *current) onChoiceMax1(limit any) (any, error) {
 	if limit == nil {
 		return &ChoiceMaxLimit{Max: 1}, nil
 	}
-	maxVal := limit.(int)
+	maxVal := int(limit.(int64))
 	if maxVal <= 0 {
 		return nil, fmt.Errorf("invalid maximum: %d", maxVal)
 	}
@@ -2463,7 +2463,7 @@ func (p *parser) callonChoiceMax1() (any, error) {
 }
 
 func (c *current) onChoiceExact1(limit any) (any, error) {
-	exact := limit.(int)
+	exact := int(limit.(int64))
 	if exact <= 0 {
 		return nil, fmt.Errorf("invalid exact: %d", exact)
 	}
@@ -0,0 +1,58 @@
+package conformance
+
+// RenameIdentifier replaces every reference to the given identifier or feature code in the conformance, in place,
+// and reports whether anything was renamed
+func RenameIdentifier(cs Set, from string, to string) (renamed bool) {
+	for _, c := range cs {
+		switch c := c.(type) {
+		case *Mandatory:
+			renamed = renameExpression(c.Expression, from, to) || renamed
+		case *Optional:
+			renamed = renameExpression(c.Expression, from, to) || renamed
+		}
+	}
+	return
+}
+
+func renameExpression(e Expression, from string, to string) (renamed bool) {
+	switch e := e.(type) {
+	case *IdentifierExpression:
+		if e.ID == from {
+			e.ID = to
+			renamed = true
+		}
+	case *FeatureExpression:
+		if e.Feature == from {
+			e.Feature = to
+			renamed = true
+		}
+	case *LogicalExpression:
+		renamed = renameExpression(e.Left, from, to)
+		for _, r := range e.Right {
+			renamed = renameExpression(r, from, to) || renamed
+		}
+	case *EqualityExpression:
+		renamed = renameExpression(e.Left, from, to)
+		renamed = renameExpression(e.Right, from, to) || renamed
+	case *ComparisonExpression:
+		renamed = renameValue(e.Left, from, to)
+		renamed = renameValue(e.Right, from, to) || renamed
+	}
+	return
+}
+
+func renameValue(v ComparisonValue, from string, to string) bool {
+	switch v := v.(type) {
+	case *IdentifierValue:
+		if v.ID == from {
+			v.ID = to
+			return true
+		}
+	case *FeatureValue:
+		if v.Feature == from {
+			v.Feature = to
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package conformance
+
+import "testing"
+
+var renameTests = []struct {
+	Conformance string
+	Renamed     string
+}{
+	{"AB", "XY"},
+	{"[AB & CD]", "[XY & CD]"},
+	{"!AB, O", "!XY, O"},
+	{"AB > 5, O", "(XY > 5), O"},
+	{"ABC \\| CD", ""},
+}
+
+func TestRenameIdentifier(t *testing.T) {
+	for _, rt := range renameTests {
+		cs, err := tryParseConformance(rt.Conformance)
+		if err != nil {
+			t.Errorf("failed parsing conformance %s: %v", rt.Conformance, err)
+			continue
+		}
+		renamed := RenameIdentifier(cs, "AB", "XY")
+		if rt.Renamed == "" {
+			if renamed {
+				t.Errorf("unexpected rename of %q to %q", rt.Conformance, cs.ASCIIDocString())
+			}
+			continue
+		}
+		if s := cs.ASCIIDocString(); !renamed || s != rt.Renamed {
+			t.Errorf("unexpected rename of %q: expected %q, got %q", rt.Conformance, rt.Renamed, s)
+		}
+	}
+}
@@ -87,8 +87,7 @@ func (cs Set) Equal(c Conformance) bool {
 		return false
 	}
 	for i, c := range cs {
-		oc := cs[i]
-		if !oc.Equal(c) {
+		if !c.Equal(ocs[i]) {
 			return false
 		}
 	}
@@ -0,0 +1,190 @@
+package conformance
+
+import "slices"
+
+// Simplify returns a canonical copy of the conformance: nested groups of the same operator are flattened, duplicate
+// terms are removed, terms that can never be reached are dropped, and otherwise-chains are put in canonical order
+// where that does not change their meaning
+func Simplify(cs Set) Set {
+	var simplified Set
+	for _, c := range cs {
+		c = c.Clone()
+		switch c := c.(type) {
+		case *Mandatory:
+			c.Expression = simplifyExpression(c.Expression)
+		case *Optional:
+			c.Expression = simplifyExpression(c.Expression)
+		}
+		if slices.ContainsFunc(simplified, c.Equal) {
+			// An earlier identical term always matches first
+			continue
+		}
+		simplified = append(simplified, c)
+	}
+	simplified = dropUnreachable(simplified)
+	return orderOtherwise(simplified)
+}
+
+func simplifyExpression(e Expression) Expression {
+	le, ok := e.(*LogicalExpression)
+	if !ok {
+		return e
+	}
+	var operands []Expression
+	for _, o := range slices.Concat([]Expression{le.Left}, le.Right) {
+		o = simplifyExpression(o)
+		if ole, ok := o.(*LogicalExpression); ok && !le.Not && !ole.Not && ole.Operand == le.Operand && le.Operand != "^" {
+			// (A | (B | C)) is just A | B | C
+			for _, oo := range slices.Concat([]Expression{ole.Left}, ole.Right) {
+				operands = appendOperand(operands, oo, le.Operand)
+			}
+			continue
+		}
+		operands = appendOperand(operands, o, le.Operand)
+	}
+	if len(operands) == 1 && !le.Not {
+		return operands[0]
+	}
+	return &LogicalExpression{Operand: le.Operand, Not: le.Not, Left: operands[0], Right: operands[1:]}
+}
+
+func appendOperand(operands []Expression, o Expression, operand string) []Expression {
+	if operand != "^" && slices.ContainsFunc(operands, o.Equal) {
+		// A | A is just A, and A & A is just A
+		return operands
+	}
+	return append(operands, o)
+}
+
+func dropUnreachable(cs Set) Set {
+	for i, c := range cs {
+		if m, ok := c.(*Mandatory); ok && m.Expression == nil {
+			// Nothing after an unconditional M is reachable; deprecation is kept, as ASCIIDocString does
+			tail := slices.DeleteFunc(slices.Clone(cs[i+1:]), func(c Conformance) bool {
+				_, ok := c.(*Deprecated)
+				return !ok
+			})
+			return slices.Concat(cs[:i+1], tail)
+		}
+	}
+	return cs
+}
+
+var otherwiseOrder = map[Type]int{
+	TypeProvisional: 1,
+	TypeMandatory:   2,
+	TypeOptional:    3,
+	TypeDeprecated:  4,
+	TypeDisallowed:  5,
+}
+
+// orderOtherwise moves terms towards the canonical P, M, O, D, X order, one swap at a time, keeping only the swaps that are provably equivalent
+func orderOtherwise(cs Set) Set {
+	for {
+		var swapped bool
+		for i := 1; i < len(cs); i++ {
+			a, aok := otherwiseOrder[cs[i-1].Type()]
+			b, bok := otherwiseOrder[cs[i].Type()]
+			if !aok || !bok || a <= b {
+				continue
+			}
+			candidate := slices.Clone(cs)
+			candidate[i-1], candidate[i] = candidate[i], candidate[i-1]
+			if equivalent, ok := Equivalent(cs, candidate); ok && equivalent {
+				cs = candidate
+				swapped = true
+			}
+		}
+		if !swapped {
+			return cs
+		}
+	}
+}
+
+const maxEquivalenceIdentifiers = 12
+
+// Equivalent reports whether two conformance sets produce the same state and choice for every combination of the
+// features and identifiers they refer to; ok is false if that can't be determined, e.g. because they refer to values
+func Equivalent(a Set, b Set) (equivalent bool, ok bool) {
+	ids := make(map[string]struct{})
+	if !conformanceIdentifiers(a, ids) || !conformanceIdentifiers(b, ids) || len(ids) > maxEquivalenceIdentifiers {
+		return false, false
+	}
+	names := make([]string, 0, len(ids))
+	for id := range ids {
+		names = append(names, id)
+	}
+	slices.Sort(names)
+	for combination := 0; combination < 1<<len(names); combination++ {
+		context := Context{Values: make(map[string]any, len(names))}
+		for i, id := range names {
+			context.Values[id] = combination&(1<<i) != 0
+		}
+		as, achoice, err := evalWithChoice(a, context)
+		if err != nil {
+			return false, false
+		}
+		bs, bchoice, err := evalWithChoice(b, context)
+		if err != nil {
+			return false, false
+		}
+		if as != bs || achoice != bchoice {
+			return false, true
+		}
+	}
+	return true, true
+}
+
+func evalWithChoice(cs Set, context Context) (State, string, error) {
+	for _, c := range cs {
+		state, err := c.Eval(context)
+		if err != nil {
+			return StateUnknown, "", err
+		}
+		if state == StateUnknown {
+			continue
+		}
+		if o, ok := c.(*Optional); ok && o.Choice != nil {
+			return state, o.Choice.ASCIIDocString(), nil
+		}
+		return state, "", nil
+	}
+	return StateDisallowed, "", nil
+}
+
+func conformanceIdentifiers(cs Set, ids map[string]struct{}) bool {
+	for _, c := range cs {
+		switch c := c.(type) {
+		case *Mandatory:
+			if c.Expression != nil && !expressionIdentifiers(c.Expression, ids) {
+				return false
+			}
+		case *Optional:
+			if c.Expression != nil && !expressionIdentifiers(c.Expression, ids) {
+				return false
+			}
+		case *Provisional, *Deprecated, *Disallowed:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func expressionIdentifiers(e Expression, ids map[string]struct{}) bool {
+	switch e := e.(type) {
+	case *IdentifierExpression:
+		ids[e.ID] = struct{}{}
+	case *FeatureExpression:
+		ids[e.Feature] = struct{}{}
+	case *LogicalExpression:
+		for _, o := range slices.Concat([]Expression{e.Left}, e.Right) {
+			if !expressionIdentifiers(o, ids) {
+				return false
+			}
+		}
+	default:
+		return false
+	}
+	return true
+}
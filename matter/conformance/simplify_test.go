@@ -0,0 +1,48 @@
+package conformance
+
+import "testing"
+
+var simplifyTests = []struct {
+	Conformance string
+	Simplified  string
+}{
+	{"AB \\| (CD \\| EF)", "AB \\| CD \\| EF"},
+	{"AB & (CD & EF)", "AB & CD & EF"},
+	{"AB \\| AB", "AB"},
+	{"[AB & AB]", "[AB]"},
+	{"AB, AB, O", "AB, O"},
+	{"M, O", "M"},
+	{"[!AB], AB", "AB, [!AB]"},
+	{"[AB], CD", "[AB], CD"},
+	{"(AB & CD) \\| EF", "(AB & CD) \\| EF"},
+}
+
+func TestSimplify(t *testing.T) {
+	for _, st := range simplifyTests {
+		cs, err := tryParseConformance(st.Conformance)
+		if err != nil {
+			t.Errorf("failed parsing conformance %s: %v", st.Conformance, err)
+			continue
+		}
+		simplified := Simplify(cs)
+		if s := simplified.ASCIIDocString(); s != st.Simplified {
+			t.Errorf("unexpected simplification of %q: expected %q, got %q", st.Conformance, st.Simplified, s)
+		}
+		equivalent, ok := Equivalent(cs, simplified)
+		if !ok || !equivalent {
+			t.Errorf("simplification of %q to %q is not equivalent", st.Conformance, simplified.ASCIIDocString())
+		}
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	a, _ := tryParseConformance("[AB], CD")
+	b, _ := tryParseConformance("CD, [AB]")
+	if equivalent, ok := Equivalent(a, b); !ok || equivalent {
+		t.Errorf("expected %q and %q to differ", a.ASCIIDocString(), b.ASCIIDocString())
+	}
+	c, _ := tryParseConformance("Max > 5")
+	if _, ok := Equivalent(c, c); ok {
+		t.Errorf("expected comparison conformance to be unverifiable")
+	}
+}
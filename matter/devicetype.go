@@ -14,9 +14,10 @@ type DeviceType struct {
 	Description string      `json:"description,omitempty"`
 	Revisions   []*Revision `json:"revisions,omitempty"`
 
-	Superset string `json:"superset,omitempty"`
-	Class    string `json:"class,omitempty"`
-	Scope    string `json:"scope,omitempty"`
+	Superset    string          `json:"superset,omitempty"`
+	Class       string          `json:"class,omitempty"`
+	Scope       string          `json:"scope,omitempty"`
+	Conformance conformance.Set `json:"conformance,omitempty"`
 
 	Conditions []*Condition `json:"conditions,omitempty"`
 
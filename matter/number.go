@@ -49,7 +49,12 @@ func ParseFormattedNumber(s string) (*Number, types.NumberFormat) {
 		}, types.NumberFormatInt
 	}
 
-	id, err = strconv.ParseUint(strings.TrimPrefix(s, "0x"), 16, 64)
+	hex := strings.TrimPrefix(s, "0x")
+	if len(hex) < len(s) {
+		// Manufacturer extended IDs are often written with the vendor prefix separated, e.g. 0xFFF1_FC00
+		hex = strings.ReplaceAll(hex, "_", "")
+	}
+	id, err = strconv.ParseUint(hex, 16, 64)
 	if err == nil {
 		return &Number{
 			text:   s,
@@ -135,6 +140,16 @@ func (n *Number) Format() types.NumberFormat {
 	return types.NumberFormatUndefined
 }
 
+// ManufacturerCode returns the vendor prefix in the upper 16 bits of a manufacturer extended ID; ok is false for
+// standard IDs
+func (n *Number) ManufacturerCode() (code uint64, ok bool) {
+	if !n.Valid() || n.value > math.MaxUint32 {
+		return 0, false
+	}
+	code = (n.Value() & 0xFFFF0000) >> 16
+	return code, code > 0
+}
+
 func (n *Number) IntString() string {
 	if !n.Valid() {
 		return n.Text()
@@ -208,29 +223,27 @@ func NonGlobalIDInvalidForEntity(n *Number, entityType types.EntityType) bool {
 		// Matter IDs are 32-bits, max
 		return true
 	}
-	prefix := (val & 0xFFFF0000) >> 16
-	var manufacturerCode bool
-	if prefix > 0 {
-		manufacturerCode = true
-	}
+	_, manufacturerCode := n.ManufacturerCode()
+	// Manufacturer extended IDs have the same ranges as standard IDs below the vendor prefix
+	suffix := val & 0xFFFF
 
 	switch entityType {
 	case types.EntityTypeDeviceType:
 		return val > 0xBFFF
 	case types.EntityTypeCluster:
 		if manufacturerCode {
-			return val < 0xFC00 && val > 0xFFFE
+			return suffix < 0xFC00 || suffix > 0xFFFE
 		}
 		return val > 0x7FFF
 	case types.EntityTypeAttribute:
-		return val > 0x4FFF
+		return suffix > 0x4FFF
 	case types.EntityTypeCommand:
 		if manufacturerCode {
-			return val > 0xFF
+			return suffix > 0xFF
 		}
 		return val > 0xDF
 	case types.EntityTypeEvent:
-		return val > 0xFF
+		return suffix > 0xFF
 	case types.EntityTypeCommandField, types.EntityTypeEventField, types.EntityTypeStructField:
 		return val > 0xDF
 	}
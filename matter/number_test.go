@@ -0,0 +1,31 @@
+package matter
+
+import "testing"
+
+type numberTest struct {
+	input string
+	valid bool
+	value uint64
+}
+
+var numberTests = []numberTest{
+	{"12", true, 12},
+	{"0x1A", true, 0x1A},
+	{"1A", true, 0x1A},
+	{"0xFFF1_FC00", true, 0xFFF1FC00},
+	{"FFF1_FC00", false, 0},
+	{"1_000", false, 0},
+}
+
+func TestParseNumber(t *testing.T) {
+	for _, nt := range numberTests {
+		n := ParseNumber(nt.input)
+		if n.Valid() != nt.valid {
+			t.Errorf("parsing %s; expected valid %v, got %v", nt.input, nt.valid, n.Valid())
+			continue
+		}
+		if nt.valid && n.Value() != nt.value {
+			t.Errorf("parsing %s; expected %d, got %d", nt.input, nt.value, n.Value())
+		}
+	}
+}
@@ -65,6 +65,7 @@ var TopLevelSectionOrders = map[DocType][]Section{
 		SectionClusterRestrictions,
 		SectionElementRequirements,
 		SectionEndpointComposition,
+		SectionComposedDeviceTypeRequirements,
 	},
 }
 
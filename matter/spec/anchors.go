@@ -167,6 +167,12 @@ func (doc *Doc) makeAnchor(parent parse.HasElements, element asciidoc.Element, c
 	return a
 }
 
+// AnchorID returns the ID of the anchor explicitly defined on an element, if any
+func AnchorID(element asciidoc.Element) string {
+	id, _ := getAnchorElements(element, nil)
+	return id
+}
+
 func getAnchorElements(element asciidoc.Element, crossReferences map[string][]*CrossReference) (id string, labelSet asciidoc.Set) {
 	var idAttr asciidoc.Attribute
 	var refTextAttr *asciidoc.NamedAttribute
@@ -56,6 +56,7 @@ func (sp *Builder) buildSpec(docs []*Doc) (spec *Specification, err error) {
 	}
 
 	var basicInformationCluster, bridgedBasicInformationCluster *matter.Cluster
+	extensions := make(map[*matter.Cluster]*Doc)
 
 	for _, d := range docs {
 		slog.Debug("building spec", "path", d.Path)
@@ -81,6 +82,10 @@ func (sp *Builder) buildSpec(docs []*Doc) (spec *Specification, err error) {
 			switch m := m.(type) {
 			case *matter.ClusterGroup:
 				for _, c := range m.Clusters {
+					if isClusterExtension(d, c) {
+						extensions[c] = d
+						continue
+					}
 					addClusterToSpec(spec, d, c)
 				}
 			case *matter.Cluster:
@@ -90,6 +95,10 @@ func (sp *Builder) buildSpec(docs []*Doc) (spec *Specification, err error) {
 				case "Bridged Device Basic Information":
 					bridgedBasicInformationCluster = m
 				}
+				if isClusterExtension(d, m) {
+					extensions[m] = d
+					break
+				}
 				addClusterToSpec(spec, d, m)
 			case *matter.DeviceType:
 				spec.DeviceTypes = append(spec.DeviceTypes, m)
@@ -117,6 +126,8 @@ func (sp *Builder) buildSpec(docs []*Doc) (spec *Specification, err error) {
 
 	}
 
+	addClusterExtensions(spec, extensions)
+
 	if !sp.IgnoreHierarchy {
 		resolveHierarchy(spec)
 	}
@@ -145,21 +156,68 @@ func buildDocumentGroups(docs []*Doc, spec *Specification) {
 
 func buildClusterReferences(spec *Specification) {
 	for _, c := range spec.ClustersByName {
-		if c.Features != nil {
-			spec.ClusterRefs.Add(c, c.Features)
+		addClusterReferences(spec, c)
+	}
+	for c := range spec.ClusterExtensions {
+		addClusterReferences(spec, c)
+	}
+}
+
+func addClusterReferences(spec *Specification, c *matter.Cluster) {
+	if c.Features != nil {
+		spec.ClusterRefs.Add(c, c.Features)
+	}
+	for _, en := range c.Bitmaps {
+		spec.ClusterRefs.Add(c, en)
+	}
+	for _, en := range c.Enums {
+		spec.ClusterRefs.Add(c, en)
+	}
+	for _, en := range c.Structs {
+		spec.ClusterRefs.Add(c, en)
+	}
+}
+
+// isClusterExtension returns true for vendor clusters with a standard ID, which add to that cluster rather than
+// replacing it
+func isClusterExtension(d *Doc, c *matter.Cluster) bool {
+	if !d.Vendor || !c.ID.Valid() {
+		return false
+	}
+	_, manufacturerSpecific := c.ID.ManufacturerCode()
+	return !manufacturerSpecific
+}
+
+// addClusterExtensions links vendor extensions to the standard clusters they extend; every element an extension adds
+// must have a manufacturer extended ID, so it can't collide with the standard cluster's own
+func addClusterExtensions(spec *Specification, extensions map[*matter.Cluster]*Doc) {
+	for ext, d := range extensions {
+		base, ok := spec.ClustersByID[ext.ID.Value()]
+		if !ok {
+			slog.Warn("Vendor cluster extension of unknown cluster", slog.String("clusterId", ext.ID.HexString()), slog.String("clusterName", ext.Name), log.Path("source", d.Path))
+			continue
 		}
-		for _, en := range c.Bitmaps {
-			spec.ClusterRefs.Add(c, en)
+		spec.ClusterExtensions[ext] = base
+		spec.Clusters[ext] = struct{}{}
+		addClusterDataTypes(spec, d, ext)
+		for _, a := range ext.Attributes {
+			warnStandardExtensionID(ext, a.Name, a.ID, d)
 		}
-		for _, en := range c.Enums {
-			spec.ClusterRefs.Add(c, en)
+		for _, c := range ext.Commands {
+			warnStandardExtensionID(ext, c.Name, c.ID, d)
 		}
-		for _, en := range c.Structs {
-			spec.ClusterRefs.Add(c, en)
+		for _, e := range ext.Events {
+			warnStandardExtensionID(ext, e.Name, e.ID, d)
 		}
 	}
 }
 
+func warnStandardExtensionID(ext *matter.Cluster, name string, id *matter.Number, d *Doc) {
+	if _, ok := id.ManufacturerCode(); !ok {
+		slog.Warn("Vendor cluster extension element without a manufacturer extended ID", slog.String("clusterName", ext.Name), slog.String("name", name), slog.String("id", id.HexString()), log.Path("source", d.Path))
+	}
+}
+
 func indexAnchors(docs []*Doc) (err error) {
 	for _, d := range docs {
 		var anchors map[string][]*Anchor
@@ -220,7 +278,10 @@ func addClusterToSpec(spec *Specification, d *Doc, m *matter.Cluster) {
 		slog.Warn("Duplicate cluster Name", slog.String("clusterId", m.ID.HexString()), slog.String("clusterName", m.Name), slog.String("existingClusterId", existing.ID.HexString()))
 	}
 	spec.ClustersByName[m.Name] = m
+	addClusterDataTypes(spec, d, m)
+}
 
+func addClusterDataTypes(spec *Specification, d *Doc, m *matter.Cluster) {
 	for _, en := range m.Bitmaps {
 		_, ok := spec.bitmapIndex[en.Name]
 		if ok {
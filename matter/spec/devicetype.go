@@ -92,6 +92,7 @@ func readDeviceTypeIDs(doc *Doc, s *Section) ([]*matter.DeviceType, error) {
 		if err != nil {
 			return nil, err
 		}
+		c.Conformance = ti.ReadConformance(row, matter.TableColumnConformance)
 		deviceTypes = append(deviceTypes, c)
 	}
 
@@ -24,7 +24,9 @@ type Doc struct {
 
 	docType matter.DocType
 
-	Domain   matter.Domain
+	Domain matter.Domain
+	// Vendor is set on documents loaded from a vendor root, rather than the spec
+	Vendor   bool
 	parents  []*Doc
 	children []*Doc
 
@@ -89,6 +91,10 @@ func (doc *Doc) Parents() []*Doc {
 	return p
 }
 
+func (doc *Doc) Spec() *Specification {
+	return doc.spec
+}
+
 func (doc *Doc) Group() *DocGroup {
 	return doc.group
 }
@@ -16,6 +16,9 @@ func (s *Section) toFeatures(d *Doc, entityMap map[asciidoc.Attributable][]types
 	var ti *TableInfo
 	ti, err = parseFirstTable(d, s)
 	if err != nil {
+		if err == ErrNoTableFound {
+			return nil, nil
+		}
 		return nil, fmt.Errorf("failed reading features: %w", err)
 
 	}
@@ -20,10 +20,11 @@ func ParseFile(path asciidoc.Path, specRoot string, attributes ...asciidoc.Attri
 		return nil, err
 	}
 	defer contents.Close()
-	return parseReader(contents, path, specRoot, attributes...)
+	return ParseReader(contents, path, specRoot, attributes...)
 }
 
-func parseReader(r io.Reader, path asciidoc.Path, specRoot string, attributes ...asciidoc.AttributeName) (doc *Doc, err error) {
+// ParseReader parses the AsciiDoc in r as if it had been read from path
+func ParseReader(r io.Reader, path asciidoc.Path, specRoot string, attributes ...asciidoc.AttributeName) (doc *Doc, err error) {
 	var d *asciidoc.Document
 
 	d, err = parseDocument(r, path, specRoot, attributes...)
@@ -70,6 +71,7 @@ func parseDocument(r io.Reader, path asciidoc.Path, specRoot string, attributes
 type Parser struct {
 	rootPath   string
 	attributes []asciidoc.AttributeName
+	vendor     bool
 }
 
 func NewParser(rootPath string, attributes []asciidoc.AttributeName) (Parser, error) {
@@ -83,6 +85,14 @@ func NewParser(rootPath string, attributes []asciidoc.AttributeName) (Parser, er
 	return Parser{rootPath: rootPath, attributes: attributes}, nil
 }
 
+// NewVendorParser parses documents from a vendor root laid out like the spec, marking them as vendor documents; their
+// paths are relative to the spec root, so they never pick up the errata of a spec document with the same name
+func NewVendorParser(specRoot string, attributes []asciidoc.AttributeName) (Parser, error) {
+	p, err := NewParser(specRoot, attributes)
+	p.vendor = true
+	return p, err
+}
+
 func (p Parser) Name() string {
 	return "Parsing documents"
 }
@@ -103,6 +113,7 @@ func (p Parser) Process(cxt context.Context, input *pipeline.Data[struct{}], ind
 	if err != nil {
 		return
 	}
+	doc.Vendor = p.vendor
 	outputs = append(outputs, &pipeline.Data[*Doc]{Path: input.Path, Content: doc})
 	return
 }
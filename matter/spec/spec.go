@@ -24,6 +24,10 @@ type Specification struct {
 	ClusterRefs ClusterRefs
 	DocRefs     map[types.Entity]*Doc
 
+	// ClusterExtensions maps clusters from vendor documents that add manufacturer-specific elements to a standard
+	// cluster to the cluster they extend
+	ClusterExtensions map[*matter.Cluster]*matter.Cluster
+
 	bitmapIndex  map[string]*matter.Bitmap
 	enumIndex    map[string]*matter.Enum
 	structIndex  map[string]*matter.Struct
@@ -46,6 +50,8 @@ func newSpec() *Specification {
 		ClusterRefs:    ClusterRefs{refs: make(map[types.Entity]map[*matter.Cluster]struct{})},
 		DocRefs:        make(map[types.Entity]*Doc),
 
+		ClusterExtensions: make(map[*matter.Cluster]*matter.Cluster),
+
 		bitmapIndex:  make(map[string]*matter.Bitmap),
 		enumIndex:    make(map[string]*matter.Enum),
 		structIndex:  make(map[string]*matter.Struct),
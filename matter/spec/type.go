@@ -92,10 +92,30 @@ func (doc *Doc) determineDocType() (matter.DocType, error) {
 			return matter.DocTypeSoftAP, nil
 		}
 	}
+	if doc.Vendor {
+		// Vendor roots needn't follow the spec's layout, so any doc with a cluster ID section is a cluster
+		if doc.guessDocType() == matter.DocTypeCluster || doc.hasClusterIDSection() {
+			return matter.DocTypeCluster, nil
+		}
+	}
 	slog.Debug("could not determine doc type", "path", doc.Path)
 	return matter.DocTypeUnknown, nil
 }
 
+func (doc *Doc) hasClusterIDSection() bool {
+	firstSection := parse.FindFirst[*Section](doc.Elements())
+	if firstSection == nil {
+		return false
+	}
+	for _, s := range parse.Skim[*Section](firstSection.Elements()) {
+		switch strings.ToLower(s.Name) {
+		case "cluster identifiers", "cluster id", "cluster ids":
+			return true
+		}
+	}
+	return false
+}
+
 func (doc *Doc) guessDocType() matter.DocType {
 	firstSection := parse.FindFirst[*Section](doc.Elements())
 	if firstSection != nil {
@@ -9,7 +9,7 @@ import (
 	"github.com/project-chip/alchemy/matter/types"
 )
 
-func validateDeviceTypes(spec *spec.Specification) {
+func (vs *violations) validateDeviceTypes(spec *spec.Specification) {
 	for _, dt := range spec.DeviceTypes {
 		requiredClusterIDs := make(map[uint64]*matter.Cluster)
 		for _, cr := range dt.ClusterRequirements {
@@ -19,7 +19,7 @@ func validateDeviceTypes(spec *spec.Specification) {
 			clusterID := cr.ClusterID.Value()
 			c, ok := spec.ClustersByID[clusterID]
 			if !ok {
-				slog.Error("Cluster Requirement references unknown cluster ID", slog.String("deviceType", dt.Name), slog.String("clusterId", cr.ClusterID.HexString()))
+				vs.add(slog.LevelError, dt, "Cluster Requirement references unknown cluster ID", slog.String("deviceType", dt.Name), slog.String("clusterId", cr.ClusterID.HexString()))
 				requiredClusterIDs[clusterID] = nil
 				continue
 			}
@@ -27,7 +27,7 @@ func validateDeviceTypes(spec *spec.Specification) {
 			name := stripName(cr.ClusterName)
 			clusterName := stripName(c.Name)
 			if !strings.EqualFold(name, clusterName) {
-				slog.Error("Cluster Requirement mismatch", slog.String("deviceType", dt.Name), slog.String("clusterName", cr.ClusterName), slog.String("referencedName", c.Name))
+				vs.add(slog.LevelError, dt, "Cluster Requirement mismatch", slog.String("deviceType", dt.Name), slog.String("clusterName", cr.ClusterName), slog.String("referencedName", c.Name))
 				continue
 			}
 		}
@@ -37,11 +37,11 @@ func validateDeviceTypes(spec *spec.Specification) {
 			}
 			c, ok := requiredClusterIDs[er.ClusterID.Value()]
 			if !ok {
-				slog.Error("Element Requirement references non-required cluster", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName))
+				vs.add(slog.LevelError, dt, "Element Requirement references non-required cluster", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName))
 				continue
 			}
 			if c == nil {
-				slog.Error("Element Requirement references unknown cluster", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName))
+				vs.add(slog.LevelError, dt, "Element Requirement references unknown cluster", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName))
 				continue
 			}
 			switch er.Element {
@@ -54,7 +54,7 @@ func validateDeviceTypes(spec *spec.Specification) {
 					}
 				}
 				if !found {
-					slog.Error("Element Requirement references unknown attribute", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName), slog.String("attributeName", er.Name))
+					vs.add(slog.LevelError, dt, "Element Requirement references unknown attribute", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName), slog.String("attributeName", er.Name))
 				}
 			case types.EntityTypeFeature:
 				found := false
@@ -69,7 +69,7 @@ func validateDeviceTypes(spec *spec.Specification) {
 					}
 				}
 				if !found {
-					slog.Error("Element Requirement references unknown feature", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName), slog.String("featureName", er.Name))
+					vs.add(slog.LevelError, dt, "Element Requirement references unknown feature", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName), slog.String("featureName", er.Name))
 				}
 			case types.EntityTypeCommand:
 				found := false
@@ -80,7 +80,7 @@ func validateDeviceTypes(spec *spec.Specification) {
 					}
 				}
 				if !found {
-					slog.Error("Element Requirement references unknown command", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName), slog.String("commandName", er.Name))
+					vs.add(slog.LevelError, dt, "Element Requirement references unknown command", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName), slog.String("commandName", er.Name))
 				}
 			case types.EntityTypeEvent:
 				found := false
@@ -91,11 +91,11 @@ func validateDeviceTypes(spec *spec.Specification) {
 					}
 				}
 				if !found {
-					slog.Error("Element Requirement references unknown event", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName), slog.String("commandName", er.Name))
+					vs.add(slog.LevelError, dt, "Element Requirement references unknown event", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName), slog.String("commandName", er.Name))
 				}
 
 			default:
-				slog.Error("Unknown entity type", slog.String("entityType", er.Element.String()))
+				vs.add(slog.LevelError, dt, "Unknown entity type", slog.String("entityType", er.Element.String()))
 			}
 		}
 	}
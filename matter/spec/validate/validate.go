@@ -1,14 +1,57 @@
 package validate
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
 	"strings"
 
+	"github.com/project-chip/alchemy/internal/log"
 	"github.com/project-chip/alchemy/matter/spec"
 )
 
-func Validate(spec *spec.Specification) {
-	validateStructs(spec)
-	validateDeviceTypes(spec)
+// Violation is a problem found in the spec's object model
+type Violation struct {
+	Path    string
+	Line    int
+	Level   slog.Level
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", v.Path, v.Line, v.Message)
+	}
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+type violations []Violation
+
+// add logs a violation, and records it with the details from its attributes
+func (vs *violations) add(level slog.Level, source log.Source, msg string, attrs ...slog.Attr) {
+	v := Violation{Level: level, Message: msg}
+	if source != nil {
+		v.Path, v.Line = source.Origin()
+		attrs = append([]slog.Attr{log.Path("path", source)}, attrs...)
+	}
+	var details []string
+	for _, a := range attrs {
+		if a.Key != "path" {
+			details = append(details, fmt.Sprintf("%s=%s", a.Key, a.Value.String()))
+		}
+	}
+	if len(details) > 0 {
+		v.Message = fmt.Sprintf("%s (%s)", msg, strings.Join(details, ", "))
+	}
+	*vs = append(*vs, v)
+	slog.LogAttrs(context.Background(), level, msg, attrs...)
+}
+
+func Validate(spec *spec.Specification) []Violation {
+	var vs violations
+	vs.validateStructs(spec)
+	vs.validateDeviceTypes(spec)
+	return vs
 }
 
 func stripName(s string) string {
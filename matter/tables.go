@@ -131,6 +131,12 @@ const (
 	TableTypeEvents
 	TableTypeEvent
 	TableTypeFeatures
+	TableTypeConditions
+	TableTypeClusterRequirements
+	TableTypeElementRequirements
+	TableTypeComposedDeviceTypeRequirements
+	TableTypeNamespace
+	TableTypeNamespaceTags
 )
 
 type Table struct {
@@ -170,6 +176,7 @@ var Tables = map[TableType]Table{
 			TableColumnSuperset,
 			TableColumnClass, // This will get renamed to Scope
 			TableColumnScope,
+			TableColumnConformance,
 		},
 	},
 	TableTypeClassification: {
@@ -267,6 +274,68 @@ var Tables = map[TableType]Table{
 			TableColumnID: TableColumnBit, // Rename ID to Bit
 		},
 	},
+	TableTypeConditions: {
+		ColumnOrder: []TableColumn{
+			TableColumnCondition,
+			TableColumnFeature,
+			TableColumnDescription,
+		},
+	},
+	TableTypeClusterRequirements: {
+		ColumnOrder: []TableColumn{
+			TableColumnID,
+			TableColumnCluster,
+			TableColumnClientServer,
+			TableColumnQuality,
+			TableColumnConformance,
+		},
+		ColumnNames: map[TableColumn]TableColumn{
+			TableColumnName: TableColumnCluster, // Rename Name to Cluster
+		},
+	},
+	TableTypeElementRequirements: {
+		ColumnOrder: []TableColumn{
+			TableColumnID,
+			TableColumnClusterID,
+			TableColumnCluster,
+			TableColumnElement,
+			TableColumnName,
+			TableColumnQuality,
+			TableColumnConstraint,
+			TableColumnAccess,
+			TableColumnConformance,
+		},
+	},
+	TableTypeComposedDeviceTypeRequirements: {
+		ColumnOrder: []TableColumn{
+			TableColumnDeviceID,
+			TableColumnDeviceName,
+			TableColumnClusterID,
+			TableColumnCluster,
+			TableColumnElement,
+			TableColumnName,
+			TableColumnQuality,
+			TableColumnConstraint,
+			TableColumnAccess,
+			TableColumnConformance,
+		},
+	},
+	TableTypeNamespace: {
+		ColumnOrder: []TableColumn{
+			TableColumnID,
+			TableColumnNamespace,
+		},
+	},
+	TableTypeNamespaceTags: {
+		ColumnOrder: []TableColumn{
+			TableColumnID,
+			TableColumnName,
+			TableColumnSummary,
+		},
+		ColumnNames: map[TableColumn]TableColumn{
+			TableColumnDescription: TableColumnSummary, // Rename Description to Summary
+		},
+	},
 }
 
 var ClusterIDSectionName = "Cluster ID"
@@ -0,0 +1,189 @@
+package scaffold
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+)
+
+var clusterHeader = `[[ref_%sCluster]]
+= %s Cluster
+
+%s
+
+== Revision History
+The global ClusterRevision attribute value SHALL be the highest revision number in the table below.
+
+`
+
+// Cluster returns the AsciiDoc for a cluster definition, with its sections and tables laid out the way disco-ball expects
+func Cluster(def *ClusterDefinition) string {
+	def.setDefaults()
+	description := def.Description
+	if description == "" {
+		description = "This cluster provides TODO."
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(clusterHeader, entityName(def.Name), def.Name, description))
+	var rows [][]string
+	for _, r := range def.Revisions {
+		rows = append(rows, []string{r.Revision, r.Description})
+	}
+	writeTable(&b, []string{matter.TableColumnNames[matter.TableColumnRevision], matter.TableColumnNames[matter.TableColumnDescription]}, rows...)
+
+	b.WriteString("\n== Classification\n\n")
+	writeTable(&b, columnNames(matter.TableTypeAppClusterClassification, matter.TableTypeClassification), []string{def.Hierarchy, def.Role, def.Scope, def.PICS})
+
+	b.WriteString("\n== " + matter.ClusterIDSectionName + "\n\n")
+	writeTable(&b, columnNames(matter.TableTypeClusterID), []string{matter.ParseNumber(def.ID).HexString(), def.Name, def.Conformance})
+
+	b.WriteString("\n== Features\nThis cluster SHALL support the FeatureMap bitmap attribute as defined below.\n\n")
+	rows = nil
+	for _, f := range def.Features {
+		rows = append(rows, []string{f.Bit, f.Code, f.Name, conformanceCell(f.Conformance), f.Summary})
+	}
+	writeTable(&b, columnNames(matter.TableTypeFeatures), rows...)
+
+	b.WriteString("\n== Data Types\n")
+	for _, bm := range def.Bitmaps {
+		writeDataTypeHeader(&b, bm.Name, bm.Type, bm.Description)
+		rows = nil
+		for _, bit := range bm.Bits {
+			rows = append(rows, []string{bit.Bit, bit.Name, bit.Summary, conformanceCell(bit.Conformance)})
+		}
+		writeTable(&b, columnNames(matter.TableTypeBitmap), rows...)
+	}
+	for _, e := range def.Enums {
+		writeDataTypeHeader(&b, e.Name, e.Type, e.Description)
+		rows = nil
+		for _, v := range e.Values {
+			rows = append(rows, []string{v.Value, v.Name, v.Summary, conformanceCell(v.Conformance)})
+		}
+		writeTable(&b, columnNames(matter.TableTypeEnum), rows...)
+	}
+	for _, s := range def.Structs {
+		writeDataTypeHeader(&b, s.Name, "", s.Description)
+		writeFields(&b, def, columnNames(matter.TableTypeStruct), s.Fields, "====", "Field")
+	}
+
+	b.WriteString("\n== Attributes\n\n")
+	writeFields(&b, def, columnNames(matter.TableTypeAttributes), def.Attributes, "===", "Attribute")
+
+	b.WriteString("\n== " + matter.CommandsSectionName + "\n\n")
+	rows = nil
+	for _, c := range def.Commands {
+		rows = append(rows, []string{matter.ParseNumber(c.ID).HexString(), c.Name, c.Direction, c.Response, c.Access, c.Quality, conformanceCell(c.Conformance)})
+	}
+	writeTable(&b, columnNames(matter.TableTypeCommands), rows...)
+	for _, c := range def.Commands {
+		writeEntitySection(&b, "===", c.Name, "Command", c.Description)
+		if len(c.Fields) > 0 {
+			b.WriteString("\n")
+			writeFields(&b, def, columnNames(matter.TableTypeEvent), c.Fields, "====", "Field")
+		}
+	}
+
+	b.WriteString("\n== Events\n\n")
+	rows = nil
+	for _, e := range def.Events {
+		rows = append(rows, []string{matter.ParseNumber(e.ID).HexString(), e.Name, e.Priority, e.Quality, e.Access, conformanceCell(e.Conformance)})
+	}
+	writeTable(&b, columnNames(matter.TableTypeEvents), rows...)
+	for _, e := range def.Events {
+		writeEntitySection(&b, "===", e.Name, "Event", e.Description)
+		if len(e.Fields) > 0 {
+			b.WriteString("\n")
+			writeFields(&b, def, columnNames(matter.TableTypeEvent), e.Fields, "====", "Field")
+		}
+	}
+	return b.String()
+}
+
+func writeDataTypeHeader(b *strings.Builder, name string, baseType string, description string) {
+	b.WriteString(fmt.Sprintf("\n[[ref_%s,%s]]\n=== %s Type\n", name, name, name))
+	if baseType != "" {
+		b.WriteString(fmt.Sprintf("This data type is derived from %s.", baseType))
+		if description != "" {
+			b.WriteString(" " + description)
+		}
+		b.WriteString("\n\n")
+	} else if description != "" {
+		b.WriteString(description + "\n\n")
+	} else {
+		b.WriteString("\n")
+	}
+}
+
+func writeEntitySection(b *strings.Builder, level string, name string, suffix string, description string) {
+	b.WriteString(fmt.Sprintf("\n%s %s %s\n", level, name, suffix))
+	if description != "" {
+		b.WriteString(description + "\n")
+	}
+}
+
+// writeFields writes a table of fields with the given columns, followed by a section for each field with a description
+func writeFields(b *strings.Builder, def *ClusterDefinition, header []string, fields []FieldDefinition, level string, suffix string) {
+	var rows [][]string
+	for _, f := range fields {
+		cells := map[string]string{
+			matter.TableColumnNames[matter.TableColumnID]:          matter.ParseNumber(f.ID).HexString(),
+			matter.TableColumnNames[matter.TableColumnName]:        f.Name,
+			matter.TableColumnNames[matter.TableColumnType]:        def.typeCell(f.Type),
+			matter.TableColumnNames[matter.TableColumnConstraint]:  f.Constraint,
+			matter.TableColumnNames[matter.TableColumnQuality]:     f.Quality,
+			matter.TableColumnNames[matter.TableColumnDefault]:     f.Default,
+			matter.TableColumnNames[matter.TableColumnAccess]:      f.Access,
+			matter.TableColumnNames[matter.TableColumnConformance]: conformanceCell(f.Conformance),
+		}
+		var row []string
+		for _, h := range header {
+			row = append(row, cells[h])
+		}
+		rows = append(rows, row)
+	}
+	writeTable(b, header, rows...)
+	for _, f := range fields {
+		if f.Description != "" {
+			writeEntitySection(b, level, f.Name, suffix, f.Description)
+		}
+	}
+}
+
+// typeCell links data types defined by the cluster to their sections
+func (def *ClusterDefinition) typeCell(dataType string) string {
+	name := strings.TrimSpace(dataType)
+	list := strings.HasPrefix(name, "list[") && strings.HasSuffix(name, "]")
+	if list {
+		name = strings.TrimSpace(name[5 : len(name)-1])
+	}
+	if slices.Contains(def.dataTypeNames(), name) {
+		name = "<<ref_" + name + ">>"
+	}
+	if list {
+		return "list[" + name + "]"
+	}
+	return name
+}
+
+func (def *ClusterDefinition) dataTypeNames() (names []string) {
+	for _, bm := range def.Bitmaps {
+		names = append(names, bm.Name)
+	}
+	for _, e := range def.Enums {
+		names = append(names, e.Name)
+	}
+	for _, s := range def.Structs {
+		names = append(names, s.Name)
+	}
+	return
+}
+
+func conformanceCell(conformance string) string {
+	if conformance == "" {
+		return "M"
+	}
+	return conformance
+}
@@ -0,0 +1,351 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/zap"
+)
+
+// ClusterDefinition describes a cluster in YAML or JSON, without any of the prose a spec document would have;
+// conformance, constraint, access and quality use the same notation as the spec's tables
+type ClusterDefinition struct {
+	Name        string `yaml:"name"`
+	ID          string `yaml:"id"`
+	Description string `yaml:"description,omitempty"`
+	Domain      string `yaml:"domain,omitempty"`
+	PICS        string `yaml:"pics,omitempty"`
+	Hierarchy   string `yaml:"hierarchy,omitempty"`
+	Role        string `yaml:"role,omitempty"`
+	Scope       string `yaml:"scope,omitempty"`
+	Conformance string `yaml:"conformance,omitempty"`
+
+	Revisions  []RevisionDefinition `yaml:"revisions,omitempty"`
+	Features   []FeatureDefinition  `yaml:"features,omitempty"`
+	Bitmaps    []BitmapDefinition   `yaml:"bitmaps,omitempty"`
+	Enums      []EnumDefinition     `yaml:"enums,omitempty"`
+	Structs    []StructDefinition   `yaml:"structs,omitempty"`
+	Attributes []FieldDefinition    `yaml:"attributes,omitempty"`
+	Commands   []CommandDefinition  `yaml:"commands,omitempty"`
+	Events     []EventDefinition    `yaml:"events,omitempty"`
+}
+
+type RevisionDefinition struct {
+	Revision    string `yaml:"revision"`
+	Description string `yaml:"description"`
+}
+
+type FeatureDefinition struct {
+	Bit         string `yaml:"bit"`
+	Code        string `yaml:"code"`
+	Name        string `yaml:"name"`
+	Summary     string `yaml:"summary,omitempty"`
+	Conformance string `yaml:"conformance,omitempty"`
+}
+
+type BitmapDefinition struct {
+	Name        string          `yaml:"name"`
+	Type        string          `yaml:"type,omitempty"`
+	Description string          `yaml:"description,omitempty"`
+	Bits        []BitDefinition `yaml:"bits"`
+}
+
+type BitDefinition struct {
+	Bit         string `yaml:"bit"`
+	Name        string `yaml:"name"`
+	Summary     string `yaml:"summary,omitempty"`
+	Conformance string `yaml:"conformance,omitempty"`
+}
+
+type EnumDefinition struct {
+	Name        string            `yaml:"name"`
+	Type        string            `yaml:"type,omitempty"`
+	Description string            `yaml:"description,omitempty"`
+	Values      []ValueDefinition `yaml:"values"`
+}
+
+type ValueDefinition struct {
+	Value       string `yaml:"value"`
+	Name        string `yaml:"name"`
+	Summary     string `yaml:"summary,omitempty"`
+	Conformance string `yaml:"conformance,omitempty"`
+}
+
+type StructDefinition struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description,omitempty"`
+	Fields      []FieldDefinition `yaml:"fields"`
+}
+
+type FieldDefinition struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Type        string `yaml:"type"`
+	Description string `yaml:"description,omitempty"`
+	Constraint  string `yaml:"constraint,omitempty"`
+	Quality     string `yaml:"quality,omitempty"`
+	Default     string `yaml:"default,omitempty"`
+	Access      string `yaml:"access,omitempty"`
+	Conformance string `yaml:"conformance,omitempty"`
+}
+
+type CommandDefinition struct {
+	ID          string            `yaml:"id"`
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description,omitempty"`
+	Direction   string            `yaml:"direction,omitempty"`
+	Response    string            `yaml:"response,omitempty"`
+	Access      string            `yaml:"access,omitempty"`
+	Quality     string            `yaml:"quality,omitempty"`
+	Conformance string            `yaml:"conformance,omitempty"`
+	Fields      []FieldDefinition `yaml:"fields,omitempty"`
+}
+
+type EventDefinition struct {
+	ID          string            `yaml:"id"`
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description,omitempty"`
+	Priority    string            `yaml:"priority,omitempty"`
+	Quality     string            `yaml:"quality,omitempty"`
+	Access      string            `yaml:"access,omitempty"`
+	Conformance string            `yaml:"conformance,omitempty"`
+	Fields      []FieldDefinition `yaml:"fields,omitempty"`
+}
+
+// ReadDefinition loads a cluster definition from a YAML or JSON file, filling in defaults and checking that every
+// value can be read the way the spec's tables are read
+func ReadDefinition(path string) (*ClusterDefinition, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var def ClusterDefinition
+	err = yaml.UnmarshalWithOptions(b, &def, yaml.DisallowUnknownField())
+	if err != nil {
+		return nil, fmt.Errorf("error reading cluster definition %s: %w", path, err)
+	}
+	def.setDefaults()
+	err = def.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster definition %s: %w", path, err)
+	}
+	return &def, nil
+}
+
+func (def *ClusterDefinition) setDefaults() {
+	def.Name = strings.TrimSpace(def.Name)
+	if def.PICS == "" {
+		def.PICS = strings.ToUpper(entityName(def.Name))
+	}
+	if def.Hierarchy == "" {
+		def.Hierarchy = "Base"
+	}
+	if def.Role == "" {
+		def.Role = "Application"
+	}
+	if def.Scope == "" {
+		def.Scope = "Endpoint"
+	}
+	if def.Conformance == "" {
+		def.Conformance = "P"
+	}
+	if len(def.Revisions) == 0 {
+		def.Revisions = []RevisionDefinition{{Revision: "1", Description: "Initial revision"}}
+	}
+	for i := range def.Bitmaps {
+		if def.Bitmaps[i].Type == "" {
+			def.Bitmaps[i].Type = "map8"
+		}
+	}
+	for i := range def.Enums {
+		if def.Enums[i].Type == "" {
+			def.Enums[i].Type = "enum8"
+		}
+	}
+	for i := range def.Commands {
+		if def.Commands[i].Direction == "" {
+			def.Commands[i].Direction = "client => server"
+		}
+		if def.Commands[i].Response == "" {
+			def.Commands[i].Response = "N"
+		}
+	}
+}
+
+// domain returns the domain the definition names, if any
+func (def *ClusterDefinition) domain() matter.Domain {
+	return zap.StringToDomain(def.Domain)
+}
+
+func (def *ClusterDefinition) validate() (err error) {
+	if def.Name == "" {
+		return fmt.Errorf("missing cluster name")
+	}
+	if err = validateNumber("cluster ID", def.ID); err != nil {
+		return
+	}
+	if def.Domain != "" && def.domain() == matter.DomainUnknown {
+		return fmt.Errorf("unknown domain %q", def.Domain)
+	}
+	if err = validateConformance(def.Name, def.Conformance); err != nil {
+		return
+	}
+	for _, r := range def.Revisions {
+		if err = validateNumber("revision", r.Revision); err != nil {
+			return
+		}
+	}
+	for _, f := range def.Features {
+		if err = validateNumber("bit of feature "+f.Name, f.Bit); err != nil {
+			return
+		}
+		if f.Code == "" || f.Name == "" {
+			return fmt.Errorf("feature at bit %s is missing its code or name", f.Bit)
+		}
+		if err = validateConformance(f.Name, f.Conformance); err != nil {
+			return
+		}
+	}
+	for _, bm := range def.Bitmaps {
+		if err = validateDataTypeName(bm.Name, "Bitmap"); err != nil {
+			return
+		}
+		if dt := types.ParseDataType(bm.Type, false); dt == nil || !dt.IsMap() {
+			return fmt.Errorf("bitmap %s has invalid type %q", bm.Name, bm.Type)
+		}
+		for _, b := range bm.Bits {
+			if err = validateNumber(fmt.Sprintf("bit of %s.%s", bm.Name, b.Name), b.Bit); err != nil {
+				return
+			}
+			if err = validateConformance(bm.Name+"."+b.Name, b.Conformance); err != nil {
+				return
+			}
+		}
+	}
+	for _, e := range def.Enums {
+		if err = validateDataTypeName(e.Name, "Enum"); err != nil {
+			return
+		}
+		if dt := types.ParseDataType(e.Type, false); dt == nil || !dt.IsEnum() {
+			return fmt.Errorf("enum %s has invalid type %q", e.Name, e.Type)
+		}
+		for _, v := range e.Values {
+			if err = validateNumber(fmt.Sprintf("value of %s.%s", e.Name, v.Name), v.Value); err != nil {
+				return
+			}
+			if err = validateConformance(e.Name+"."+v.Name, v.Conformance); err != nil {
+				return
+			}
+		}
+	}
+	for _, s := range def.Structs {
+		if err = validateDataTypeName(s.Name, "Struct"); err != nil {
+			return
+		}
+		if err = validateFields(s.Name, s.Fields, types.EntityTypeStructField); err != nil {
+			return
+		}
+	}
+	if err = validateFields(def.Name, def.Attributes, types.EntityTypeAttribute); err != nil {
+		return
+	}
+	for _, c := range def.Commands {
+		if err = validateNumber("ID of command "+c.Name, c.ID); err != nil {
+			return
+		}
+		if err = validateAccess(c.Name, c.Access, types.EntityTypeCommand); err != nil {
+			return
+		}
+		if err = validateConformance(c.Name, c.Conformance); err != nil {
+			return
+		}
+		if err = validateFields(c.Name, c.Fields, types.EntityTypeCommandField); err != nil {
+			return
+		}
+	}
+	for _, e := range def.Events {
+		if err = validateNumber("ID of event "+e.Name, e.ID); err != nil {
+			return
+		}
+		if err = validateAccess(e.Name, e.Access, types.EntityTypeEvent); err != nil {
+			return
+		}
+		if err = validateConformance(e.Name, e.Conformance); err != nil {
+			return
+		}
+		if err = validateFields(e.Name, e.Fields, types.EntityTypeEventField); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func validateFields(parent string, fields []FieldDefinition, entityType types.EntityType) (err error) {
+	for _, f := range fields {
+		name := parent + "." + f.Name
+		if f.Name == "" {
+			return fmt.Errorf("field with ID %s in %s is missing its name", f.ID, parent)
+		}
+		if err = validateNumber("ID of "+name, f.ID); err != nil {
+			return
+		}
+		if f.Type == "" {
+			return fmt.Errorf("%s is missing its type", name)
+		}
+		if f.Constraint != "" {
+			if _, err = constraint.ParseString(f.Constraint); err != nil {
+				return fmt.Errorf("%s has invalid constraint %q: %w", name, f.Constraint, err)
+			}
+		}
+		if err = validateAccess(name, f.Access, entityType); err != nil {
+			return
+		}
+		if err = validateConformance(name, f.Conformance); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func validateNumber(name string, value string) error {
+	if !matter.ParseNumber(value).Valid() {
+		return fmt.Errorf("invalid %s: %q", name, value)
+	}
+	return nil
+}
+
+func validateDataTypeName(name string, suffix string) error {
+	if !strings.HasSuffix(name, suffix) {
+		return fmt.Errorf("%s name %q must end in %s", strings.ToLower(suffix), name, suffix)
+	}
+	return nil
+}
+
+func validateConformance(name string, value string) error {
+	if value == "" {
+		return nil
+	}
+	for _, c := range conformance.ParseConformance(value) {
+		if _, ok := c.(*conformance.Generic); ok {
+			return fmt.Errorf("%s has invalid conformance %q", name, value)
+		}
+	}
+	return nil
+}
+
+func validateAccess(name string, value string, entityType types.EntityType) error {
+	if value == "" {
+		return nil
+	}
+	if _, parsed := spec.ParseAccess(value, entityType); !parsed {
+		return fmt.Errorf("%s has invalid access %q", name, value)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package scaffold
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDefinitionCluster(t *testing.T) {
+	def, err := ReadDefinition(filepath.Join("testdata", "WidgetControl.yaml"))
+	if err != nil {
+		t.Fatalf("failed reading definition: %v", err)
+	}
+	c, err := def.Cluster(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed reading cluster: %v", err)
+	}
+	if c.Name != "Widget Control" || c.ID.HexString() != "0xFFF4" || c.PICS != "WIDGETCONTROL" {
+		t.Errorf("unexpected cluster %s %s %s", c.Name, c.ID.HexString(), c.PICS)
+	}
+	if c.Features == nil || len(c.Features.Bits) != 1 {
+		t.Errorf("expected 1 feature")
+	}
+	if len(c.Bitmaps) != 1 || len(c.Enums) != 1 || len(c.Structs) != 1 {
+		t.Errorf("expected 1 bitmap, enum and struct; got %d, %d and %d", len(c.Bitmaps), len(c.Enums), len(c.Structs))
+	}
+	if len(c.Attributes) != 4 {
+		t.Fatalf("expected 4 attributes; got %d", len(c.Attributes))
+	}
+	history := c.Attributes[2]
+	if history.Name != "History" || !history.Type.IsArray() || history.Type.EntryType.Name != "WidgetStateStruct" {
+		t.Errorf("unexpected History attribute type %v", history.Type)
+	}
+	if s := c.Attributes[3].Conformance.ASCIIDocString(); s != "SPD, O" {
+		t.Errorf("unexpected Flags conformance %q", s)
+	}
+	if len(c.Commands) != 1 || len(c.Commands[0].Fields) != 1 {
+		t.Errorf("expected 1 command with 1 field")
+	}
+	if len(c.Events) != 1 || len(c.Events[0].Fields) != 1 {
+		t.Errorf("expected 1 event with 1 field")
+	}
+}
+
+func TestDefinitionEmptyCluster(t *testing.T) {
+	def := &ClusterDefinition{Name: "Sprocket", ID: "0xFFF5"}
+	def.setDefaults()
+	c, err := def.Cluster(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed reading cluster: %v", err)
+	}
+	if c.Name != "Sprocket" || len(c.Attributes) != 0 || len(c.Commands) != 0 || len(c.Events) != 0 {
+		t.Errorf("unexpected cluster %s with %d attributes, %d commands and %d events", c.Name, len(c.Attributes), len(c.Commands), len(c.Events))
+	}
+	if c.Features != nil && len(c.Features.Bits) != 0 {
+		t.Errorf("expected no features; got %d", len(c.Features.Bits))
+	}
+}
+
+func TestDefinitionValidation(t *testing.T) {
+	def := &ClusterDefinition{Name: "Widget", ID: "0xFFF4", Enums: []EnumDefinition{{Name: "WidgetMode"}}}
+	def.setDefaults()
+	if err := def.validate(); err == nil {
+		t.Errorf("expected error for enum without Enum suffix")
+	}
+	def = &ClusterDefinition{Name: "Widget", ID: "0xFFF4", Attributes: []FieldDefinition{{ID: "0", Name: "Mode", Type: "uint8", Conformance: "M |"}}}
+	def.setDefaults()
+	if err := def.validate(); err == nil {
+		t.Errorf("expected error for invalid conformance")
+	}
+}
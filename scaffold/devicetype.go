@@ -0,0 +1,46 @@
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+)
+
+type DeviceTypeSettings struct {
+	Name     string
+	ID       *matter.Number
+	Superset string
+	Class    string
+	Scope    string
+}
+
+var deviceTypeHeader = `[[ref_%sDeviceType]]
+= %s
+
+This defines conformance for the %s device type.
+
+== Revision History
+The device type revision SHALL be the highest revision number in the table below.
+
+`
+
+// DeviceType returns the AsciiDoc for a new device type with no cluster requirements
+func DeviceType(settings DeviceTypeSettings) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(deviceTypeHeader, entityName(settings.Name), settings.Name, settings.Name))
+	writeTable(&b, []string{matter.TableColumnNames[matter.TableColumnRevision], matter.TableColumnNames[matter.TableColumnDescription]}, []string{"1", "Initial revision"})
+
+	b.WriteString("\n== Classification\n\n")
+	writeTable(&b, columnNames(matter.TableTypeDeviceTypeClassification), []string{settings.ID.HexString(), settings.Name, settings.Superset, settings.Class, settings.Scope})
+
+	b.WriteString("\n== Conditions\n\n")
+	writeTable(&b, []string{matter.TableColumnNames[matter.TableColumnCondition], matter.TableColumnNames[matter.TableColumnDescription]})
+
+	b.WriteString("\n== Cluster Requirements\nEach endpoint supporting this device type SHALL include these clusters based on the conformance defined below.\n\n")
+	writeTable(&b, columnNames(matter.TableTypeClusterRequirements))
+
+	b.WriteString("\n== Element Requirements\nBelow list qualities and conformance that override the cluster specification requirements.\n\n")
+	writeTable(&b, columnNames(matter.TableTypeElementRequirements))
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package scaffold
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+// Path returns where the definition's document lives in the spec
+func (def *ClusterDefinition) Path(specRoot string) string {
+	return filepath.Join(specRoot, "src", "app_clusters", entityName(def.Name)+".adoc")
+}
+
+// Doc renders the definition and parses it as though it were the cluster's document in the spec, so it can be
+// built and generated from alongside the rest of the spec
+func (def *ClusterDefinition) Doc(specRoot string, attributes ...asciidoc.AttributeName) (*spec.Doc, error) {
+	specRoot, err := filepath.Abs(specRoot)
+	if err != nil {
+		return nil, err
+	}
+	path, err := spec.NewSpecPath(def.Path(specRoot), specRoot)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := spec.ParseReader(strings.NewReader(Cluster(def)), path, specRoot, attributes...)
+	if err != nil {
+		return nil, err
+	}
+	doc.Domain = def.domain()
+	return doc, nil
+}
+
+// Cluster returns the cluster the definition describes, read the same way it would be from its spec document
+func (def *ClusterDefinition) Cluster(specRoot string) (*matter.Cluster, error) {
+	doc, err := def.Doc(specRoot)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := doc.Entities()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entities {
+		if c, ok := e.(*matter.Cluster); ok {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("no cluster found in definition for %s", def.Name)
+}
+
+// ParseDefinitions reads the cluster definitions at the given paths and parses the documents they render to, keyed
+// by where those documents would be in the spec; a definition replaces any document already at that path
+func ParseDefinitions(specRoot string, paths []string, attributes []asciidoc.AttributeName) (docs []*pipeline.Data[*spec.Doc], err error) {
+	for _, p := range paths {
+		var def *ClusterDefinition
+		def, err = ReadDefinition(p)
+		if err != nil {
+			return
+		}
+		var doc *spec.Doc
+		doc, err = def.Doc(specRoot, attributes...)
+		if err != nil {
+			return
+		}
+		docs = append(docs, pipeline.NewData(def.Path(specRoot), doc))
+	}
+	return
+}
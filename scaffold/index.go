@@ -0,0 +1,101 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/zap"
+)
+
+var indexTitlePattern = regexp.MustCompile(`^=+\s+(.+?)\s*$`)
+var includePattern = regexp.MustCompile(`^include::([^\[]+)\[([^\]]*)\]\s*$`)
+
+// FindIndex returns the path of the index doc in dir whose title names the given domain
+func FindIndex(dir string, domain matter.Domain) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || filepath.Ext(name) != ".adoc" || !unicode.IsLower([]rune(name)[0]) {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		var b []byte
+		b, err = os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		for _, line := range strings.Split(string(b), "\n") {
+			match := indexTitlePattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			title := strings.TrimSuffix(match[1], " Device Types")
+			if zap.StringToDomain(title) == domain {
+				return path, nil
+			}
+			break
+		}
+	}
+	return "", fmt.Errorf("no index for domain %s found in %s", matter.DomainNames[domain], dir)
+}
+
+// Register returns the contents of the index doc at indexPath with an include for docPath added after its last include;
+// changed is false if the index already includes the doc
+func Register(indexPath string, docPath string) (content string, changed bool, err error) {
+	var b []byte
+	b, err = os.ReadFile(indexPath)
+	if err != nil {
+		return
+	}
+	content = string(b)
+	lines := strings.Split(content, "\n")
+
+	var rel string
+	rel, err = filepath.Rel(filepath.Dir(indexPath), docPath)
+	if err != nil {
+		return
+	}
+	rel = filepath.ToSlash(rel)
+
+	last := -1
+	var attributes string
+	for i, line := range lines {
+		match := includePattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if filepath.Base(match[1]) == filepath.Base(docPath) {
+			return
+		}
+		last = i
+		attributes = match[2]
+		if strings.HasPrefix(match[1], "./") {
+			rel = "./" + strings.TrimPrefix(rel, "./")
+		}
+	}
+
+	include := fmt.Sprintf("include::%s[%s]", rel, attributes)
+	var inserted []string
+	if last < 0 {
+		inserted = append(strings.Split(strings.TrimRight(content, "\n"), "\n"), "", include, "")
+	} else {
+		inserted = append(inserted, lines[:last+1]...)
+		if last > 0 && strings.TrimSpace(lines[last-1]) == "" {
+			// Keep the blank line that separates the existing includes
+			inserted = append(inserted, "")
+		}
+		inserted = append(inserted, include)
+		inserted = append(inserted, lines[last+1:]...)
+	}
+	content = strings.Join(inserted, "\n")
+	changed = true
+	return
+}
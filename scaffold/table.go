@@ -0,0 +1,74 @@
+package scaffold
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/project-chip/alchemy/matter"
+)
+
+// columnNames returns the header of a table in the order disco expects; any extra table types supply column renames
+func columnNames(tableType matter.TableType, renames ...matter.TableType) (names []string) {
+	for _, c := range matter.Tables[tableType].ColumnOrder {
+		if c == matter.TableColumnContext {
+			// Context is a legacy name for Scope
+			continue
+		}
+		for _, r := range renames {
+			if renamed, ok := matter.Tables[r].ColumnNames[c]; ok {
+				c = renamed
+			}
+		}
+		names = append(names, matter.TableColumnNames[c])
+	}
+	return
+}
+
+// writeTable writes a table with the given header and rows; a table with no rows is left out, since a table with only a header isn't valid
+func writeTable(b *strings.Builder, header []string, rows ...[]string) {
+	if len(rows) == 0 {
+		return
+	}
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			// Pipes in conformance and the like would otherwise end the cell
+			row[i] = strings.ReplaceAll(strings.ReplaceAll(cell, `\|`, "|"), "|", `\|`)
+			cell = row[i]
+			if i < len(widths) {
+				widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+			}
+		}
+	}
+	b.WriteString("[options=\"header\",valign=\"middle\"]\n|===\n")
+	writeRow(b, widths, header)
+	for _, row := range rows {
+		writeRow(b, widths, row)
+	}
+	b.WriteString("|===\n")
+}
+
+func writeRow(b *strings.Builder, widths []int, row []string) {
+	var line strings.Builder
+	for i, w := range widths {
+		var cell string
+		if i < len(row) {
+			cell = row[i]
+		}
+		if i > 0 {
+			line.WriteString(" ")
+		}
+		line.WriteString("| ")
+		line.WriteString(cell)
+		line.WriteString(strings.Repeat(" ", w-utf8.RuneCountInString(cell)))
+	}
+	b.WriteString(strings.TrimRight(line.String(), " "))
+	b.WriteString("\n")
+}
+
+func entityName(name string) string {
+	return strings.Join(strings.Fields(name), "")
+}
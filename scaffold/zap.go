@@ -0,0 +1,169 @@
+package scaffold
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// ZAPDefinition converts a cluster read from a ZAP template into a cluster definition, so it can be rendered as a
+// spec document; ZAP templates carry no prose, so descriptions are only as good as the template's
+func ZAPDefinition(c *matter.Cluster) *ClusterDefinition {
+	def := &ClusterDefinition{
+		Name:        c.Name,
+		ID:          c.ID.HexString(),
+		Description: strings.Join(strings.Fields(c.Description), " "),
+	}
+	if c.Features != nil {
+		codes := make(map[string]struct{})
+		for _, b := range c.Features.Bits {
+			var code string
+			if f, ok := b.(*matter.Feature); ok && f.Code != "" {
+				code = f.Code
+				codes[code] = struct{}{}
+			} else {
+				code = featureCode(b.Name(), codes)
+			}
+			def.Features = append(def.Features, FeatureDefinition{
+				Bit:         b.Bit(),
+				Code:        code,
+				Name:        b.Name(),
+				Summary:     b.Summary(),
+				Conformance: b.Conformance().ASCIIDocString(),
+			})
+		}
+	}
+	for _, bm := range c.Bitmaps {
+		bd := BitmapDefinition{Name: bm.Name, Type: dataTypeName(bm.Type), Description: bm.Description}
+		for _, b := range bm.Bits {
+			bd.Bits = append(bd.Bits, BitDefinition{Bit: b.Bit(), Name: b.Name(), Summary: b.Summary(), Conformance: b.Conformance().ASCIIDocString()})
+		}
+		def.Bitmaps = append(def.Bitmaps, bd)
+	}
+	for _, e := range c.Enums {
+		ed := EnumDefinition{Name: e.Name, Type: dataTypeName(e.Type), Description: e.Description}
+		for _, v := range e.Values {
+			ed.Values = append(ed.Values, ValueDefinition{Value: v.Value.IntString(), Name: v.Name, Summary: v.Summary, Conformance: v.Conformance.ASCIIDocString()})
+		}
+		def.Enums = append(def.Enums, ed)
+	}
+	for _, s := range c.Structs {
+		def.Structs = append(def.Structs, StructDefinition{Name: s.Name, Description: s.Description, Fields: zapFields(s.Fields, types.EntityTypeStructField)})
+	}
+	def.Attributes = zapFields(c.Attributes, types.EntityTypeAttribute)
+	for _, cmd := range c.Commands {
+		cd := CommandDefinition{
+			ID:          cmd.ID.HexString(),
+			Name:        cmd.Name,
+			Description: strings.Join(strings.Fields(cmd.Description), " "),
+			Access:      spec.AccessToASCIIDocString(cmd.Access, types.EntityTypeCommand),
+			Conformance: cmd.Conformance.ASCIIDocString(),
+			Fields:      zapFields(cmd.Fields, types.EntityTypeCommandField),
+		}
+		switch cmd.Direction {
+		case matter.InterfaceServer:
+			cd.Direction = "client => server"
+		case matter.InterfaceClient:
+			cd.Direction = "server => client"
+		}
+		if cmd.Response != nil {
+			cd.Response = cmd.Response.Name
+		}
+		def.Commands = append(def.Commands, cd)
+	}
+	for _, e := range c.Events {
+		access := e.Access
+		if access.Read == matter.PrivilegeUnknown {
+			access.Read = matter.PrivilegeView
+		}
+		priority := strings.ToLower(e.Priority)
+		if priority != "" {
+			priority = strings.ToUpper(priority[:1]) + priority[1:]
+		}
+		def.Events = append(def.Events, EventDefinition{
+			ID:          e.ID.HexString(),
+			Name:        e.Name,
+			Description: strings.Join(strings.Fields(e.Description), " "),
+			Priority:    priority,
+			Access:      spec.AccessToASCIIDocString(access, types.EntityTypeEvent),
+			Conformance: e.Conformance.ASCIIDocString(),
+			Fields:      zapFields(e.Fields, types.EntityTypeEventField),
+		})
+	}
+	return def
+}
+
+func zapFields(fs matter.FieldSet, entityType types.EntityType) (fields []FieldDefinition) {
+	for i, f := range fs {
+		fd := FieldDefinition{
+			ID:          f.ID.HexString(),
+			Name:        f.Name,
+			Type:        dataTypeName(f.Type),
+			Default:     f.Default,
+			Conformance: f.Conformance.ASCIIDocString(),
+		}
+		if !f.ID.Valid() {
+			// Some older templates leave struct fields unnumbered, in which case their order is their ID
+			fd.ID = strconv.Itoa(i)
+		}
+		// ZAP puts min and max on enums and bitmaps as well, which the spec leaves to the type itself
+		if f.Constraint != nil && (f.Type == nil || f.Type.BaseType != types.BaseDataTypeCustom) {
+			fd.Constraint = f.Constraint.ASCIIDocString(f.Type)
+		}
+		// ZAP marks nearly everything reportable, which says nothing the spec would, so nullability is the only
+		// quality worth carrying over
+		if f.Quality.Has(matter.QualityNullable) {
+			fd.Quality = "X"
+		}
+		if entityType == types.EntityTypeAttribute {
+			fd.Access = spec.AccessToASCIIDocString(f.Access, entityType)
+		} else {
+			// Fields only ever declare fabric sensitivity, which is all a struct's access renders
+			fd.Access = spec.AccessToASCIIDocString(f.Access, types.EntityTypeStruct)
+		}
+		fields = append(fields, fd)
+	}
+	return
+}
+
+func dataTypeName(dt *types.DataType) string {
+	if dt == nil {
+		return ""
+	}
+	if dt.IsArray() {
+		return "list[" + dataTypeName(dt.EntryType) + "]"
+	}
+	switch dt.BaseType {
+	case types.BaseDataTypeCustom, types.BaseDataTypeUnknown:
+		return dt.Name
+	}
+	return types.BaseDataTypeName(dt.BaseType)
+}
+
+// featureCode makes up a code for a feature, since older ZAP templates don't have them: the capitals of its name, or its
+// first letters if it has fewer than two, lengthened until no other feature in the cluster has it
+func featureCode(name string, codes map[string]struct{}) string {
+	var code strings.Builder
+	for _, r := range name {
+		if unicode.IsUpper(r) || unicode.IsDigit(r) {
+			code.WriteRune(r)
+		}
+	}
+	letters := []rune(strings.ToUpper(strings.Join(strings.Fields(name), "")))
+	c := code.String()
+	if len(c) < 2 && len(letters) >= 2 {
+		c = string(letters[:2])
+	}
+	for i := len(c); i < len(letters); i++ {
+		if _, ok := codes[c]; !ok {
+			break
+		}
+		c += string(letters[i])
+	}
+	codes[c] = struct{}{}
+	return c
+}
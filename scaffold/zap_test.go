@@ -0,0 +1,71 @@
+package scaffold
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/zap/parse"
+)
+
+func TestZAPDefinition(t *testing.T) {
+	path := filepath.Join("testdata", "widget-control-cluster.xml")
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed reading ZAP template: %v", err)
+	}
+	zapParser := parse.NewZapParser()
+	outputs, _, err := zapParser.Process(context.Background(), pipeline.NewData(path, b), 0, 1)
+	if err != nil {
+		t.Fatalf("failed parsing ZAP template: %v", err)
+	}
+	if len(outputs) != 1 || len(outputs[0].Content) != 1 {
+		t.Fatalf("expected 1 cluster")
+	}
+	zc, ok := outputs[0].Content[0].(*matter.Cluster)
+	if !ok {
+		t.Fatalf("expected cluster; got %T", outputs[0].Content[0])
+	}
+
+	def := ZAPDefinition(zc)
+	def.setDefaults()
+	if err = def.validate(); err != nil {
+		t.Fatalf("invalid definition: %v", err)
+	}
+	c, err := def.Cluster(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed reading cluster: %v", err)
+	}
+	if c.Name != "Widget Control" || c.ID.HexString() != "0xFFF4" {
+		t.Errorf("unexpected cluster %s %s", c.Name, c.ID.HexString())
+	}
+	if c.Features == nil || len(c.Features.Bits) != 1 {
+		t.Fatalf("expected 1 feature")
+	}
+	if f, ok := c.Features.Bits[0].(*matter.Feature); !ok || f.Code != "SPD" || f.Conformance().ASCIIDocString() != "O" {
+		t.Errorf("unexpected feature %v", c.Features.Bits[0])
+	}
+	if len(c.Bitmaps) != 1 || len(c.Enums) != 1 || len(c.Structs) != 1 {
+		t.Errorf("expected 1 bitmap, enum and struct; got %d, %d and %d", len(c.Bitmaps), len(c.Enums), len(c.Structs))
+	}
+	if len(c.Attributes) != 4 {
+		t.Fatalf("expected 4 attributes; got %d", len(c.Attributes))
+	}
+	speed := c.Attributes[1]
+	if speed.Name != "Speed" || !speed.Quality.Has(matter.QualityNullable) || speed.Access.Write != matter.PrivilegeOperate || speed.Conformance.ASCIIDocString() != "O" {
+		t.Errorf("unexpected Speed attribute %s %s %s", speed.Quality, speed.Access, speed.Conformance.ASCIIDocString())
+	}
+	history := c.Attributes[2]
+	if !history.Type.IsArray() || history.Type.EntryType.Name != "WidgetStateStruct" {
+		t.Errorf("unexpected History attribute type %v", history.Type)
+	}
+	if len(c.Commands) != 1 || len(c.Commands[0].Fields) != 1 {
+		t.Errorf("expected 1 command with 1 field")
+	}
+	if len(c.Events) != 1 || c.Events[0].Priority != "Info" || c.Events[0].Access.Read != matter.PrivilegeView {
+		t.Errorf("expected 1 info event readable with view")
+	}
+}
@@ -0,0 +1,229 @@
+package site
+
+import (
+	"html"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func (g *Generator) renderCluster(c *matter.Cluster) string {
+	p := newPage(c.Name)
+	p.paragraph("description", c.Description)
+
+	p.startTable("", "ID", "Hierarchy", "Role", "Scope", "PICS Code")
+	hierarchy := html.EscapeString(c.Hierarchy)
+	if c.Parent != nil {
+		hierarchy = g.links.anchor(c.Parent, c.Hierarchy)
+	}
+	p.row(html.EscapeString(idString(c.ID)), hierarchy, html.EscapeString(c.Role), html.EscapeString(c.Scope), html.EscapeString(c.PICS))
+	p.endTable()
+
+	if len(c.Revisions) > 0 {
+		p.heading(2, "revision-history", "Revision History")
+		p.startTable("", "Revision", "Description")
+		for _, r := range c.Revisions {
+			p.row(html.EscapeString(r.Number), html.EscapeString(r.Description))
+		}
+		p.endTable()
+	}
+
+	if c.Features != nil && len(c.Features.Bits) > 0 {
+		p.heading(2, "features", "Features")
+		p.startTable("", "Bit", "Code", "Feature", "Conformance", "Summary")
+		for _, b := range c.Features.Bits {
+			f, ok := b.(*matter.Feature)
+			if !ok {
+				continue
+			}
+			p.WriteString("<tr id=\"" + entityAnchor(f) + "\">")
+			p.WriteString("<td>" + html.EscapeString(f.Bit()) + "</td><td>" + html.EscapeString(f.Code) + "</td><td>" + html.EscapeString(f.Name()) + "</td><td>" + conformanceString(f.Conformance()) + "</td><td>" + html.EscapeString(f.Summary()) + "</td>")
+			p.WriteString("</tr>\n")
+		}
+		p.endTable()
+	}
+
+	g.renderDataTypes(p, c, &c.AssociatedDataTypes)
+
+	if len(c.Attributes) > 0 {
+		p.heading(2, "attributes", "Attributes")
+		g.renderFields(p, c.Attributes, types.EntityTypeAttribute, true)
+	}
+
+	if len(c.Commands) > 0 {
+		p.heading(2, "commands", "Commands")
+		p.startTable("", "ID", "Name", "Direction", "Response", "Access", "Conformance")
+		for _, cmd := range c.Commands {
+			response := g.links.dataType(cmd.Response)
+			if cmd.Response != nil && cmd.Response.Entity == nil {
+				// Responses are usually other commands on the same cluster
+				for _, rc := range c.Commands {
+					if rc.Name == cmd.Response.Name {
+						response = g.links.anchor(rc, rc.Name)
+						break
+					}
+				}
+			}
+			p.row(html.EscapeString(idString(cmd.ID)), g.links.anchor(cmd, cmd.Name), html.EscapeString(cmd.Direction.String()), response, html.EscapeString(spec.AccessToASCIIDocString(cmd.Access, types.EntityTypeCommand)), conformanceString(cmd.Conformance))
+		}
+		p.endTable()
+		for _, cmd := range c.Commands {
+			p.heading(3, entityAnchor(cmd), cmd.Name+" Command")
+			p.paragraph("description", cmd.Description)
+			if len(cmd.Fields) > 0 {
+				g.renderFields(p, cmd.Fields, types.EntityTypeCommandField, false)
+			}
+		}
+	}
+
+	if len(c.Events) > 0 {
+		p.heading(2, "events", "Events")
+		p.startTable("", "ID", "Name", "Priority", "Access", "Conformance")
+		for _, e := range c.Events {
+			p.row(html.EscapeString(idString(e.ID)), g.links.anchor(e, e.Name), html.EscapeString(e.Priority), html.EscapeString(spec.AccessToASCIIDocString(e.Access, types.EntityTypeEvent)), conformanceString(e.Conformance))
+		}
+		p.endTable()
+		for _, e := range c.Events {
+			p.heading(3, entityAnchor(e), e.Name+" Event")
+			p.paragraph("description", e.Description)
+			if len(e.Fields) > 0 {
+				g.renderFields(p, e.Fields, types.EntityTypeEventField, false)
+			}
+		}
+	}
+	return p.String()
+}
+
+func (g *Generator) renderFields(p *page, fields matter.FieldSet, entityType types.EntityType, anchored bool) {
+	p.startTable("", "ID", "Name", "Type", "Constraint", "Quality", "Default", "Access", "Conformance")
+	for _, f := range fields {
+		if anchored {
+			p.WriteString("<tr id=\"" + entityAnchor(f) + "\">")
+		} else {
+			p.WriteString("<tr>")
+		}
+		cells := []string{
+			html.EscapeString(idString(f.ID)),
+			html.EscapeString(f.Name),
+			g.links.dataType(f.Type),
+			constraintString(f.Constraint, f.Type),
+			html.EscapeString(qualityString(f.Quality)),
+			html.EscapeString(f.Default),
+			html.EscapeString(spec.AccessToASCIIDocString(f.Access, entityType)),
+			conformanceString(f.Conformance),
+		}
+		for _, c := range cells {
+			p.WriteString("<td>" + c + "</td>")
+		}
+		p.WriteString("</tr>\n")
+	}
+	p.endTable()
+}
+
+func (g *Generator) renderDataTypes(p *page, owner *matter.Cluster, adt *matter.AssociatedDataTypes) {
+	if len(adt.Bitmaps) == 0 && len(adt.Enums) == 0 && len(adt.Structs) == 0 && len(adt.TypeDefs) == 0 {
+		return
+	}
+	p.heading(2, "data-types", "Data Types")
+	for _, bm := range adt.Bitmaps {
+		g.renderBitmap(p, owner, bm)
+	}
+	for _, e := range adt.Enums {
+		g.renderEnum(p, owner, e)
+	}
+	for _, s := range adt.Structs {
+		g.renderStruct(p, owner, s)
+	}
+	for _, td := range adt.TypeDefs {
+		g.renderTypeDef(p, owner, td)
+	}
+}
+
+func (g *Generator) renderBitmap(p *page, owner *matter.Cluster, bm *matter.Bitmap) {
+	p.heading(3, entityAnchor(bm), bm.Name)
+	g.renderDataTypeHeader(p, owner, bm, bm.Description, bm.Type)
+	p.startTable("", "Bit", "Name", "Summary", "Conformance")
+	for _, b := range bm.Bits {
+		p.row(html.EscapeString(b.Bit()), html.EscapeString(b.Name()), html.EscapeString(b.Summary()), conformanceString(b.Conformance()))
+	}
+	p.endTable()
+}
+
+func (g *Generator) renderEnum(p *page, owner *matter.Cluster, e *matter.Enum) {
+	p.heading(3, entityAnchor(e), e.Name)
+	g.renderDataTypeHeader(p, owner, e, e.Description, e.Type)
+	p.startTable("", "Value", "Name", "Summary", "Conformance")
+	for _, v := range e.Values {
+		p.row(html.EscapeString(idString(v.Value)), html.EscapeString(v.Name), html.EscapeString(v.Summary), conformanceString(v.Conformance))
+	}
+	p.endTable()
+}
+
+func (g *Generator) renderStruct(p *page, owner *matter.Cluster, s *matter.Struct) {
+	p.heading(3, entityAnchor(s), s.Name)
+	g.renderDataTypeHeader(p, owner, s, s.Description, nil)
+	g.renderFields(p, s.Fields, types.EntityTypeStructField, false)
+}
+
+func (g *Generator) renderTypeDef(p *page, owner *matter.Cluster, td *matter.TypeDef) {
+	p.heading(3, entityAnchor(td), td.Name)
+	g.renderDataTypeHeader(p, owner, td, td.Description, td.Type)
+}
+
+// renderDataTypeHeader writes a data type's description, base type and the other clusters that share it
+func (g *Generator) renderDataTypeHeader(p *page, owner *matter.Cluster, e types.Entity, description string, dataType *types.DataType) {
+	p.paragraph("description", description)
+	if dataType != nil {
+		p.WriteString("<p>Derived from " + g.links.dataType(dataType) + ".</p>\n")
+	}
+	if href, ok := g.links[e]; ok && owner != nil && !strings.HasPrefix(href, clusterPageName(owner)+"#") {
+		p.WriteString("<p class=\"refs\">Defined in " + g.links.anchor(e, "another document") + ".</p>\n")
+	}
+	refs := g.sharedClusters(owner, e)
+	if len(refs) > 0 {
+		p.WriteString("<p class=\"refs\">Also used by ")
+		for i, c := range refs {
+			if i > 0 {
+				p.WriteString(", ")
+			}
+			p.WriteString(g.links.anchor(c, c.Name))
+		}
+		p.WriteString(".</p>\n")
+	}
+}
+
+// sharedClusters returns the clusters other than owner that reference the entity
+func (g *Generator) sharedClusters(owner *matter.Cluster, e types.Entity) (clusters []*matter.Cluster) {
+	refs, ok := g.spec.ClusterRefs.Get(e)
+	if !ok {
+		return
+	}
+	for c := range refs {
+		if c != owner {
+			clusters = append(clusters, c)
+		}
+	}
+	slices.SortStableFunc(clusters, func(a, b *matter.Cluster) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return
+}
+
+func conformanceString(c conformance.Set) string {
+	if len(c) == 0 {
+		return ""
+	}
+	return html.EscapeString(c.ASCIIDocString())
+}
+
+func constraintString(c constraint.Constraint, dataType *types.DataType) string {
+	if c == nil {
+		return ""
+	}
+	return html.EscapeString(c.ASCIIDocString(dataType))
+}
@@ -0,0 +1,181 @@
+package site
+
+import (
+	"html"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+func (g *Generator) renderDeviceType(dt *matter.DeviceType) string {
+	p := newPage(dt.Name)
+	p.paragraph("description", dt.Description)
+
+	p.startTable("", "ID", "Class", "Scope", "Superset")
+	p.row(html.EscapeString(idString(dt.ID)), html.EscapeString(dt.Class), html.EscapeString(dt.Scope), html.EscapeString(dt.Superset))
+	p.endTable()
+
+	if len(dt.Revisions) > 0 {
+		p.heading(2, "revision-history", "Revision History")
+		p.startTable("", "Revision", "Description")
+		for _, r := range dt.Revisions {
+			p.row(html.EscapeString(r.Number), html.EscapeString(r.Description))
+		}
+		p.endTable()
+	}
+
+	if len(dt.Conditions) > 0 {
+		p.heading(2, "conditions", "Conditions")
+		p.startTable("", "Condition", "Description")
+		for _, c := range dt.Conditions {
+			p.row(html.EscapeString(c.Feature), html.EscapeString(c.Description))
+		}
+		p.endTable()
+	}
+
+	if len(dt.ClusterRequirements) > 0 {
+		p.heading(2, "cluster-requirements", "Cluster Requirements")
+		p.startTable("", "ID", "Cluster", "Client/Server", "Quality", "Conformance")
+		for _, cr := range dt.ClusterRequirements {
+			p.row(html.EscapeString(idString(cr.ClusterID)), g.clusterLink(cr.Cluster, cr.ClusterName), html.EscapeString(cr.Interface.String()), html.EscapeString(qualityString(cr.Quality)), conformanceString(cr.Conformance))
+		}
+		p.endTable()
+	}
+
+	if len(dt.ElementRequirements) > 0 {
+		p.heading(2, "element-requirements", "Element Requirements")
+		g.renderElementRequirements(p, dt.ElementRequirements)
+	}
+
+	if len(dt.ComposedDeviceTypeRequirements) > 0 {
+		p.heading(2, "composed-device-type-requirements", "Composed Device Type Requirements")
+		p.startTable("", "Device Type ID", "Device Type", "Cluster", "Element", "Name", "Field", "Constraint", "Quality", "Access", "Conformance")
+		for _, cdr := range dt.ComposedDeviceTypeRequirements {
+			er := cdr.ElementRequirement
+			p.row(html.EscapeString(idString(cdr.DeviceTypeID)), g.deviceTypeLink(cdr.DeviceTypeName), g.clusterLink(er.Cluster, er.ClusterName), html.EscapeString(er.Element.String()), g.elementLink(er), html.EscapeString(er.Field), constraintString(er.Constraint, nil), html.EscapeString(qualityString(er.Quality)), html.EscapeString(spec.AccessToASCIIDocString(er.Access, er.Element)), conformanceString(er.Conformance))
+		}
+		p.endTable()
+	}
+	return p.String()
+}
+
+func (g *Generator) renderElementRequirements(p *page, requirements []*matter.ElementRequirement) {
+	p.startTable("", "Cluster", "Element", "Name", "Field", "Constraint", "Quality", "Access", "Conformance")
+	for _, er := range requirements {
+		p.row(g.clusterLink(er.Cluster, er.ClusterName), html.EscapeString(er.Element.String()), g.elementLink(*er), html.EscapeString(er.Field), constraintString(er.Constraint, nil), html.EscapeString(qualityString(er.Quality)), html.EscapeString(spec.AccessToASCIIDocString(er.Access, er.Element)), conformanceString(er.Conformance))
+	}
+	p.endTable()
+}
+
+func (g *Generator) clusterLink(c *matter.Cluster, name string) string {
+	if c == nil {
+		if c = g.spec.ClustersByName[name]; c == nil {
+			return html.EscapeString(name)
+		}
+	}
+	return g.links.anchor(c, name)
+}
+
+func (g *Generator) deviceTypeLink(name string) string {
+	for _, dt := range g.spec.DeviceTypes {
+		if dt.Name == name {
+			return g.links.anchor(dt, name)
+		}
+	}
+	return html.EscapeString(name)
+}
+
+// elementLink links an element requirement to the attribute, command, event or feature it names
+func (g *Generator) elementLink(er matter.ElementRequirement) string {
+	c := er.Cluster
+	if c == nil {
+		c = g.spec.ClustersByName[er.ClusterName]
+	}
+	if c == nil {
+		return html.EscapeString(er.Name)
+	}
+	for _, a := range c.Attributes {
+		if a.Name == er.Name {
+			return g.links.anchor(a, er.Name)
+		}
+	}
+	for _, cmd := range c.Commands {
+		if cmd.Name == er.Name {
+			return g.links.anchor(cmd, er.Name)
+		}
+	}
+	for _, e := range c.Events {
+		if e.Name == er.Name {
+			return g.links.anchor(e, er.Name)
+		}
+	}
+	if c.Features != nil {
+		for _, b := range c.Features.Bits {
+			if f, ok := b.(*matter.Feature); ok && (f.Code == er.Name || f.Name() == er.Name) {
+				return g.links.anchor(f, er.Name)
+			}
+		}
+	}
+	return html.EscapeString(er.Name)
+}
+
+func (g *Generator) renderDeviceTypeMatrix(deviceTypes []*matter.DeviceType, clusters []*matter.Cluster) string {
+	p := newPage("Device Type Requirements")
+	p.paragraph("description", "Each cell shows the conformance of a cluster on a device type, prefixed with S for server or C for client.")
+
+	used := make(map[*matter.Cluster]struct{})
+	for _, dt := range deviceTypes {
+		for _, cr := range dt.ClusterRequirements {
+			if c := g.requirementCluster(cr); c != nil {
+				used[c] = struct{}{}
+			}
+		}
+	}
+	var columns []*matter.Cluster
+	for _, c := range clusters {
+		if _, ok := used[c]; ok {
+			columns = append(columns, c)
+		}
+	}
+
+	p.WriteString("<table class=\"matrix\">\n<tr><th>Device Type</th>")
+	for _, c := range columns {
+		p.WriteString("<th>" + g.links.anchor(c, c.Name) + "</th>")
+	}
+	p.WriteString("</tr>\n")
+	for _, dt := range deviceTypes {
+		cells := make(map[*matter.Cluster]string)
+		for _, cr := range dt.ClusterRequirements {
+			c := g.requirementCluster(cr)
+			if c == nil {
+				continue
+			}
+			var prefix string
+			switch cr.Interface {
+			case matter.InterfaceServer:
+				prefix = "S: "
+			case matter.InterfaceClient:
+				prefix = "C: "
+			}
+			if existing, ok := cells[c]; ok {
+				cells[c] = existing + "<br>" + html.EscapeString(prefix) + conformanceString(cr.Conformance)
+			} else {
+				cells[c] = html.EscapeString(prefix) + conformanceString(cr.Conformance)
+			}
+		}
+		p.WriteString("<tr><th>" + g.links.anchor(dt, dt.Name) + "</th>")
+		for _, c := range columns {
+			p.WriteString("<td>" + cells[c] + "</td>")
+		}
+		p.WriteString("</tr>\n")
+	}
+	p.endTable()
+	return p.String()
+}
+
+func (g *Generator) requirementCluster(cr *matter.ClusterRequirement) *matter.Cluster {
+	if cr.Cluster != nil {
+		return cr.Cluster
+	}
+	return g.spec.ClustersByName[cr.ClusterName]
+}
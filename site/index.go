@@ -0,0 +1,159 @@
+package site
+
+import (
+	"encoding/json"
+	"html"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type searchEntry struct {
+	Name   string `json:"n"`
+	Kind   string `json:"k"`
+	URL    string `json:"u"`
+	Parent string `json:"p,omitempty"`
+}
+
+type searchIndex []searchEntry
+
+func (si *searchIndex) add(l links, e types.Entity, kind string, parent string) {
+	href, ok := l[e]
+	if !ok {
+		return
+	}
+	*si = append(*si, searchEntry{Name: entityName(e), Kind: kind, URL: href, Parent: parent})
+}
+
+func (si *searchIndex) addCluster(l links, c *matter.Cluster) {
+	si.add(l, c, "cluster", "")
+	if c.Features != nil {
+		for _, b := range c.Features.Bits {
+			if f, ok := b.(*matter.Feature); ok {
+				si.add(l, f, "feature", c.Name)
+			}
+		}
+	}
+	for _, a := range c.Attributes {
+		si.add(l, a, "attribute", c.Name)
+	}
+	for _, cmd := range c.Commands {
+		si.add(l, cmd, "command", c.Name)
+	}
+	for _, e := range c.Events {
+		si.add(l, e, "event", c.Name)
+	}
+	for _, bm := range c.Bitmaps {
+		si.addDataType(l, bm, c.Name)
+	}
+	for _, e := range c.Enums {
+		si.addDataType(l, e, c.Name)
+	}
+	for _, s := range c.Structs {
+		si.addDataType(l, s, c.Name)
+	}
+	for _, td := range c.TypeDefs {
+		si.addDataType(l, td, c.Name)
+	}
+}
+
+func (si *searchIndex) addDataType(l links, e types.Entity, parent string) {
+	var kind string
+	switch e.(type) {
+	case *matter.Bitmap:
+		kind = "bitmap"
+	case *matter.Enum:
+		kind = "enum"
+	case *matter.Struct:
+		kind = "struct"
+	case *matter.TypeDef:
+		kind = "typedef"
+	default:
+		return
+	}
+	si.add(l, e, kind, parent)
+}
+
+func (si *searchIndex) addDeviceType(l links, dt *matter.DeviceType) {
+	si.add(l, dt, "device type", "")
+}
+
+func (si *searchIndex) addNamespace(l links, ns *matter.Namespace) {
+	si.add(l, ns, "namespace", "")
+}
+
+const searchScript = `(function() {
+	var input = document.getElementById("search");
+	var results = document.getElementById("results");
+	input.addEventListener("input", function() {
+		var terms = input.value.toLowerCase().split(/\s+/).filter(function(t) { return t.length > 0; });
+		results.innerHTML = "";
+		if (terms.length == 0) {
+			return;
+		}
+		var count = 0;
+		for (var i = 0; i < searchIndex.length && count < 100; i++) {
+			var e = searchIndex[i];
+			var haystack = (e.n + " " + e.k + " " + (e.p || "")).toLowerCase();
+			if (!terms.every(function(t) { return haystack.indexOf(t) >= 0; })) {
+				continue;
+			}
+			var li = document.createElement("li");
+			var a = document.createElement("a");
+			a.href = e.u;
+			a.textContent = e.n;
+			li.appendChild(a);
+			var span = document.createElement("span");
+			span.textContent = e.p ? e.k + " in " + e.p : e.k;
+			li.appendChild(span);
+			results.appendChild(li);
+			count++;
+		}
+	});
+})();
+`
+
+func (g *Generator) renderIndex(clusters []*matter.Cluster, deviceTypes []*matter.DeviceType, namespaces []*matter.Namespace, index searchIndex) string {
+	p := newPage("Matter Data Model Reference")
+	p.WriteString("<input id=\"search\" type=\"search\" placeholder=\"Search clusters, attributes, commands, events, data types and device types\">\n<ul id=\"results\"></ul>\n")
+
+	p.heading(2, "clusters", "Clusters")
+	p.startTable("", "ID", "Name", "PICS Code")
+	for _, c := range clusters {
+		p.row(html.EscapeString(idString(c.ID)), g.links.anchor(c, c.Name), html.EscapeString(c.PICS))
+	}
+	p.endTable()
+
+	p.heading(2, "device-types", "Device Types")
+	p.startTable("", "ID", "Name", "Class")
+	for _, dt := range deviceTypes {
+		p.row(html.EscapeString(idString(dt.ID)), g.links.anchor(dt, dt.Name), html.EscapeString(dt.Class))
+	}
+	p.endTable()
+
+	if len(namespaces) > 0 {
+		p.heading(2, "namespaces", "Namespaces")
+		p.startTable("", "ID", "Name")
+		for _, ns := range namespaces {
+			p.row(html.EscapeString(idString(ns.ID)), g.links.anchor(ns, ns.Name))
+		}
+		p.endTable()
+	}
+
+	p.WriteString("<p>")
+	p.link(globalDataTypesPageName, "Global Data Types")
+	p.WriteString(" &middot; ")
+	p.link(deviceTypeMatrixPageName, "Device Type Requirement Matrix")
+	p.WriteString("</p>\n")
+
+	js, err := json.Marshal(index)
+	if err != nil {
+		js = []byte("[]")
+	}
+	p.WriteString("<script>\nvar searchIndex = ")
+	p.Write(js)
+	p.WriteString(";\n")
+	p.WriteString(searchScript)
+	p.WriteString("</script>\n")
+	return p.String()
+}
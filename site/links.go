@@ -0,0 +1,143 @@
+package site
+
+import (
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// links maps entities to the page and anchor where they're documented
+type links map[types.Entity]string
+
+func buildLinks(s *spec.Specification, clusters []*matter.Cluster, deviceTypes []*matter.DeviceType, namespaces []*matter.Namespace) links {
+	l := make(links)
+	for _, c := range clusters {
+		page := clusterPageName(c)
+		l[c] = page
+		if c.Features != nil {
+			for _, b := range c.Features.Bits {
+				if f, ok := b.(*matter.Feature); ok {
+					l[f] = page + "#" + entityAnchor(f)
+				}
+			}
+		}
+		for _, a := range c.Attributes {
+			l[a] = page + "#" + entityAnchor(a)
+		}
+		for _, cmd := range c.Commands {
+			l[cmd] = page + "#" + entityAnchor(cmd)
+		}
+		for _, e := range c.Events {
+			l[e] = page + "#" + entityAnchor(e)
+		}
+		l.addDataTypes(page, c, &c.AssociatedDataTypes)
+	}
+	// Data types inherited from a base cluster that isn't itself in the spec get linked to the first cluster that uses them
+	for _, c := range clusters {
+		l.addDataTypes(clusterPageName(c), nil, &c.AssociatedDataTypes)
+	}
+	for _, dt := range deviceTypes {
+		l[dt] = deviceTypePageName(dt)
+	}
+	for _, ns := range namespaces {
+		l[ns] = namespacePageName(ns)
+	}
+	for e := range s.GlobalObjects {
+		if _, ok := l[e]; ok {
+			continue
+		}
+		switch e.(type) {
+		case *matter.Bitmap, *matter.Enum, *matter.Struct, *matter.TypeDef:
+			l[e] = globalDataTypesPageName + "#" + entityAnchor(e)
+		}
+	}
+	return l
+}
+
+// restrict removes the links to pages that aren't being rendered
+func (l links) restrict(pages map[string]struct{}) {
+	for e, href := range l {
+		page, _, _ := strings.Cut(href, "#")
+		if _, ok := pages[page]; !ok {
+			delete(l, e)
+		}
+	}
+}
+
+// addDataTypes links the data types associated with a cluster; if owner is set, only the data types it defines are linked
+func (l links) addDataTypes(page string, owner *matter.Cluster, adt *matter.AssociatedDataTypes) {
+	add := func(e types.Entity, parent types.Entity) {
+		if _, ok := l[e]; ok {
+			return
+		}
+		if owner != nil && parent != nil && parent != types.Entity(owner) {
+			return
+		}
+		l[e] = page + "#" + entityAnchor(e)
+	}
+	for _, bm := range adt.Bitmaps {
+		add(bm, bm.ParentEntity)
+	}
+	for _, e := range adt.Enums {
+		add(e, e.ParentEntity)
+	}
+	for _, s := range adt.Structs {
+		add(s, s.ParentEntity)
+	}
+	for _, td := range adt.TypeDefs {
+		add(td, td.ParentEntity)
+	}
+}
+
+func entityAnchor(e types.Entity) string {
+	switch e := e.(type) {
+	case *matter.Feature:
+		return "feature-" + strcase.ToKebab(e.Code)
+	case *matter.Field:
+		return "attribute-" + strcase.ToKebab(e.Name)
+	case *matter.Command:
+		return "command-" + strcase.ToKebab(e.Name)
+	case *matter.Event:
+		return "event-" + strcase.ToKebab(e.Name)
+	case *matter.Bitmap:
+		return "bitmap-" + strcase.ToKebab(e.Name)
+	case *matter.Enum:
+		return "enum-" + strcase.ToKebab(e.Name)
+	case *matter.Struct:
+		return "struct-" + strcase.ToKebab(e.Name)
+	case *matter.TypeDef:
+		return "typedef-" + strcase.ToKebab(e.Name)
+	}
+	return ""
+}
+
+func entityName(e types.Entity) string {
+	switch e := e.(type) {
+	case *matter.Cluster:
+		return e.Name
+	case *matter.DeviceType:
+		return e.Name
+	case *matter.Namespace:
+		return e.Name
+	case *matter.Feature:
+		return e.Name()
+	case *matter.Field:
+		return e.Name
+	case *matter.Command:
+		return e.Name
+	case *matter.Event:
+		return e.Name
+	case *matter.Bitmap:
+		return e.Name
+	case *matter.Enum:
+		return e.Name
+	case *matter.Struct:
+		return e.Name
+	case *matter.TypeDef:
+		return e.Name
+	}
+	return ""
+}
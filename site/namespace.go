@@ -0,0 +1,40 @@
+package site
+
+import (
+	"html"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func (g *Generator) renderNamespace(ns *matter.Namespace) string {
+	p := newPage(ns.Name)
+	p.startTable("", "ID")
+	p.row(html.EscapeString(idString(ns.ID)))
+	p.endTable()
+
+	p.heading(2, "semantic-tags", "Semantic Tags")
+	p.startTable("", "ID", "Name", "Description")
+	for _, t := range ns.SemanticTags {
+		p.row(html.EscapeString(idString(t.ID)), html.EscapeString(t.Name), html.EscapeString(t.Description))
+	}
+	p.endTable()
+	return p.String()
+}
+
+func (g *Generator) renderGlobalDataTypes(globals []types.Entity) string {
+	p := newPage("Global Data Types")
+	for _, e := range globals {
+		switch e := e.(type) {
+		case *matter.Bitmap:
+			g.renderBitmap(p, nil, e)
+		case *matter.Enum:
+			g.renderEnum(p, nil, e)
+		case *matter.Struct:
+			g.renderStruct(p, nil, e)
+		case *matter.TypeDef:
+			g.renderTypeDef(p, nil, e)
+		}
+	}
+	return p.String()
+}
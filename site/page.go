@@ -0,0 +1,159 @@
+package site
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+const stylesheet = `body { font-family: sans-serif; margin: 0; line-height: 1.4; }
+header { background: #1d3557; color: #fff; padding: 0.5em 1em; }
+header a { color: #fff; margin-right: 1em; text-decoration: none; }
+main { max-width: 70em; margin: 1em auto; padding: 0 1em; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #bbb; padding: 0.25em 0.5em; vertical-align: top; text-align: left; }
+th { background: #eee; }
+.matrix td { text-align: center; font-size: 0.85em; }
+.description { color: #333; }
+.refs { font-size: 0.9em; color: #555; }
+#search { width: 100%; font-size: 1.1em; padding: 0.25em; }
+#results li span { color: #777; font-size: 0.85em; margin-left: 0.5em; }
+`
+
+type page struct {
+	strings.Builder
+}
+
+func newPage(title string) *page {
+	p := &page{}
+	p.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>")
+	p.text(title)
+	p.WriteString("</title>\n<style>\n")
+	p.WriteString(stylesheet)
+	p.WriteString("</style>\n</head>\n<body>\n<header>")
+	p.link(indexPageName, "Index")
+	p.link(globalDataTypesPageName, "Global Data Types")
+	p.link(deviceTypeMatrixPageName, "Device Type Matrix")
+	p.WriteString("</header>\n<main>\n<h1>")
+	p.text(title)
+	p.WriteString("</h1>\n")
+	return p
+}
+
+func (p *page) String() string {
+	return p.Builder.String() + "</main>\n</body>\n</html>\n"
+}
+
+func (p *page) text(s string) {
+	p.WriteString(html.EscapeString(s))
+}
+
+func (p *page) link(href string, label string) {
+	if href == "" {
+		p.text(label)
+		return
+	}
+	p.WriteString(fmt.Sprintf("<a href=\"%s\">", html.EscapeString(href)))
+	p.text(label)
+	p.WriteString("</a>")
+}
+
+func (p *page) heading(level int, id string, title string) {
+	if id == "" {
+		p.WriteString(fmt.Sprintf("<h%d>", level))
+	} else {
+		p.WriteString(fmt.Sprintf("<h%d id=\"%s\">", level, html.EscapeString(id)))
+	}
+	p.text(title)
+	p.WriteString(fmt.Sprintf("</h%d>\n", level))
+}
+
+func (p *page) paragraph(class string, s string) {
+	if s == "" {
+		return
+	}
+	p.WriteString(fmt.Sprintf("<p class=\"%s\">", class))
+	p.text(s)
+	p.WriteString("</p>\n")
+}
+
+func (p *page) startTable(class string, headers ...string) {
+	if class == "" {
+		p.WriteString("<table>\n<tr>")
+	} else {
+		p.WriteString(fmt.Sprintf("<table class=\"%s\">\n<tr>", class))
+	}
+	for _, h := range headers {
+		p.WriteString("<th>")
+		p.text(h)
+		p.WriteString("</th>")
+	}
+	p.WriteString("</tr>\n")
+}
+
+func (p *page) endTable() {
+	p.WriteString("</table>\n")
+}
+
+// row writes a table row; cells are expected to already be escaped
+func (p *page) row(cells ...string) {
+	p.WriteString("<tr>")
+	for _, c := range cells {
+		p.WriteString("<td>")
+		p.WriteString(c)
+		p.WriteString("</td>")
+	}
+	p.WriteString("</tr>\n")
+}
+
+func (l links) anchor(e types.Entity, label string) string {
+	href, ok := l[e]
+	if !ok {
+		return html.EscapeString(label)
+	}
+	return fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(href), html.EscapeString(label))
+}
+
+func (l links) dataType(dt *types.DataType) string {
+	if dt == nil {
+		return ""
+	}
+	if dt.IsArray() && dt.EntryType != nil {
+		return "list[" + l.dataType(dt.EntryType) + "]"
+	}
+	if dt.Entity != nil {
+		return l.anchor(dt.Entity, dt.Name)
+	}
+	return html.EscapeString(dt.Name)
+}
+
+var qualityOrder = []struct {
+	quality matter.Quality
+	code    string
+}{
+	{matter.QualityNullable, "X"},
+	{matter.QualityNonVolatile, "N"},
+	{matter.QualityFixed, "F"},
+	{matter.QualityScene, "S"},
+	{matter.QualityReportable, "P"},
+	{matter.QualityChangedOmitted, "C"},
+	{matter.QualityDiagnostics, "K"},
+	{matter.QualitySingleton, "I"},
+	{matter.QualityLargeMessage, "L"},
+	{matter.QualitySourceAttribution, "A"},
+	{matter.QualityAtomicWrite, "T"},
+	{matter.QualityQuieterReporting, "Q"},
+}
+
+func qualityString(q matter.Quality) string {
+	var sb strings.Builder
+	for _, qo := range qualityOrder {
+		if q.Has(qo.quality) {
+			sb.WriteString(qo.code)
+		}
+	}
+	return sb.String()
+}
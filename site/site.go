@@ -0,0 +1,175 @@
+package site
+
+import (
+	"cmp"
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type Generator struct {
+	outputRoot string
+	spec       *spec.Specification
+
+	links links
+}
+
+func NewGenerator(outputRoot string, spec *spec.Specification) *Generator {
+	return &Generator{outputRoot: outputRoot, spec: spec}
+}
+
+func (g *Generator) Name() string {
+	return "Generating reference site"
+}
+
+func (g *Generator) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeCollective
+}
+
+func (g *Generator) Process(cxt context.Context, inputs []*pipeline.Data[*spec.Doc]) (outputs []*pipeline.Data[string], err error) {
+	clusters := g.sortedClusters()
+	deviceTypes := slices.Clone(g.spec.DeviceTypes)
+	slices.SortStableFunc(deviceTypes, func(a, b *matter.DeviceType) int {
+		return cmp.Or(compareIDs(a.ID, b.ID), strings.Compare(a.Name, b.Name))
+	})
+	namespaces := slices.Clone(g.spec.Namespaces)
+	slices.SortStableFunc(namespaces, func(a, b *matter.Namespace) int {
+		return cmp.Or(compareIDs(a.ID, b.ID), strings.Compare(a.Name, b.Name))
+	})
+
+	// Only pages for documents that made it through the path filter are rendered; everything else is still listed, but
+	// without links to pages that don't exist
+	docs := make(map[*spec.Doc]struct{}, len(inputs))
+	for _, input := range inputs {
+		docs[input.Content] = struct{}{}
+	}
+	included := func(e types.Entity) bool {
+		doc, ok := g.spec.DocRefs[e]
+		if !ok {
+			return true
+		}
+		_, ok = docs[doc]
+		return ok
+	}
+	pages := map[string]struct{}{indexPageName: {}, globalDataTypesPageName: {}, deviceTypeMatrixPageName: {}}
+	for _, c := range clusters {
+		if included(c) {
+			pages[clusterPageName(c)] = struct{}{}
+		}
+	}
+	for _, dt := range deviceTypes {
+		if included(dt) {
+			pages[deviceTypePageName(dt)] = struct{}{}
+		}
+	}
+	for _, ns := range namespaces {
+		if included(ns) {
+			pages[namespacePageName(ns)] = struct{}{}
+		}
+	}
+
+	g.links = buildLinks(g.spec, clusters, deviceTypes, namespaces)
+	g.links.restrict(pages)
+
+	var index searchIndex
+	for _, c := range clusters {
+		index.addCluster(g.links, c)
+		if !included(c) {
+			continue
+		}
+		outputs = append(outputs, g.page(clusterPageName(c), g.renderCluster(c)))
+	}
+	for _, dt := range deviceTypes {
+		index.addDeviceType(g.links, dt)
+		if !included(dt) {
+			continue
+		}
+		outputs = append(outputs, g.page(deviceTypePageName(dt), g.renderDeviceType(dt)))
+	}
+	for _, ns := range namespaces {
+		index.addNamespace(g.links, ns)
+		if !included(ns) {
+			continue
+		}
+		outputs = append(outputs, g.page(namespacePageName(ns), g.renderNamespace(ns)))
+	}
+	globals := g.globalDataTypes()
+	for _, e := range globals {
+		index.addDataType(g.links, e, "")
+	}
+	outputs = append(outputs, g.page(globalDataTypesPageName, g.renderGlobalDataTypes(globals)))
+	outputs = append(outputs, g.page(deviceTypeMatrixPageName, g.renderDeviceTypeMatrix(deviceTypes, clusters)))
+	outputs = append(outputs, g.page(indexPageName, g.renderIndex(clusters, deviceTypes, namespaces, index)))
+	return
+}
+
+func (g *Generator) page(name string, content string) *pipeline.Data[string] {
+	return pipeline.NewData(filepath.Join(g.outputRoot, name), content)
+}
+
+func (g *Generator) sortedClusters() []*matter.Cluster {
+	clusters := make([]*matter.Cluster, 0, len(g.spec.Clusters))
+	for c := range g.spec.Clusters {
+		clusters = append(clusters, c)
+	}
+	slices.SortStableFunc(clusters, func(a, b *matter.Cluster) int {
+		return cmp.Or(compareIDs(a.ID, b.ID), strings.Compare(a.Name, b.Name))
+	})
+	return clusters
+}
+
+func (g *Generator) globalDataTypes() []types.Entity {
+	globals := make([]types.Entity, 0, len(g.spec.GlobalObjects))
+	for e := range g.spec.GlobalObjects {
+		switch e.(type) {
+		case *matter.Bitmap, *matter.Enum, *matter.Struct, *matter.TypeDef:
+			globals = append(globals, e)
+		}
+	}
+	slices.SortStableFunc(globals, func(a, b types.Entity) int {
+		return strings.Compare(entityName(a), entityName(b))
+	})
+	return globals
+}
+
+const indexPageName = "index.html"
+const globalDataTypesPageName = "data-types.html"
+const deviceTypeMatrixPageName = "device-type-matrix.html"
+
+func clusterPageName(c *matter.Cluster) string {
+	return "cluster-" + strcase.ToKebab(c.Name) + ".html"
+}
+
+func deviceTypePageName(dt *matter.DeviceType) string {
+	return "device-type-" + strcase.ToKebab(dt.Name) + ".html"
+}
+
+func namespacePageName(ns *matter.Namespace) string {
+	return "namespace-" + strcase.ToKebab(ns.Name) + ".html"
+}
+
+func compareIDs(a *matter.Number, b *matter.Number) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return a.Compare(b)
+}
+
+func idString(n *matter.Number) string {
+	if n == nil {
+		return ""
+	}
+	return n.HexString()
+}
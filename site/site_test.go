@@ -0,0 +1,84 @@
+package site
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+var hrefPattern = regexp.MustCompile(`href="([^"#]*)(?:#[^"]*)?"|"u":"([^"#]*)(?:#[^"]*)?"`)
+
+func TestFilteredSiteLinks(t *testing.T) {
+	specRoot := t.TempDir()
+	err := os.CopyFS(specRoot, os.DirFS("../disco/testdata/rename"))
+	if err != nil {
+		t.Fatalf("failed copying spec: %v", err)
+	}
+	var docs []*pipeline.Data[*spec.Doc]
+	err = filepath.WalkDir(filepath.Join(specRoot, "src"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".adoc" {
+			return err
+		}
+		doc, err := spec.ReadFile(path, specRoot)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, pipeline.NewData(path, doc))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed reading spec: %v", err)
+	}
+	builder := spec.NewBuilder()
+	_, err = builder.Process(context.Background(), docs)
+	if err != nil {
+		t.Fatalf("failed building spec: %v", err)
+	}
+
+	// As if the path filter only let the Widget cluster and device type through
+	var filtered []*pipeline.Data[*spec.Doc]
+	for _, doc := range docs {
+		if strings.Contains(doc.Path, "Widget") && !strings.Contains(doc.Path, "Fancy") {
+			filtered = append(filtered, doc)
+		}
+	}
+	outputs, err := NewGenerator("site", builder.Spec).Process(context.Background(), filtered)
+	if err != nil {
+		t.Fatalf("failed generating site: %v", err)
+	}
+	rendered := make(map[string]string)
+	var pages []string
+	for _, o := range outputs {
+		name := filepath.Base(o.Path)
+		rendered[name] = o.Content
+		pages = append(pages, name)
+	}
+	slices.Sort(pages)
+	expected := []string{"cluster-widget.html", "data-types.html", "device-type-matrix.html", "device-type-widget-device.html", "index.html"}
+	if !slices.Equal(pages, expected) {
+		t.Errorf("expected pages %v, got %v", expected, pages)
+	}
+	for name, content := range rendered {
+		for _, m := range hrefPattern.FindAllStringSubmatch(content, -1) {
+			target := m[1] + m[2]
+			if target == "" {
+				continue
+			}
+			if _, ok := rendered[target]; !ok {
+				t.Errorf("%s links to %s, which wasn't rendered", name, target)
+			}
+		}
+	}
+	// Clusters without pages are still listed in the index
+	if index := rendered["index.html"]; !strings.Contains(index, "<td>Gadget</td>") {
+		t.Errorf("expected index to list Gadget without a link, got:\n%s", index)
+	}
+}
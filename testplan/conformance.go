@@ -28,7 +28,10 @@ func renderFeatureConformance(b *strings.Builder, doc *spec.Doc, cluster *matter
 }
 
 func renderConformance(cs conformance.Set, b *strings.Builder, doc *spec.Doc, cluster *matter.Cluster, formatter conformanceEntityFormatter) {
-	for _, c := range cs {
+	for i, c := range cs {
+		if i > 0 {
+			b.WriteString(", ")
+		}
 		switch c := c.(type) {
 		case *conformance.Mandatory:
 			if c.Expression == nil {
@@ -63,14 +66,27 @@ func renderExpression(b *strings.Builder, doc *spec.Doc, cluster *matter.Cluster
 	case *conformance.EqualityExpression:
 		b.WriteRune('(')
 		renderExpression(b, doc, cluster, exp.Left, formatter)
-		b.WriteString(" == ")
+		if exp.Not {
+			b.WriteString(" != ")
+		} else {
+			b.WriteString(" == ")
+		}
 		renderExpression(b, doc, cluster, exp.Right, formatter)
 		b.WriteRune(')')
 	case *conformance.FeatureExpression:
+		if exp.Not {
+			b.WriteRune('!')
+		}
 		b.WriteString(renderIdentifier(cluster.Features, exp.Feature, formatter))
 	case *conformance.IdentifierExpression:
+		if exp.Not {
+			b.WriteRune('!')
+		}
 		b.WriteString(renderIdentifier(cluster, exp.ID, formatter))
 	case *conformance.ReferenceExpression:
+		if exp.Not {
+			b.WriteRune('!')
+		}
 		b.WriteString(renderReference(doc, exp.Reference, formatter))
 	case *conformance.LogicalExpression:
 		if exp.Not {
@@ -30,7 +30,7 @@ func renderEvents(doc *spec.Doc, cut *clusterUnderTest, b *strings.Builder) {
 	}
 	b.WriteRune('\n')
 	for i, name := range names {
-		b.WriteString(fmt.Sprintf(":PICS_S%-*s : {PICS_S}.A%s({%s})\n", longest, name, cut.events[i].ID.HexString(), name))
+		b.WriteString(fmt.Sprintf(":PICS_S%-*s : {PICS_S}.E%02X({%s})\n", longest, name, cut.events[i].ID.Value(), name))
 	}
 	b.WriteString("\n\n|===\n")
 	b.WriteString("| *Variable* | *Description* | *Mandatory/Optional* | *Notes/Additional Constraints*\n")
@@ -17,8 +17,8 @@ func renderFeatures(doc *spec.Doc, cut *clusterUnderTest, b *strings.Builder) {
 		b.WriteString(fmt.Sprintf(":F_%s: %s\n", f.Code, f.Code))
 	}
 	b.WriteRune('\n')
-	for i, f := range cut.features {
-		b.WriteString(fmt.Sprintf(":PICS_SF_%s: {PICS_S}.F%02d({F_%s})\n", f.Code, i, f.Code))
+	for _, f := range cut.features {
+		b.WriteString(fmt.Sprintf(":PICS_SF_%s: {PICS_S}.F%02d({F_%s})\n", f.Code, featureBit(f), f.Code))
 	}
 	b.WriteRune('\n')
 	b.WriteString("|===\n")
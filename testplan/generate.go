@@ -20,6 +20,7 @@ import (
 type Generator struct {
 	testPlanRoot string
 	overwrite    bool
+	merge        bool
 }
 
 func (sp Generator) Name() string {
@@ -44,11 +45,14 @@ func (sp *Generator) Process(cxt context.Context, input *pipeline.Data[*spec.Doc
 
 	for newPath, cluster := range destinations {
 
-		_, err = os.ReadFile(newPath)
-		if (err == nil || !errors.Is(err, os.ErrNotExist)) && !sp.overwrite {
+		var existing []byte
+		existing, err = os.ReadFile(newPath)
+		amend := err == nil && sp.merge && !sp.overwrite
+		if (err == nil || !errors.Is(err, os.ErrNotExist)) && !sp.overwrite && !amend {
 			slog.InfoContext(cxt, "Skipping existing test plan", slog.String("path", newPath))
 			continue
 		}
+		err = nil
 
 		var result string
 		result, err = renderClusterTestPlan(doc, cluster)
@@ -57,13 +61,26 @@ func (sp *Generator) Process(cxt context.Context, input *pipeline.Data[*spec.Doc
 			return
 		}
 
+		if amend {
+			var removed []removedPICS
+			var kept []string
+			result, removed, kept = mergeTestPlan(string(existing), result)
+			for _, pics := range removed {
+				// Hand-written test cases that still use a PICS will need attention
+				slog.WarnContext(cxt, "Test plan has PICS no longer in the spec", slog.String("path", newPath), slog.String("pics", pics.code), slog.Bool("referenced", pics.referenced))
+			}
+			for _, id := range kept {
+				slog.WarnContext(cxt, "Test procedure has hand-written steps, so it was not regenerated", slog.String("path", newPath), slog.String("testCase", id))
+			}
+		}
+
 		outputs = append(outputs, pipeline.NewData[string](newPath, result))
 	}
 	return
 }
 
-func NewGenerator(testPlanRoot string, overwrite bool) *Generator {
-	return &Generator{testPlanRoot: testPlanRoot, overwrite: overwrite}
+func NewGenerator(testPlanRoot string, overwrite bool, merge bool) *Generator {
+	return &Generator{testPlanRoot: testPlanRoot, overwrite: overwrite, merge: merge}
 }
 
 func getTestPlanPath(testplanRoot string, name string) string {
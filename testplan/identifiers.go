@@ -32,3 +32,12 @@ func entityIdentifier(entity types.Entity) string {
 	}
 	return fmt.Sprintf("UNKNOWN_TYPE_%T", entity)
 }
+
+// featureBit is the bit a feature occupies in the feature map, which is what its PICS code is numbered by
+func featureBit(f *matter.Feature) uint64 {
+	from, _, err := f.Bits()
+	if err != nil {
+		return 0
+	}
+	return from
+}
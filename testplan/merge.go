@@ -0,0 +1,324 @@
+package testplan
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// generatedSections are the subsections of a test plan's server PICS definitions that come entirely from the spec, in
+// the order they're generated; everything else in a test plan is left alone when merging
+var generatedSections = []string{"Features", "Attributes", "Commands received", "Commands generated", "Events"}
+
+// generatedTestProcedures are the test cases whose procedures come from the spec: the global attributes test case and
+// the attributes test case
+var generatedTestProcedures = []string{"1.1", "2.1"}
+
+var headingPattern = regexp.MustCompile(`^(=+)\s+(.+?)\s*$`)
+var picsDefinitionPattern = regexp.MustCompile(`^:(PICS_S\w+)\s*:`)
+var generatedStepPattern = regexp.MustCompile(`^\{THread\} _\{?\w+\}?_ attribute\.$`)
+var stepNumberPattern = regexp.MustCompile(`^\d+(\^\*\^)?$`)
+
+type planSection struct {
+	title string
+	lines []string
+}
+
+// removedPICS is a PICS an existing test plan defined that the spec no longer generates
+type removedPICS struct {
+	code string
+	// referenced is whether anything the merge kept from the existing test plan still uses it
+	referenced bool
+}
+
+// mergeTestPlan replaces the generated PICS definitions and test procedures in an existing test plan with newly
+// generated ones, leaving hand-written test cases in place. It returns the PICS the existing test plan defined that
+// the new one doesn't, and the generated test procedures it left alone because they had hand-written steps
+func mergeTestPlan(existing string, generated string) (merged string, removed []removedPICS, kept []string) {
+	lines := strings.Split(existing, "\n")
+	generatedLines := strings.Split(generated, "\n")
+
+	oldPICS := generatedPICS(lines)
+	newPICS := generatedPICS(generatedLines)
+
+	var replaced []string
+	for _, id := range generatedTestProcedures {
+		start, end := findTestProcedure(lines, id)
+		if start < 0 {
+			continue
+		}
+		if isGeneratedProcedure(lines[start:end], oldPICS, newPICS) {
+			replaced = append(replaced, id)
+		} else {
+			kept = append(kept, id)
+		}
+	}
+
+	// Whatever survives the merge from the existing test plan and still uses a removed PICS will need attention
+	handWritten := strings.Join(stripGenerated(lines, replaced), "\n")
+	for p := range oldPICS {
+		if _, ok := newPICS[p]; !ok {
+			removed = append(removed, removedPICS{code: p, referenced: strings.Contains(handWritten, "{"+p+"}")})
+		}
+	}
+	slices.SortFunc(removed, func(a removedPICS, b removedPICS) int { return strings.Compare(a.code, b.code) })
+
+	lines = mergeServerPICS(lines, generatedLines)
+	lines = mergeTestProcedures(lines, generatedLines, replaced)
+	merged = strings.Join(lines, "\n")
+	return
+}
+
+func mergeServerPICS(lines []string, generatedLines []string) []string {
+	genStart, genEnd := findSection(generatedLines, 3, "Server")
+	if genStart < 0 {
+		return lines
+	}
+	_, generatedSubsections := splitSubsections(generatedLines[genStart+1:genEnd], 4)
+
+	start, end := findSection(lines, 3, "Server")
+	if start < 0 {
+		// No server PICS at all, so add them to the end of the PICS definitions
+		picsStart, picsEnd := findSection(lines, 2, "PICS Definition")
+		if picsStart < 0 {
+			return lines
+		}
+		return slices.Concat(lines[:picsEnd], generatedLines[genStart:genEnd], lines[picsEnd:])
+	}
+
+	preamble, subsections := splitSubsections(lines[start+1:end], 4)
+
+	var mergedSubsections []planSection
+	for _, s := range subsections {
+		if !slices.Contains(generatedSections, s.title) {
+			mergedSubsections = append(mergedSubsections, s)
+			continue
+		}
+		if gs, ok := findSubsection(generatedSubsections, s.title); ok {
+			mergedSubsections = append(mergedSubsections, gs)
+		}
+	}
+	// Sections the existing test plan didn't have go after whichever generated section precedes them
+	for _, gs := range generatedSubsections {
+		if _, ok := findSubsection(mergedSubsections, gs.title); ok {
+			continue
+		}
+		index := 0
+		order := slices.Index(generatedSections, gs.title)
+		for i, s := range mergedSubsections {
+			o := slices.Index(generatedSections, s.title)
+			if o >= 0 && o < order {
+				index = i + 1
+			}
+		}
+		mergedSubsections = slices.Insert(mergedSubsections, index, gs)
+	}
+
+	server := []string{lines[start]}
+	server = append(server, preamble...)
+	for _, s := range mergedSubsections {
+		server = append(server, s.lines...)
+	}
+	return slices.Concat(lines[:start], server, lines[end:])
+}
+
+// generatedPICS returns the PICS defined in the generated subsections of a test plan's server PICS definitions
+func generatedPICS(lines []string) map[string]struct{} {
+	pics := make(map[string]struct{})
+	start, end := findSection(lines, 3, "Server")
+	if start < 0 {
+		return pics
+	}
+	_, subsections := splitSubsections(lines[start+1:end], 4)
+	for _, s := range subsections {
+		if slices.Contains(generatedSections, s.title) {
+			collectPICSDefinitions(s.lines, pics)
+		}
+	}
+	return pics
+}
+
+// stripGenerated removes the generated server PICS subsections and the given test procedures from a test plan
+func stripGenerated(lines []string, procedures []string) []string {
+	for _, id := range procedures {
+		start, end := findTestProcedure(lines, id)
+		if start >= 0 {
+			lines = slices.Concat(lines[:start], lines[end:])
+		}
+	}
+	start, end := findSection(lines, 3, "Server")
+	if start < 0 {
+		return lines
+	}
+	preamble, subsections := splitSubsections(lines[start+1:end], 4)
+	server := []string{lines[start]}
+	server = append(server, preamble...)
+	for _, s := range subsections {
+		if !slices.Contains(generatedSections, s.title) {
+			server = append(server, s.lines...)
+		}
+	}
+	return slices.Concat(lines[:start], server, lines[end:])
+}
+
+// isGeneratedProcedure returns whether every step of a test procedure looks like one the generator writes: reading
+// an attribute, with either no PICS or one of the generated PICS; a procedure with any other step has been added to
+// by hand, so regenerating it would lose that step
+func isGeneratedProcedure(lines []string, pics ...map[string]struct{}) bool {
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "|") || strings.HasPrefix(line, "|===") {
+			continue
+		}
+		cells := strings.Split(line, "|")[1:]
+		for i, c := range cells {
+			cells[i] = strings.TrimSpace(c)
+		}
+		if cells[0] == "**#**" {
+			continue
+		}
+		if len(cells) < 4 || !stepNumberPattern.MatchString(cells[0]) {
+			return false
+		}
+		ref, p, step := cells[1], cells[2], cells[3]
+		if step == "{comDutTH}." && ref == "" && p == "" {
+			continue
+		}
+		if !strings.HasPrefix(ref, "{REF_") || !generatedStepPattern.MatchString(step) {
+			return false
+		}
+		if p != "" && !slices.ContainsFunc(pics, func(pics map[string]struct{}) bool {
+			_, ok := pics[strings.Trim(p, "{}")]
+			return ok
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeTestProcedures replaces the procedures of the given generated test cases; the rest of those test cases, such as
+// their notes, may have been written by hand, so they're left alone
+func mergeTestProcedures(lines []string, generatedLines []string, procedures []string) []string {
+	for _, id := range procedures {
+		genStart, genEnd := findTestProcedure(generatedLines, id)
+		if genStart < 0 {
+			continue
+		}
+		start, end := findTestProcedure(lines, id)
+		if start < 0 {
+			continue
+		}
+		lines = slices.Concat(lines[:start], generatedLines[genStart:genEnd], lines[end:])
+	}
+	return lines
+}
+
+func findTestProcedure(lines []string, id string) (start int, end int) {
+	testCasePattern := regexp.MustCompile(`^\[TC-[^\]]+-` + regexp.QuoteMeta(id) + `\]`)
+	caseStart, caseEnd := findSectionFunc(lines, 4, testCasePattern.MatchString)
+	if caseStart < 0 {
+		return -1, -1
+	}
+	start, end = findSection(lines[caseStart:caseEnd], 5, "Test Procedure")
+	if start < 0 {
+		return -1, -1
+	}
+	return caseStart + start, caseStart + end
+}
+
+// findSection returns the lines a section with the given level and title spans, from its heading up to the next
+// heading of the same or a higher level
+func findSection(lines []string, level int, title string) (start int, end int) {
+	return findSectionFunc(lines, level, func(t string) bool { return strings.EqualFold(t, title) })
+}
+
+func findSectionFunc(lines []string, level int, match func(title string) bool) (start int, end int) {
+	start, end = -1, len(lines)
+	forEachHeading(lines, func(i int, l int, t string) bool {
+		if start < 0 {
+			if l == level && match(t) {
+				start = i
+			}
+			return true
+		}
+		if l <= level {
+			end = i
+			return false
+		}
+		return true
+	})
+	if start < 0 {
+		end = -1
+	}
+	return
+}
+
+// splitSubsections splits the body of a section into whatever precedes its first subsection, and its subsections
+func splitSubsections(lines []string, level int) (preamble []string, subsections []planSection) {
+	var starts []int
+	var titles []string
+	forEachHeading(lines, func(i int, l int, t string) bool {
+		if l == level {
+			starts = append(starts, i)
+			titles = append(titles, t)
+		}
+		return true
+	})
+	if len(starts) == 0 {
+		return lines, nil
+	}
+	preamble = lines[:starts[0]]
+	for i, s := range starts {
+		e := len(lines)
+		if i < len(starts)-1 {
+			e = starts[i+1]
+		}
+		subsections = append(subsections, planSection{title: titles[i], lines: lines[s:e]})
+	}
+	return
+}
+
+func findSubsection(subsections []planSection, title string) (planSection, bool) {
+	for _, s := range subsections {
+		if strings.EqualFold(s.title, title) {
+			return s, true
+		}
+	}
+	return planSection{}, false
+}
+
+// forEachHeading calls the callback for each section heading, skipping anything inside delimited blocks, until the
+// callback returns false
+func forEachHeading(lines []string, callback func(index int, level int, title string) bool) {
+	var delimiter string
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if delimiter != "" {
+			if trimmed == delimiter {
+				delimiter = ""
+			}
+			continue
+		}
+		switch trimmed {
+		case "----", "....", "////", "****", "____", "====":
+			delimiter = trimmed
+			continue
+		}
+		matches := headingPattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		if !callback(i, len(matches[1]), matches[2]) {
+			return
+		}
+	}
+}
+
+func collectPICSDefinitions(lines []string, pics map[string]struct{}) {
+	for _, line := range lines {
+		matches := picsDefinitionPattern.FindStringSubmatch(line)
+		if matches != nil {
+			pics[matches[1]] = struct{}{}
+		}
+	}
+}
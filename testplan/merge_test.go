@@ -0,0 +1,119 @@
+package testplan
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testPlan assembles a cut-down test plan from its server PICS definitions, the steps of its global attributes and
+// attributes test procedures, and any hand-written test cases
+func testPlan(server string, globalSteps string, attributeSteps string, testCases string) string {
+	var b strings.Builder
+	b.WriteString("= *Widget Cluster Test Plan*\n\n== PICS Definition\n\n=== Role\n\n:PICS_S : WIDGET.S\n\n")
+	b.WriteString(server)
+	b.WriteString("== Test Cases\n// ################# TEST CASE TEMPLATE: START #################\n=== Generic Test Cases\n")
+	b.WriteString("==== [TC-{picsCode}-1.1] Global Attributes with {DUT_Server}\n\n===== Test Procedure\n|===\n")
+	b.WriteString(globalSteps)
+	b.WriteString("|===\n\n===== Notes/Testing Considerations\nStep 3 needs a second fabric.\n\n")
+	b.WriteString("==== [TC-{picsCode}-2.1] Attributes with Server as DUT\n\n===== Test Procedure\n|===\n")
+	b.WriteString(attributeSteps)
+	b.WriteString("|===\n\n===== Notes/Testing Considerations\n\n")
+	b.WriteString(testCases)
+	return b.String()
+}
+
+const generatedServer = `=== Server
+
+==== Features
+
+:PICS_SF_SPD : {PICS_S}.F00(SPD)
+
+==== Attributes
+
+:PICS_SA_SPEED : {PICS_S}.A0000({A_SPEED})
+
+==== Events
+
+:PICS_SE_STALLED : {PICS_S}.E00({E_STALLED})
+
+`
+
+const generatedGlobalSteps = "| 1 | | | {comDutTH}. |\n| 3 | {REF_FEATUREMAP} | | {THread} _FeatureMap_ attribute. | - bit 0: {shallBeOneIff} {PICS_SF_SPD}\n"
+const generatedAttributeSteps = "| 1 | | | {comDutTH}. |\n| 2  | {REF_WIDGET_SA_SPEED} | {PICS_SA_SPEED} | {THread} _{A_SPEED}_ attribute. |\n"
+
+const handWrittenStep = "| 9 | | {PICS_SA_LABEL} | Hand-written check of the label. |\n"
+
+const handWrittenTestCase = "==== [TC-{picsCode}-2.2] Primary Functionality with Server as DUT\n\n===== Test Procedure\n|===\n| 1 | | {PICS_SA_MODE} | Hand-written step. |\n|===\n"
+
+var mergeTests = []struct {
+	Name     string
+	Existing string
+	Expected []string
+	Unwanted []string
+	// Removed PICS, and whether what's left of the existing test plan still refers to them
+	Removed map[string]bool
+	Kept    []string
+}{
+	{
+		Name:     "missing server section",
+		Existing: testPlan("", generatedGlobalSteps, generatedAttributeSteps, ""),
+		Expected: []string{":PICS_S : WIDGET.S\n\n=== Server\n", ":PICS_SA_SPEED : ", ":PICS_SE_STALLED : ", "\n== Test Cases\n"},
+	},
+	{
+		Name:     "new subsection",
+		Existing: testPlan("=== Server\n\n==== Features\n\n:PICS_SF_SPD : {PICS_S}.F00(SPD)\n\n==== Attributes\n\n:PICS_SA_SPEED : {PICS_S}.A0000({A_SPEED})\n\n==== Manual controls\n\n:PICS_S_KNOB : {PICS_S}.KNOB\n\n", generatedGlobalSteps, generatedAttributeSteps, ""),
+		Expected: []string{"==== Attributes\n\n:PICS_SA_SPEED : {PICS_S}.A0000({A_SPEED})\n\n==== Events\n\n:PICS_SE_STALLED : {PICS_S}.E00({E_STALLED})\n\n==== Manual controls\n\n:PICS_S_KNOB : {PICS_S}.KNOB\n"},
+	},
+	{
+		Name:     "removed PICS",
+		Existing: testPlan("=== Server\n\n==== Attributes\n\n:PICS_SA_SPEED : {PICS_S}.A0000({A_SPEED})\n:PICS_SA_MODE : {PICS_S}.A0001({A_MODE})\n\n", "| 1 | | | {comDutTH}. |\n", "| 1 | | | {comDutTH}. |\n| 2  | {REF_WIDGET_SA_MODE} | {PICS_SA_MODE} | {THread} _{A_MODE}_ attribute. |\n", ""),
+		Expected: []string{generatedAttributeSteps, generatedGlobalSteps},
+		Unwanted: []string{":PICS_SA_MODE : ", "{REF_WIDGET_SA_MODE}"},
+		Removed:  map[string]bool{"PICS_SA_MODE": false},
+	},
+	{
+		Name:     "hand-edited attributes step",
+		Existing: testPlan("=== Server\n\n==== Attributes\n\n:PICS_SA_SPEED : {PICS_S}.A0000({A_SPEED})\n:PICS_SA_LABEL : {PICS_S}.A0002({A_LABEL})\n\n", "| 1 | | | {comDutTH}. |\n", generatedAttributeSteps+handWrittenStep, ""),
+		Expected: []string{generatedAttributeSteps + handWrittenStep, generatedGlobalSteps},
+		Unwanted: []string{":PICS_SA_LABEL : "},
+		Removed:  map[string]bool{"PICS_SA_LABEL": true},
+		Kept:     []string{"2.1"},
+	},
+	{
+		Name:     "hand-written test cases",
+		Existing: testPlan(generatedServer, "| 1 | | | {comDutTH}. |\n", "| 1 | | | {comDutTH}. |\n", handWrittenTestCase),
+		Expected: []string{handWrittenTestCase, "Step 3 needs a second fabric.", generatedGlobalSteps, generatedAttributeSteps},
+	},
+}
+
+func TestMergeTestPlan(t *testing.T) {
+	generated := testPlan(generatedServer, generatedGlobalSteps, generatedAttributeSteps, "")
+	for _, mt := range mergeTests {
+		merged, removed, kept := mergeTestPlan(mt.Existing, generated)
+		for _, e := range mt.Expected {
+			if !strings.Contains(merged, e) {
+				t.Errorf("%s: expected merged test plan to contain %q, got:\n%s", mt.Name, e, merged)
+			}
+		}
+		for _, u := range mt.Unwanted {
+			if strings.Contains(merged, u) {
+				t.Errorf("%s: expected merged test plan not to contain %q, got:\n%s", mt.Name, u, merged)
+			}
+		}
+		if len(removed) != len(mt.Removed) {
+			t.Errorf("%s: expected removed PICS %v, got %v", mt.Name, mt.Removed, removed)
+		}
+		for _, r := range removed {
+			referenced, ok := mt.Removed[r.code]
+			if !ok {
+				t.Errorf("%s: unexpected removed PICS %s", mt.Name, r.code)
+			} else if r.referenced != referenced {
+				t.Errorf("%s: expected removed PICS %s to have referenced=%v", mt.Name, r.code, referenced)
+			}
+		}
+		if !slices.Equal(kept, mt.Kept) {
+			t.Errorf("%s: expected kept test procedures %v, got %v", mt.Name, mt.Kept, kept)
+		}
+	}
+}
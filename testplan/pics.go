@@ -0,0 +1,245 @@
+package testplan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type PICSGenerator struct {
+	testPlanRoot string
+	overwrite    bool
+}
+
+func NewPICSGenerator(testPlanRoot string, overwrite bool) *PICSGenerator {
+	return &PICSGenerator{testPlanRoot: testPlanRoot, overwrite: overwrite}
+}
+
+func (pg PICSGenerator) Name() string {
+	return "Generating PICS XML"
+}
+
+func (pg PICSGenerator) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeIndividual
+}
+
+func (pg *PICSGenerator) Process(cxt context.Context, input *pipeline.Data[*spec.Doc], index int32, total int32) (outputs []*pipeline.Data[string], extras []*pipeline.Data[*spec.Doc], err error) {
+	doc := input.Content
+
+	var entities []types.Entity
+	entities, err = doc.Entities()
+	if err != nil {
+		return
+	}
+
+	destinations := buildDestinations(pg.testPlanRoot, entities, doc.Errata().TestPlan)
+
+	for testPlanPath, cluster := range destinations {
+		if cluster.PICS == "" {
+			slog.WarnContext(cxt, "Skipping PICS for cluster with no PICS code", slog.String("cluster", cluster.Name), slog.String("path", doc.Path.String()))
+			continue
+		}
+		newPath := getPICSPath(pg.testPlanRoot, testPlanPath)
+
+		_, err = os.ReadFile(newPath)
+		if (err == nil || !errors.Is(err, os.ErrNotExist)) && !pg.overwrite {
+			slog.InfoContext(cxt, "Skipping existing PICS", slog.String("path", newPath))
+			continue
+		}
+		err = nil
+
+		var result string
+		result, err = renderClusterPICS(doc, cluster)
+		if err != nil {
+			err = fmt.Errorf("failed rendering PICS for %s: %w", cluster.Name, err)
+			return
+		}
+
+		outputs = append(outputs, pipeline.NewData[string](newPath, result))
+	}
+	return
+}
+
+// getPICSPath puts a cluster's PICS XML alongside the test plans, named after its test plan
+func getPICSPath(testPlanRoot string, testPlanPath string) string {
+	name := strings.TrimSuffix(filepath.Base(testPlanPath), filepath.Ext(testPlanPath))
+	return filepath.Join(testPlanRoot, "src/pics", name+".xml")
+}
+
+func renderClusterPICS(doc *spec.Doc, cluster *matter.Cluster) (output string, err error) {
+	cut := filterCluster(doc, cluster)
+
+	x := etree.NewDocument()
+	x.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+
+	cp := x.CreateElement("clusterPICS")
+	cp.CreateAttr("xmlns:xsi", "http://www.w3.org/2001/XMLSchema-instance")
+	cp.CreateAttr("xsi:noNamespaceSchemaLocation", "Generic-PICS-XML-Schema.xsd")
+	cp.CreateElement("name").SetText(cluster.Name)
+	if cluster.ID.Valid() {
+		cp.CreateElement("clusterId").SetText(cluster.ID.HexString())
+	}
+	cp.CreateElement("picsRoot").SetText(cluster.PICS)
+
+	usage := cp.CreateElement("usage")
+	renderPICSItem(usage, cluster.PICS+".S", fmt.Sprintf("Does the device implement the %s cluster as a server?", cluster.Name), clusterReference(cluster, ""), "O", "")
+	renderPICSItem(usage, cluster.PICS+".C", fmt.Sprintf("Does the device implement the %s cluster as a client?", cluster.Name), clusterReference(cluster, ""), "O", "")
+
+	renderServerPICS(doc, cut, cp.CreateElement("clusterSide"))
+	renderClientPICS(cut, cp.CreateElement("clusterSide"))
+
+	x.Indent(2)
+	output, err = x.WriteToString()
+	return
+}
+
+func renderServerPICS(doc *spec.Doc, cut *clusterUnderTest, side *etree.Element) {
+	cluster := cut.cluster
+	side.CreateAttr("type", "Server")
+	formatter := func(entity types.Entity) string {
+		return picsCode(cluster, "S", entity)
+	}
+	status := func(cs conformance.Set) (string, string) {
+		return picsStatus(doc, cluster, cs, formatter)
+	}
+
+	attributes := side.CreateElement("attributes")
+	for _, a := range cut.attributes {
+		s, cond := status(a.Conformance)
+		renderPICSItem(attributes, formatter(a), fmt.Sprintf("Does the device implement the %s attribute?", a.Name), clusterReference(cluster, a.Name+" Attribute"), s, cond)
+	}
+	events := side.CreateElement("events")
+	for _, e := range cut.events {
+		s, cond := status(e.Conformance)
+		renderPICSItem(events, formatter(e), fmt.Sprintf("Does the device implement sending the %s event?", e.Name), clusterReference(cluster, e.Name+" Event"), s, cond)
+	}
+	commandsGenerated := side.CreateElement("commandsGenerated")
+	for _, c := range cut.commandsGenerated {
+		s, cond := status(c.Conformance)
+		renderPICSItem(commandsGenerated, formatter(c), fmt.Sprintf("Does the device implement sending the %s command?", c.Name), clusterReference(cluster, c.Name+" Command"), s, cond)
+	}
+	commandsReceived := side.CreateElement("commandsReceived")
+	for _, c := range cut.commandsAccepted {
+		s, cond := status(c.Conformance)
+		renderPICSItem(commandsReceived, formatter(c), fmt.Sprintf("Does the device implement receiving the %s command?", c.Name), clusterReference(cluster, c.Name+" Command"), s, cond)
+	}
+	features := side.CreateElement("features")
+	for _, f := range cut.features {
+		s, cond := status(f.Conformance())
+		renderPICSItem(features, formatter(f), fmt.Sprintf("Does the device support the %s feature?", f.Name()), clusterReference(cluster, "Features"), s, cond)
+	}
+}
+
+// renderClientPICS lists what a client may use; the spec only puts requirements on servers, so all of it is optional
+func renderClientPICS(cut *clusterUnderTest, side *etree.Element) {
+	cluster := cut.cluster
+	side.CreateAttr("type", "Client")
+
+	attributes := side.CreateElement("attributes")
+	for _, a := range cut.attributes {
+		renderPICSItem(attributes, picsCode(cluster, "C", a), fmt.Sprintf("Does the device implement reading the %s attribute?", a.Name), clusterReference(cluster, a.Name+" Attribute"), "O", "")
+	}
+	events := side.CreateElement("events")
+	for _, e := range cut.events {
+		renderPICSItem(events, picsCode(cluster, "C", e), fmt.Sprintf("Does the device implement receiving the %s event?", e.Name), clusterReference(cluster, e.Name+" Event"), "O", "")
+	}
+	// A client sends what the server receives, and receives what the server sends
+	commandsGenerated := side.CreateElement("commandsGenerated")
+	for _, c := range cut.commandsAccepted {
+		renderPICSItem(commandsGenerated, fmt.Sprintf("%s.C.C%02X.Tx", cluster.PICS, c.ID.Value()), fmt.Sprintf("Does the device implement sending the %s command?", c.Name), clusterReference(cluster, c.Name+" Command"), "O", "")
+	}
+	commandsReceived := side.CreateElement("commandsReceived")
+	for _, c := range cut.commandsGenerated {
+		renderPICSItem(commandsReceived, fmt.Sprintf("%s.C.C%02X.Rsp", cluster.PICS, c.ID.Value()), fmt.Sprintf("Does the device implement receiving the %s command?", c.Name), clusterReference(cluster, c.Name+" Command"), "O", "")
+	}
+	side.CreateElement("features")
+}
+
+func renderPICSItem(parent *etree.Element, itemNumber string, feature string, reference string, status string, cond string) {
+	pi := parent.CreateElement("picsItem")
+	pi.CreateElement("itemNumber").SetText(itemNumber)
+	pi.CreateElement("feature").SetText(feature)
+	pi.CreateElement("reference").SetText(reference)
+	se := pi.CreateElement("status")
+	if cond != "" {
+		se.CreateAttr("cond", cond)
+	}
+	se.SetText(status)
+	pi.CreateElement("support").SetText("false")
+}
+
+func clusterReference(cluster *matter.Cluster, section string) string {
+	name := cluster.Name
+	if !strings.HasSuffix(name, " Cluster") {
+		name += " Cluster"
+	}
+	if section == "" {
+		return name
+	}
+	return name + ": " + section
+}
+
+// picsCode is the PICS item number of an element of a cluster on the given side, numbered as the test plans number them
+func picsCode(cluster *matter.Cluster, side string, entity types.Entity) string {
+	switch entity := entity.(type) {
+	case *matter.Field:
+		if entity.EntityType() == types.EntityTypeAttribute {
+			return fmt.Sprintf("%s.%s.A%04X", cluster.PICS, side, entity.ID.Value())
+		}
+	case *matter.Feature:
+		return fmt.Sprintf("%s.%s.F%02d", cluster.PICS, side, featureBit(entity))
+	case *matter.Event:
+		return fmt.Sprintf("%s.%s.E%02X", cluster.PICS, side, entity.ID.Value())
+	case *matter.Command:
+		if entity.Direction == matter.InterfaceClient {
+			return fmt.Sprintf("%s.%s.C%02X.Tx", cluster.PICS, side, entity.ID.Value())
+		}
+		return fmt.Sprintf("%s.%s.C%02X.Rsp", cluster.PICS, side, entity.ID.Value())
+	}
+	return fmt.Sprintf("UNKNOWN_TYPE_%T", entity)
+}
+
+// picsStatus reduces a conformance to a PICS status, and the PICS expression under which it applies; PICS have no
+// otherwise, so anything that falls back to another conformance when its condition isn't met is just optional
+func picsStatus(doc *spec.Doc, cluster *matter.Cluster, cs conformance.Set, formatter conformanceEntityFormatter) (status string, cond string) {
+	for len(cs) > 0 {
+		if _, ok := cs[0].(*conformance.Provisional); !ok {
+			break
+		}
+		cs = cs[1:]
+	}
+	if len(cs) == 0 {
+		return "O", ""
+	}
+	var exp conformance.Expression
+	switch c := cs[0].(type) {
+	case *conformance.Mandatory:
+		if c.Expression != nil && len(cs) > 1 {
+			return "O", ""
+		}
+		status = "M"
+		exp = c.Expression
+	case *conformance.Optional:
+		status = "O"
+		exp = c.Expression
+	default:
+		return "O", ""
+	}
+	if exp != nil {
+		var b strings.Builder
+		renderExpression(&b, doc, cluster, exp, formatter)
+		cond = b.String()
+	}
+	return
+}
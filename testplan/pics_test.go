@@ -0,0 +1,105 @@
+package testplan
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestRenderClusterPICS(t *testing.T) {
+	doc, cluster := readTestCluster(t, "testdata/pics", "src/app_clusters/Sprocket.adoc")
+	out, err := renderClusterPICS(doc, cluster)
+	if err != nil {
+		t.Fatalf("failed rendering PICS: %v", err)
+	}
+	expected, err := os.ReadFile("testdata/pics/Sprocket.xml")
+	if err != nil {
+		t.Fatalf("failed reading expected PICS: %v", err)
+	}
+	if out != string(expected) {
+		t.Errorf("unexpected PICS XML:\n%s\nexpected:\n%s", out, string(expected))
+	}
+}
+
+func TestPICSStatus(t *testing.T) {
+	doc, cluster := readTestCluster(t, "testdata/pics", "src/app_clusters/Sprocket.adoc")
+	formatter := func(entity types.Entity) string {
+		return picsCode(cluster, "S", entity)
+	}
+	tests := []struct {
+		conformance string
+		status      string
+		cond        string
+	}{
+		{"M", "M", ""},
+		{"O", "O", ""},
+		{"P, M", "M", ""},
+		{"SPD", "M", "SPKT.S.F00"},
+		{"!SPD", "M", "!SPKT.S.F00"},
+		{"[SPD | TRQ]", "O", "(SPKT.S.F00 | SPKT.S.F01)"},
+		// PICS have no otherwise, so a conditional mandatory with a fallback is just optional
+		{"TRQ, O", "O", ""},
+		{"X", "O", ""},
+		{"D", "O", ""},
+	}
+	for _, tt := range tests {
+		status, cond := picsStatus(doc, cluster, conformance.ParseConformance(tt.conformance), formatter)
+		if status != tt.status || cond != tt.cond {
+			t.Errorf("expected %q to be %s (%q), got %s (%q)", tt.conformance, tt.status, tt.cond, status, cond)
+		}
+	}
+}
+
+// readTestCluster builds the spec under specRoot and returns the document at path, along with its cluster
+func readTestCluster(t *testing.T, specRoot string, path string) (*spec.Doc, *matter.Cluster) {
+	specRoot, err := filepath.Abs(specRoot)
+	if err != nil {
+		t.Fatalf("failed resolving spec root: %v", err)
+	}
+	var doc *spec.Doc
+	var docs []*pipeline.Data[*spec.Doc]
+	err = filepath.WalkDir(filepath.Join(specRoot, "src"), func(p string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || filepath.Ext(p) != ".adoc" {
+			return err
+		}
+		d, err := spec.ReadFile(p, specRoot)
+		if err != nil {
+			return err
+		}
+		if p == filepath.Join(specRoot, path) {
+			doc = d
+		}
+		docs = append(docs, pipeline.NewData(p, d))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed reading spec: %v", err)
+	}
+	if doc == nil {
+		t.Fatalf("missing %s", path)
+	}
+	builder := spec.NewBuilder()
+	_, err = builder.Process(context.Background(), docs)
+	if err != nil {
+		t.Fatalf("failed building spec: %v", err)
+	}
+	entities, err := doc.Entities()
+	if err != nil {
+		t.Fatalf("failed reading entities: %v", err)
+	}
+	for _, e := range entities {
+		if cluster, ok := e.(*matter.Cluster); ok {
+			return doc, cluster
+		}
+	}
+	t.Fatalf("no cluster in %s", path)
+	return nil, nil
+}
@@ -0,0 +1,460 @@
+package testplan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/strcase"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/zap"
+)
+
+type PythonGenerator struct {
+	testPlanRoot string
+	overwrite    bool
+}
+
+func NewPythonGenerator(testPlanRoot string, overwrite bool) *PythonGenerator {
+	return &PythonGenerator{testPlanRoot: testPlanRoot, overwrite: overwrite}
+}
+
+func (pg PythonGenerator) Name() string {
+	return "Generating Python tests"
+}
+
+func (pg PythonGenerator) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeIndividual
+}
+
+func (pg *PythonGenerator) Process(cxt context.Context, input *pipeline.Data[*spec.Doc], index int32, total int32) (outputs []*pipeline.Data[string], extras []*pipeline.Data[*spec.Doc], err error) {
+	doc := input.Content
+
+	var entities []types.Entity
+	entities, err = doc.Entities()
+	if err != nil {
+		return
+	}
+
+	destinations := buildDestinations(pg.testPlanRoot, entities, doc.Errata().TestPlan)
+
+	for _, cluster := range destinations {
+		if cluster.PICS == "" {
+			slog.WarnContext(cxt, "Skipping Python tests for cluster with no PICS code", slog.String("cluster", cluster.Name), slog.String("path", doc.Path.String()))
+			continue
+		}
+		for _, tc := range pythonTestCases(doc, cluster) {
+			newPath := getPythonPath(pg.testPlanRoot, cluster, tc)
+
+			_, err = os.ReadFile(newPath)
+			if (err == nil || !errors.Is(err, os.ErrNotExist)) && !pg.overwrite {
+				slog.InfoContext(cxt, "Skipping existing Python test", slog.String("path", newPath))
+				continue
+			}
+			err = nil
+
+			outputs = append(outputs, pipeline.NewData[string](newPath, renderPythonTestCase(cluster, tc)))
+		}
+	}
+	return
+}
+
+// getPythonPath names a Python test after its test case, as the SDK's python_testing scripts are named
+func getPythonPath(testPlanRoot string, cluster *matter.Cluster, tc *pythonTestCase) string {
+	return filepath.Join(testPlanRoot, "src/python", pythonTestName(cluster, tc)+".py")
+}
+
+var pythonIdentifierPattern = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// pythonTestName is the name of a test case's script and class, e.g. TC_WIDGET_2_1 for TC-WIDGET-2.1
+func pythonTestName(cluster *matter.Cluster, tc *pythonTestCase) string {
+	return "TC_" + pythonIdentifierPattern.ReplaceAllString(cluster.PICS, "_") + "_" + strings.ReplaceAll(tc.number, ".", "_")
+}
+
+// pythonName is the name the SDK's Python cluster objects give a cluster or one of its elements
+func pythonName(name string) string {
+	return strcase.ToCamel(zap.CleanName(name))
+}
+
+// pythonFieldName is the name of a command or event field in the SDK's Python cluster objects
+func pythonFieldName(name string) string {
+	return strcase.ToLowerCamel(zap.CleanName(name))
+}
+
+type pythonTestCase struct {
+	number string
+	name   string
+	steps  []pythonStep
+}
+
+type pythonStep struct {
+	description string
+	expectation string
+	code        []string
+}
+
+func pythonTestCases(doc *spec.Doc, cluster *matter.Cluster) []*pythonTestCase {
+	cut := filterCluster(doc, cluster)
+
+	testCases := []*pythonTestCase{
+		globalAttributesPythonTest(cut),
+		attributesPythonTest(cut),
+	}
+	if len(cut.commandsAccepted) > 0 {
+		testCases = append(testCases, commandsPythonTest(cut))
+	}
+	if len(cut.events) > 0 {
+		testCases = append(testCases, eventsPythonTest(cut))
+	}
+	return testCases
+}
+
+func renderPythonTestCase(cluster *matter.Cluster, tc *pythonTestCase) string {
+	testName := pythonTestName(cluster, tc)
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(pythonHeader, time.Now().Year(), cluster.Name))
+	b.WriteString(fmt.Sprintf("class %s(MatterBaseTest):\n", testName))
+
+	b.WriteString(fmt.Sprintf("\n    def desc_%s(self) -> str:\n", testName))
+	b.WriteString(fmt.Sprintf("        return \"[TC-%s-%s] %s\"\n", cluster.PICS, tc.number, tc.name))
+
+	b.WriteString(fmt.Sprintf("\n    def pics_%s(self) -> list[str]:\n", testName))
+	b.WriteString(fmt.Sprintf("        return [\"%s.S\"]\n", cluster.PICS))
+
+	b.WriteString(fmt.Sprintf("\n    def steps_%s(self) -> list[TestStep]:\n", testName))
+	b.WriteString("        return [\n")
+	b.WriteString("            TestStep(1, \"Commissioning, already done\", is_commissioning=True),\n")
+	for i, s := range tc.steps {
+		b.WriteString(fmt.Sprintf("            TestStep(%d, %s", i+2, strconv.Quote(s.description)))
+		if s.expectation != "" {
+			b.WriteString(", ")
+			b.WriteString(strconv.Quote(s.expectation))
+		}
+		b.WriteString("),\n")
+	}
+	b.WriteString("        ]\n")
+
+	b.WriteString(fmt.Sprintf("\n    @async_test_body\n    async def test_%s(self):\n", testName))
+	b.WriteString(fmt.Sprintf("        cluster = Clusters.%s\n", pythonName(cluster.Name)))
+	b.WriteString("        endpoint = self.get_endpoint(default=1)\n\n")
+	b.WriteString("        self.step(1)\n")
+	for i, s := range tc.steps {
+		b.WriteString(fmt.Sprintf("\n        self.step(%d)\n", i+2))
+		for _, line := range s.code {
+			b.WriteString("        ")
+			b.WriteString(line)
+			b.WriteRune('\n')
+		}
+	}
+	b.WriteString("\n\nif __name__ == \"__main__\":\n    default_matter_test_main()\n")
+	return b.String()
+}
+
+var pythonHeader = `#
+#    Copyright (c) %d Project CHIP Authors
+#    All rights reserved.
+#
+#    Licensed under the Apache License, Version 2.0 (the "License");
+#    you may not use this file except in compliance with the License.
+#    You may obtain a copy of the License at
+#
+#        http://www.apache.org/licenses/LICENSE-2.0
+#
+#    Unless required by applicable law or agreed to in writing, software
+#    distributed under the License is distributed on an "AS IS" BASIS,
+#    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+#    See the License for the specific language governing permissions and
+#    limitations under the License.
+#
+
+# Skeleton generated from the %s cluster spec; the TODOs need filling in before it is run.
+
+import chip.clusters as Clusters
+from chip.clusters.Types import NullValue
+from matter_testing_support import (EventChangeCallback, MatterBaseTest, TestStep, async_test_body, default_matter_test_main,
+                                    type_matches)
+from mobly import asserts
+
+
+`
+
+func readAttributeCode(name string) string {
+	return fmt.Sprintf("await self.read_single_attribute_check_success(cluster=cluster, attribute=cluster.Attributes.%s, endpoint=endpoint)", name)
+}
+
+// withPICS puts lines of code under a check of the PICS that covers them
+func withPICS(pics string, code ...string) []string {
+	lines := []string{fmt.Sprintf("if self.check_pics(\"%s\"):", pics)}
+	for _, c := range code {
+		lines = append(lines, "    "+c)
+	}
+	return lines
+}
+
+func globalAttributesPythonTest(cut *clusterUnderTest) *pythonTestCase {
+	cluster := cut.cluster
+	tc := &pythonTestCase{number: "1.1", name: "Global Attributes with Server as DUT"}
+
+	revision := pythonStep{description: "TH reads the ClusterRevision attribute", code: []string{"revision = " + readAttributeCode("ClusterRevision")}}
+	if len(cluster.Revisions) > 0 {
+		number := cluster.Revisions[len(cluster.Revisions)-1].Number
+		revision.expectation = fmt.Sprintf("Verify that the value is %s", number)
+		revision.code = append(revision.code, fmt.Sprintf("asserts.assert_equal(revision, %s, \"Unexpected ClusterRevision\")", number))
+	}
+	tc.steps = append(tc.steps, revision)
+
+	featureMap := pythonStep{description: "TH reads the FeatureMap attribute", code: []string{"feature_map = " + readAttributeCode("FeatureMap")}}
+	if len(cut.features) > 0 {
+		featureMap.expectation = "Verify that the bits of the supported features are set"
+	} else {
+		featureMap.expectation = "Verify that the value is 0"
+		featureMap.code = append(featureMap.code, "asserts.assert_equal(feature_map, 0, \"Unexpected FeatureMap\")")
+	}
+	for _, f := range cut.features {
+		featureMap.code = append(featureMap.code, withPICS(picsCode(cluster, "S", f), fmt.Sprintf("asserts.assert_true(feature_map & cluster.Bitmaps.Feature.k%s, \"%s feature is not set\")", pythonName(f.Name()), f.Name()))...)
+	}
+	tc.steps = append(tc.steps, featureMap)
+
+	attributeList := pythonStep{description: "TH reads the AttributeList attribute", expectation: "Verify that the list has the supported attributes", code: []string{"attribute_list = " + readAttributeCode("AttributeList")}}
+	for _, a := range cut.attributes {
+		attributeList.code = append(attributeList.code, withPICS(picsCode(cluster, "S", a), fmt.Sprintf("asserts.assert_in(cluster.Attributes.%s.attribute_id, attribute_list, \"%s is missing from AttributeList\")", pythonName(a.Name), a.Name))...)
+	}
+	tc.steps = append(tc.steps, attributeList)
+
+	acceptedCommandList := pythonStep{description: "TH reads the AcceptedCommandList attribute", expectation: "Verify that the list has the commands the DUT receives", code: []string{"accepted_command_list = " + readAttributeCode("AcceptedCommandList")}}
+	for _, c := range cut.commandsAccepted {
+		acceptedCommandList.code = append(acceptedCommandList.code, withPICS(picsCode(cluster, "S", c), fmt.Sprintf("asserts.assert_in(cluster.Commands.%s.command_id, accepted_command_list, \"%s is missing from AcceptedCommandList\")", pythonName(c.Name), c.Name))...)
+	}
+	tc.steps = append(tc.steps, acceptedCommandList)
+
+	generatedCommandList := pythonStep{description: "TH reads the GeneratedCommandList attribute", expectation: "Verify that the list has the commands the DUT sends", code: []string{"generated_command_list = " + readAttributeCode("GeneratedCommandList")}}
+	for _, c := range cut.commandsGenerated {
+		generatedCommandList.code = append(generatedCommandList.code, withPICS(picsCode(cluster, "S", c), fmt.Sprintf("asserts.assert_in(cluster.Commands.%s.command_id, generated_command_list, \"%s is missing from GeneratedCommandList\")", pythonName(c.Name), c.Name))...)
+	}
+	tc.steps = append(tc.steps, generatedCommandList)
+	return tc
+}
+
+func attributesPythonTest(cut *clusterUnderTest) *pythonTestCase {
+	cluster := cut.cluster
+	tc := &pythonTestCase{number: "2.1", name: "Attributes with Server as DUT"}
+	for _, a := range cut.attributes {
+		checks, expectation := pythonConstraintChecks(a, cluster.Attributes, "val")
+		code := []string{"val = " + readAttributeCode(pythonName(a.Name))}
+		code = append(code, checks...)
+		tc.steps = append(tc.steps, pythonStep{
+			description: fmt.Sprintf("TH reads the %s attribute", a.Name),
+			expectation: expectation,
+			code:        withPICS(picsCode(cluster, "S", a), code...),
+		})
+	}
+	return tc
+}
+
+func commandsPythonTest(cut *clusterUnderTest) *pythonTestCase {
+	cluster := cut.cluster
+	tc := &pythonTestCase{number: "2.2", name: "Primary Functionality with Server as DUT"}
+	for _, c := range cut.commandsAccepted {
+		name := pythonName(c.Name)
+		var code []string
+		var call strings.Builder
+		hasResponse := c.Response != nil && c.Response.Name != "Y" && c.Response.Name != "N"
+		if hasResponse {
+			call.WriteString("response = ")
+		}
+		if len(c.Fields) == 0 {
+			call.WriteString(fmt.Sprintf("await self.send_single_cmd(cmd=cluster.Commands.%s()", name))
+		} else {
+			code = append(code, "# TODO: choose values for the command's fields")
+			call.WriteString(fmt.Sprintf("await self.send_single_cmd(cmd=cluster.Commands.%s(\n", name))
+			for _, f := range c.Fields {
+				call.WriteString(fmt.Sprintf("    %s=%s,  # %s\n", pythonFieldName(f.Name), pythonPlaceholder(cluster, f, c.Fields), pythonFieldComment(f)))
+			}
+			call.WriteString(")")
+		}
+		call.WriteString(", endpoint=endpoint")
+		if c.Access.IsTimed() {
+			call.WriteString(", timedRequestTimeoutMs=1000")
+		}
+		call.WriteString(")")
+		code = append(code, strings.Split(call.String(), "\n")...)
+		expectation := "Verify that the DUT responds with a success status"
+		if hasResponse {
+			expectation = fmt.Sprintf("Verify that the DUT responds with a %s command", c.Response.Name)
+			code = append(code, fmt.Sprintf("asserts.assert_true(type_matches(response, cluster.Commands.%s), \"Unexpected response to %s\")", pythonName(c.Response.Name), c.Name))
+		}
+		tc.steps = append(tc.steps, pythonStep{
+			description: fmt.Sprintf("TH sends the %s command", c.Name),
+			expectation: expectation,
+			code:        withPICS(picsCode(cluster, "S", c), code...),
+		})
+	}
+	return tc
+}
+
+func eventsPythonTest(cut *clusterUnderTest) *pythonTestCase {
+	cluster := cut.cluster
+	tc := &pythonTestCase{number: "2.3", name: "Events with Server as DUT"}
+	tc.steps = append(tc.steps, pythonStep{
+		description: fmt.Sprintf("TH subscribes to the %s cluster's events", cluster.Name),
+		code: []string{
+			"event_listener = EventChangeCallback(cluster)",
+			"await event_listener.start(self.default_controller, self.dut_node_id, endpoint)",
+		},
+	})
+	for _, e := range cut.events {
+		code := []string{
+			fmt.Sprintf("# TODO: make the DUT emit the %s event", e.Name),
+			fmt.Sprintf("event = event_listener.wait_for_event_report(cluster.Events.%s)", pythonName(e.Name)),
+		}
+		for _, f := range e.Fields {
+			checks, _ := pythonConstraintChecks(f, e.Fields, "event."+pythonFieldName(f.Name))
+			code = append(code, checks...)
+		}
+		tc.steps = append(tc.steps, pythonStep{
+			description: fmt.Sprintf("DUT emits the %s event", e.Name),
+			expectation: fmt.Sprintf("Verify that TH receives the %s event", e.Name),
+			code:        withPICS(picsCode(cluster, "S", e), code...),
+		})
+	}
+	return tc
+}
+
+// pythonConstraintChecks turns the numeric limits of a field's constraint into assertions on the value read; lists
+// and strings are limited by their length
+func pythonConstraintChecks(f *matter.Field, fs matter.FieldSet, value string) (checks []string, expectation string) {
+	if f.Type == nil || f.Constraint == nil {
+		return
+	}
+	cc := &matter.ConstraintContext{Field: f, Fields: fs}
+	min := f.Constraint.Min(cc)
+	max := f.Constraint.Max(cc)
+	if !min.IsNumeric() && !max.IsNumeric() {
+		return
+	}
+	subject := value
+	var what string
+	if f.Type.IsArray() || f.Type.HasLength() {
+		subject = "len(" + value + ")"
+		if f.Type.IsArray() {
+			what = "list has "
+		} else {
+			what = "value has a length of "
+		}
+	} else {
+		what = "value is "
+	}
+	var indent string
+	if f.Quality.Has(matter.QualityNullable) {
+		checks = append(checks, fmt.Sprintf("if %s is not NullValue:", value))
+		indent = "    "
+	}
+	switch {
+	case min.IsNumeric() && max.IsNumeric():
+		expectation = fmt.Sprintf("Verify that the %sbetween %s and %s", what, pythonNumber(min), pythonNumber(max))
+	case min.IsNumeric():
+		expectation = fmt.Sprintf("Verify that the %sat least %s", what, pythonNumber(min))
+	default:
+		expectation = fmt.Sprintf("Verify that the %sat most %s", what, pythonNumber(max))
+	}
+	if f.Type.IsArray() {
+		expectation += " entries"
+	}
+	if min.IsNumeric() {
+		checks = append(checks, fmt.Sprintf("%sasserts.assert_greater_equal(%s, %s, \"%s is below its minimum\")", indent, subject, pythonNumber(min), f.Name))
+	}
+	if max.IsNumeric() {
+		checks = append(checks, fmt.Sprintf("%sasserts.assert_less_equal(%s, %s, \"%s is above its maximum\")", indent, subject, pythonNumber(max), f.Name))
+	}
+	return
+}
+
+func pythonNumber(e types.DataTypeExtreme) string {
+	switch e.Type {
+	case types.DataTypeExtremeTypeInt64:
+		if e.Format == types.NumberFormatHex && e.Int64 >= 0 {
+			return fmt.Sprintf("0x%X", e.Int64)
+		}
+		return strconv.FormatInt(e.Int64, 10)
+	case types.DataTypeExtremeTypeUInt64:
+		if e.Format == types.NumberFormatHex {
+			return fmt.Sprintf("0x%X", e.UInt64)
+		}
+		return strconv.FormatUint(e.UInt64, 10)
+	}
+	return ""
+}
+
+// pythonPlaceholder is a value of the right type for a command field, for the test writer to replace
+func pythonPlaceholder(cluster *matter.Cluster, f *matter.Field, fs matter.FieldSet) string {
+	dt := f.Type
+	if dt == nil {
+		return "None"
+	}
+	if dt.IsArray() {
+		return "[]"
+	}
+	switch dt.BaseType {
+	case types.BaseDataTypeBoolean:
+		return "False"
+	case types.BaseDataTypeString:
+		return "\"\""
+	case types.BaseDataTypeOctStr:
+		return "b\"\""
+	case types.BaseDataTypeSingle, types.BaseDataTypeDouble:
+		return "0.0"
+	case types.BaseDataTypeCustom:
+		entity, ok := cluster.Identifier(dt.Name)
+		if !ok {
+			return "None"
+		}
+		switch entity := entity.(type) {
+		case *matter.Enum:
+			if len(entity.Values) > 0 {
+				return fmt.Sprintf("cluster.Enums.%s.k%s", pythonName(entity.Name), pythonName(entity.Values[0].Name))
+			}
+		case *matter.Bitmap:
+			return "0"
+		case *matter.Struct:
+			return fmt.Sprintf("cluster.Structs.%s()", pythonName(entity.Name))
+		}
+		return "None"
+	}
+	if f.Constraint != nil {
+		min := f.Constraint.Min(&matter.ConstraintContext{Field: f, Fields: fs})
+		if min.IsNumeric() {
+			return pythonNumber(min)
+		}
+	}
+	return "0"
+}
+
+func pythonFieldComment(f *matter.Field) string {
+	var parts []string
+	if f.Type != nil {
+		parts = append(parts, f.Type.Name)
+	}
+	if f.Constraint != nil {
+		if c := f.Constraint.ASCIIDocString(f.Type); c != "" && c != "all" {
+			parts = append(parts, c)
+		}
+	}
+	if f.Quality.Has(matter.QualityNullable) {
+		parts = append(parts, "nullable")
+	}
+	if !conformance.IsMandatory(f.Conformance) {
+		parts = append(parts, "optional")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,128 @@
+package testplan
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestPythonPaths(t *testing.T) {
+	doc, cluster := readTestCluster(t, "testdata/pics", "src/app_clusters/Sprocket.adoc")
+	var names []string
+	for _, tc := range pythonTestCases(doc, cluster) {
+		path := getPythonPath("tests", cluster, tc)
+		names = append(names, filepath.Base(path))
+		out := renderPythonTestCase(cluster, tc)
+		name := strings.TrimSuffix(filepath.Base(path), ".py")
+		for _, s := range []string{"class " + name + "(MatterBaseTest):", "def desc_" + name + "(", "def steps_" + name + "(", "async def test_" + name + "("} {
+			if !strings.Contains(out, s) {
+				t.Errorf("expected %s to contain %q, got:\n%s", path, s, out)
+			}
+		}
+	}
+	expected := []string{"TC_SPKT_1_1.py", "TC_SPKT_2_1.py", "TC_SPKT_2_2.py", "TC_SPKT_2_3.py"}
+	if !slices.Equal(names, expected) {
+		t.Errorf("expected Python tests %v, got %v", expected, names)
+	}
+}
+
+func TestPythonConstraintChecks(t *testing.T) {
+	tests := []struct {
+		typeName    string
+		isArray     bool
+		constraint  string
+		nullable    bool
+		checks      []string
+		expectation string
+	}{
+		{"uint8", false, "all", false, nil, ""},
+		{"uint8", false, "desc", false, nil, ""},
+		{"uint8", false, "max 100", false, []string{
+			`asserts.assert_less_equal(val, 100, "Value is above its maximum")`,
+		}, "Verify that the value is at most 100"},
+		{"int16", false, "min -5", false, []string{
+			`asserts.assert_greater_equal(val, -5, "Value is below its minimum")`,
+		}, "Verify that the value is at least -5"},
+		{"uint16", false, "0x10 to 0xFF", true, []string{
+			`if val is not NullValue:`,
+			`    asserts.assert_greater_equal(val, 0x10, "Value is below its minimum")`,
+			`    asserts.assert_less_equal(val, 0xFF, "Value is above its maximum")`,
+		}, "Verify that the value is between 0x10 and 0xFF"},
+		{"string", false, "max 32", false, []string{
+			`asserts.assert_less_equal(len(val), 32, "Value is above its maximum")`,
+		}, "Verify that the value has a length of at most 32"},
+		{"uint8", true, "max 4", false, []string{
+			`asserts.assert_less_equal(len(val), 4, "Value is above its maximum")`,
+		}, "Verify that the list has at most 4 entries"},
+	}
+	for _, tt := range tests {
+		f := pythonTestField(t, tt.typeName, tt.isArray, tt.constraint, tt.nullable)
+		checks, expectation := pythonConstraintChecks(f, matter.FieldSet{f}, "val")
+		if !slices.Equal(checks, tt.checks) || expectation != tt.expectation {
+			t.Errorf("unexpected checks for %s %q:\n%s\n%q\nexpected:\n%s\n%q", tt.typeName, tt.constraint, strings.Join(checks, "\n"), expectation, strings.Join(tt.checks, "\n"), tt.expectation)
+		}
+	}
+}
+
+func TestPythonPlaceholder(t *testing.T) {
+	cluster := &matter.Cluster{}
+	e := matter.NewEnum(nil)
+	e.Name = "ModeEnum"
+	v := matter.NewEnumValue(nil)
+	v.Name = "Fast Spin"
+	e.Values = append(e.Values, v)
+	cluster.Enums = append(cluster.Enums, e)
+	bm := matter.NewBitmap(nil)
+	bm.Name = "OptionsBitmap"
+	cluster.Bitmaps = append(cluster.Bitmaps, bm)
+	s := matter.NewStruct(nil)
+	s.Name = "LimitStruct"
+	cluster.Structs = append(cluster.Structs, s)
+
+	tests := []struct {
+		typeName    string
+		isArray     bool
+		constraint  string
+		placeholder string
+	}{
+		{"bool", false, "", "False"},
+		{"string", false, "max 32", `""`},
+		{"octstr", false, "", `b""`},
+		{"single", false, "", "0.0"},
+		{"uint8", true, "", "[]"},
+		{"uint8", false, "all", "0"},
+		{"uint8", false, "5 to 10", "5"},
+		{"ModeEnum", false, "", "cluster.Enums.ModeEnum.kFastSpin"},
+		{"OptionsBitmap", false, "", "0"},
+		{"LimitStruct", false, "", "cluster.Structs.LimitStruct()"},
+		{"MissingEnum", false, "", "None"},
+	}
+	for _, tt := range tests {
+		f := pythonTestField(t, tt.typeName, tt.isArray, tt.constraint, false)
+		if placeholder := pythonPlaceholder(cluster, f, matter.FieldSet{f}); placeholder != tt.placeholder {
+			t.Errorf("expected placeholder for %s to be %s, got %s", tt.typeName, tt.placeholder, placeholder)
+		}
+	}
+}
+
+func pythonTestField(t *testing.T, typeName string, isArray bool, c string, nullable bool) *matter.Field {
+	f := matter.NewField(nil)
+	f.Name = "Value"
+	f.Type = types.ParseDataType(typeName, isArray)
+	if c != "" {
+		var err error
+		f.Constraint, err = constraint.ParseString(c)
+		if err != nil {
+			t.Fatalf("failed parsing constraint %q: %v", c, err)
+		}
+	}
+	if nullable {
+		f.Quality = matter.QualityNullable
+	}
+	return f
+}
@@ -22,6 +22,9 @@ type Configurator struct {
 	Clusters map[*matter.Cluster]bool
 	Structs  map[*matter.Struct][]*matter.Number
 
+	// ClusterExtensions are vendor extensions of standard clusters, rendered as clusterExtension elements
+	ClusterExtensions map[*matter.Cluster]bool
+
 	ClusterIDs []string
 }
 
@@ -35,6 +38,8 @@ func NewConfigurator(spec *spec.Specification, doc *spec.Doc, entities []types.E
 		Enums:    make(map[*matter.Enum][]*matter.Number),
 		Clusters: make(map[*matter.Cluster]bool),
 		Structs:  make(map[*matter.Struct][]*matter.Number),
+
+		ClusterExtensions: make(map[*matter.Cluster]bool),
 	}
 	for _, m := range entities {
 		switch v := m.(type) {
@@ -109,6 +114,10 @@ func (c *Configurator) addCluster(parentEntity types.Entity, v *matter.Cluster,
 			return
 		}
 	}
+	if _, ok := c.Spec.ClusterExtensions[v]; ok {
+		c.ClusterExtensions[v] = false
+		return
+	}
 	c.Clusters[v] = false
 }
 
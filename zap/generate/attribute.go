@@ -86,6 +86,7 @@ func (tg *TemplateGenerator) generateAttributes(configurator *zap.Configurator,
 
 func (tg *TemplateGenerator) populateAttribute(configurator *zap.Configurator, ae *etree.Element, attribute *matter.Field, cluster *matter.Cluster, clusterPrefix string, errata *errata.ZAP) (err error) {
 	patchNumberAttribute(ae, attribute.ID, "code")
+	patchManufacturerCode(ae, attribute.ID)
 	ae.CreateAttr("side", "server")
 	define := getDefine(attribute.Name, clusterPrefix, errata)
 	xml.SetNonexistentAttr(ae, "define", define)
@@ -196,10 +197,7 @@ func writeAttributeDataType(x *etree.Element, fs matter.FieldSet, f *matter.Fiel
 }
 
 func getDefine(name string, prefix string, errata *errata.ZAP) string {
-	define := strcase.ToScreamingDelimited(cleanAcronyms(name), '_', "", true)
-	if !strings.HasPrefix(define, prefix) {
-		define = prefix + define
-	}
+	define := baseDefine(name, prefix)
 	if errata.DefineOverrides != nil {
 		if override, ok := errata.DefineOverrides[define]; ok {
 			return override
@@ -208,6 +206,15 @@ func getDefine(name string, prefix string, errata *errata.ZAP) string {
 	return define
 }
 
+// baseDefine returns the define for name before any errata override; overrides are keyed by it
+func baseDefine(name string, prefix string) string {
+	define := strcase.ToScreamingDelimited(cleanAcronyms(name), '_', "", true)
+	if !strings.HasPrefix(define, prefix) {
+		define = prefix + define
+	}
+	return define
+}
+
 var acronymPattern = regexp.MustCompile(`[A-Z]{2,}`)
 
 func cleanAcronyms(s string) string {
@@ -2,6 +2,8 @@ package generate
 
 import (
 	"log/slog"
+	"maps"
+	"slices"
 	"strconv"
 
 	"github.com/beevik/etree"
@@ -83,30 +85,76 @@ func (tg *TemplateGenerator) renderClusters(configurator *zap.Configurator, ce *
 			return
 		}
 	}
-	return
+	return tg.renderClusterExtensions(configurator, ce, errata)
 }
 
-func (tg *TemplateGenerator) populateCluster(configurator *zap.Configurator, cle *etree.Element, cluster *matter.Cluster, errata *errata.ZAP) (err error) {
+func (tg *TemplateGenerator) renderClusterExtensions(configurator *zap.Configurator, ce *etree.Element, errata *errata.ZAP) (err error) {
+	extensions := sortedClusterExtensions(configurator)
+	for _, cee := range ce.SelectElements("clusterExtension") {
+		code := cee.SelectAttr("code")
+		if code == nil {
+			slog.Warn("clusterExtension element with no code attribute", log.Path("path", configurator.Doc.Path))
+			continue
+		}
+		clusterID := matter.ParseNumber(code.Value)
+		var extension *matter.Cluster
+		for _, c := range extensions {
+			if !configurator.ClusterExtensions[c] && c.ID.Equals(clusterID) {
+				extension = c
+				configurator.ClusterExtensions[c] = true
+				break
+			}
+		}
+		if extension == nil {
+			// We don't have this extension in the vendor docs; leave it here for now
+			slog.Warn("unknown code ID in clusterExtension", log.Path("path", configurator.Doc.Path), slog.String("id", code.Value))
+			continue
+		}
+		err = tg.populateClusterExtension(configurator, cee, extension, errata)
+		if err != nil {
+			return
+		}
+	}
+	for _, extension := range extensions {
+		if configurator.ClusterExtensions[extension] {
+			continue
+		}
+		cee := etree.NewElement("clusterExtension")
+		xml.AppendElement(ce, cee, "clusterExtension", "cluster", "struct", "enum", "bitmap", "domain")
+		err = tg.populateClusterExtension(configurator, cee, extension, errata)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
 
-	var define string
-	var clusterPrefix string
+// sortedClusterExtensions returns the configurator's cluster extensions in ID order
+func sortedClusterExtensions(configurator *zap.Configurator) []*matter.Cluster {
+	extensions := slices.Collect(maps.Keys(configurator.ClusterExtensions))
+	slices.SortStableFunc(extensions, func(a, b *matter.Cluster) int { return a.ID.Compare(b.ID) })
+	return extensions
+}
 
-	define = getDefine(cluster.Name+" Cluster", "", errata)
-	if len(errata.ClusterDefinePrefix) > 0 {
-		clusterPrefix = errata.ClusterDefinePrefix
+func (tg *TemplateGenerator) populateClusterExtension(configurator *zap.Configurator, cee *etree.Element, extension *matter.Cluster, errata *errata.ZAP) (err error) {
+	patchNumberAttribute(cee, extension.ID, "code")
+	attributes, events, commands := clusterElements(extension)
+	err = tg.generateAttributes(configurator, cee, extension, attributes, errata.ClusterDefinePrefix, errata)
+	if err != nil {
+		return
 	}
-
-	if cluster.Conformance != nil {
-		if conformance.IsProvisional(cluster.Conformance) {
-			cle.CreateAttr("apiMaturity", "provisional")
-		} else {
-			cle.RemoveAttr("apiMaturity")
-		}
+	err = tg.generateCommands(configurator, commands, configurator.Doc.Path.Relative, cee, extension, errata)
+	if err != nil {
+		return
 	}
+	err = tg.generateEvents(configurator, cee, extension, events, errata)
+	return
+}
 
-	attributes := make(map[*matter.Field]struct{})
-	events := make(map[*matter.Event]struct{})
-	commands := make(map[*matter.Command][]*matter.Number)
+func clusterElements(cluster *matter.Cluster) (attributes map[*matter.Field]struct{}, events map[*matter.Event]struct{}, commands map[*matter.Command][]*matter.Number) {
+	attributes = make(map[*matter.Field]struct{})
+	events = make(map[*matter.Event]struct{})
+	commands = make(map[*matter.Command][]*matter.Number)
 
 	for _, a := range cluster.Attributes {
 		attributes[a] = struct{}{}
@@ -122,6 +170,28 @@ func (tg *TemplateGenerator) populateCluster(configurator *zap.Configurator, cle
 		}
 		commands[c] = []*matter.Number{}
 	}
+	return
+}
+
+func (tg *TemplateGenerator) populateCluster(configurator *zap.Configurator, cle *etree.Element, cluster *matter.Cluster, errata *errata.ZAP) (err error) {
+
+	var define string
+	var clusterPrefix string
+
+	define = getDefine(cluster.Name+" Cluster", "", errata)
+	if len(errata.ClusterDefinePrefix) > 0 {
+		clusterPrefix = errata.ClusterDefinePrefix
+	}
+
+	if cluster.Conformance != nil {
+		if conformance.IsProvisional(cluster.Conformance) {
+			cle.CreateAttr("apiMaturity", "provisional")
+		} else {
+			cle.RemoveAttr("apiMaturity")
+		}
+	}
+
+	attributes, events, commands := clusterElements(cluster)
 
 	de := xml.SetOrCreateSimpleElement(cle, "domain", "")
 	de.CreateAttr("name", matter.DomainNames[configurator.Doc.Domain])
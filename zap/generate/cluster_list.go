@@ -11,6 +11,7 @@ import (
 	"github.com/iancoleman/orderedmap"
 	"github.com/iancoleman/strcase"
 	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/spec"
 )
 
@@ -47,11 +48,11 @@ func (p ClusterListPatcher) Process(cxt context.Context, inputs []*pipeline.Data
 
 	var names []string
 	for _, input := range inputs {
-		doc := input.Content
-		path := doc.Path
-		name := strings.TrimSuffix(path.Base(), path.Ext()) + " Cluster"
-		name = strcase.ToScreamingSnake(name)
-		names = append(names, name)
+		if extensionDoc(input.Content) {
+			// Extensions add to clusters that are already listed
+			continue
+		}
+		names = append(names, clusterListName(input.Content))
 	}
 
 	err = insertClusterName(o, "ClientDirectories", names)
@@ -72,14 +73,58 @@ func (p ClusterListPatcher) Process(cxt context.Context, inputs []*pipeline.Data
 	return
 }
 
-func insertClusterName(o *orderedmap.OrderedMap, key string, names []string) error {
+// extensionDoc returns true if every cluster in the doc is a vendor extension of a standard cluster
+func extensionDoc(doc *spec.Doc) bool {
+	if doc.Spec() == nil {
+		return false
+	}
+	entities, err := doc.Entities()
+	if err != nil {
+		return false
+	}
+	var extensions int
+	for _, e := range entities {
+		var clusters []*matter.Cluster
+		switch e := e.(type) {
+		case *matter.ClusterGroup:
+			clusters = e.Clusters
+		case *matter.Cluster:
+			clusters = []*matter.Cluster{e}
+		}
+		for _, c := range clusters {
+			if _, ok := doc.Spec().ClusterExtensions[c]; !ok {
+				return false
+			}
+			extensions++
+		}
+	}
+	return extensions > 0
+}
+
+// clusterListName returns the name a cluster doc is listed under in zap_cluster_list.json
+func clusterListName(doc *spec.Doc) string {
+	path := doc.Path
+	name := strings.TrimSuffix(path.Base(), path.Ext()) + " Cluster"
+	return strcase.ToScreamingSnake(name)
+}
+
+func clusterDirectories(o *orderedmap.OrderedMap, key string) (is orderedmap.OrderedMap, err error) {
 	val, ok := o.Get(key)
 	if !ok {
-		return fmt.Errorf("no %s field in zap_cluster_list.json", key)
+		err = fmt.Errorf("no %s field in zap_cluster_list.json", key)
+		return
 	}
-	is, ok := val.(orderedmap.OrderedMap)
+	is, ok = val.(orderedmap.OrderedMap)
 	if !ok {
-		return fmt.Errorf("%s not a map in zap_cluster_list.json; %T", key, val)
+		err = fmt.Errorf("%s not a map in zap_cluster_list.json; %T", key, val)
+	}
+	return
+}
+
+func insertClusterName(o *orderedmap.OrderedMap, key string, names []string) error {
+	is, err := clusterDirectories(o, key)
+	if err != nil {
+		return err
 	}
 	var insertedNames []string
 	for _, name := range names {
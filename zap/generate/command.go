@@ -90,6 +90,7 @@ func (tg *TemplateGenerator) populateCommand(configurator *zap.Configurator, ce
 		serverSource = true
 	}
 	ce.CreateAttr("code", c.ID.ShortHexString())
+	patchManufacturerCode(ce, c.ID)
 	ce.CreateAttr("name", zap.CleanName(c.Name))
 	if c.Access.IsFabricScoped() {
 		ce.CreateAttr("isFabricScoped", "true")
@@ -101,7 +101,7 @@ func xmlToString(x *etree.Document) (string, error) {
 	return s, nil
 }
 
-var tagClosePattern = regexp.MustCompile(`(?m)/(?P<Tag>bitmap|cluster|command|enum|event|struct)>\n(\s+)<`)
+var tagClosePattern = regexp.MustCompile(`(?m)/(?P<Tag>bitmap|cluster|clusterExtension|command|enum|event|struct)>\n(\s+)<`)
 
 func postProcessTemplate(s string) string {
 	// etree removes extraneous whitespace between tags, so this restores it for commonly separated tags in ZAP templates
@@ -18,7 +18,7 @@ func removeConformance(parent *etree.Element) {
 		switch child := child.(type) {
 		case *etree.Element:
 			switch child.Tag {
-			case "mandatoryConform", "optionalConform", "disableConform", "provisionalConform", "deprecateConform", "otherwiseConform":
+			case "mandatoryConform", "optionalConform", "disallowConform", "provisionalConform", "deprecateConform", "describedConform", "otherwiseConform":
 				trash = append(trash, child)
 			}
 		}
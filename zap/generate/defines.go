@@ -0,0 +1,497 @@
+package generate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/iancoleman/strcase"
+	"github.com/project-chip/alchemy/errata"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/zap"
+)
+
+type DefineKind string
+
+const (
+	DefineKindCluster   DefineKind = "cluster define"
+	DefineKindAttribute DefineKind = "attribute define"
+	DefineKindCommand   DefineKind = "command name"
+	DefineKindBitmap    DefineKind = "bitmap name"
+	DefineKindEnum      DefineKind = "enum name"
+	DefineKindStruct    DefineKind = "struct name"
+)
+
+// Define is a C define or type name a ZAP template declares
+type Define struct {
+	Kind DefineKind
+	// Name is what the template will declare; existing cluster and attribute defines are kept, so this is the existing
+	// define if there is one
+	Name string
+	// Generated is the name derived from the spec and errata
+	Generated string
+	// Existing is the name in the SDK's template, if it differs from Generated
+	Existing string
+
+	ID      *matter.Number
+	Cluster *matter.Cluster
+	// ClusterIDs are the clusters a type is scoped to; global types have none
+	ClusterIDs []*matter.Number
+	Entity     types.Entity
+
+	DocPath  string
+	Template string
+}
+
+func (d *Define) String() string {
+	var sb strings.Builder
+	sb.WriteString(string(d.Kind))
+	sb.WriteRune(' ')
+	sb.WriteString(d.Name)
+	if d.Cluster != nil {
+		fmt.Fprintf(&sb, " in %s", d.Cluster.Name)
+	}
+	if d.ID != nil && d.ID.Valid() {
+		fmt.Fprintf(&sb, " (%s)", d.ID.HexString())
+	}
+	if d.DocPath != "" {
+		fmt.Fprintf(&sb, " from %s", d.DocPath)
+	} else if d.Template != "" {
+		fmt.Fprintf(&sb, " in %s", filepath.Base(d.Template))
+	}
+	return sb.String()
+}
+
+// DefineCollision is a name declared by elements that can't share it
+type DefineCollision struct {
+	Kind    DefineKind
+	Name    string
+	Defines []*Define
+}
+
+// DefineReporter computes every cluster define, attribute define, command name and type name the ZAP templates
+// would declare, without rendering them, and finds the ones that collide or differ from the SDK's templates
+type DefineReporter struct {
+	sdkRoot string
+
+	Defines    []*Define
+	Collisions []*DefineCollision
+	// Suggestions are errata that would resolve the collisions and differences, by doc path
+	Suggestions map[string]*errata.ZAP
+}
+
+func NewDefineReporter(sdkRoot string) *DefineReporter {
+	return &DefineReporter{sdkRoot: sdkRoot, Suggestions: make(map[string]*errata.ZAP)}
+}
+
+func (p *DefineReporter) Name() string {
+	return "Computing ZAP defines"
+}
+
+func (p *DefineReporter) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeCollective
+}
+
+func (p *DefineReporter) Process(cxt context.Context, inputs []*pipeline.Data[*spec.Doc]) (outputs []*pipeline.Data[struct{}], err error) {
+	slices.SortFunc(inputs, func(a, b *pipeline.Data[*spec.Doc]) int { return strings.Compare(a.Path, b.Path) })
+	var s *spec.Specification
+	for _, input := range inputs {
+		doc := input.Content
+		if s == nil {
+			s = doc.Spec()
+		}
+		var entities []types.Entity
+		entities, err = doc.Entities()
+		if err != nil {
+			return
+		}
+		errata := errata.GetZAP(doc.Path.Relative)
+		if errata.SkipFile {
+			continue
+		}
+		destinations := ZAPTemplateDestinations(p.sdkRoot, doc.Path.Relative, entities, errata)
+		paths := make([]string, 0, len(destinations))
+		for path := range destinations {
+			paths = append(paths, path)
+		}
+		slices.Sort(paths)
+		for _, path := range paths {
+			if len(destinations[path]) == 0 {
+				continue
+			}
+			var configurator *zap.Configurator
+			configurator, err = zap.NewConfigurator(doc.Spec(), doc, destinations[path], path, errata)
+			if err != nil {
+				return
+			}
+			var existing *etree.Element
+			existing, err = readTemplate(path)
+			if err != nil {
+				return
+			}
+			p.addClusterDefines(configurator, existing, errata)
+			addTypeDefines(p, DefineKindBitmap, configurator.Bitmaps, "bitmap", "Bitmap", configurator, existing, errata)
+			addTypeDefines(p, DefineKindEnum, configurator.Enums, "enum", "Enum", configurator, existing, errata)
+			addTypeDefines(p, DefineKindStruct, configurator.Structs, "struct", "Struct", configurator, existing, errata)
+		}
+	}
+	if s != nil {
+		p.addGlobalDefines(s)
+	}
+	p.findCollisions()
+	return
+}
+
+func readTemplate(path string) (*etree.Element, error) {
+	doc := etree.NewDocument()
+	err := doc.ReadFromFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed reading ZAP template %s: %w", path, err)
+	}
+	return doc.SelectElement("configurator"), nil
+}
+
+func (p *DefineReporter) addClusterDefines(configurator *zap.Configurator, existing *etree.Element, errata *errata.ZAP) {
+	clusters := make([]*matter.Cluster, 0, len(configurator.Clusters)+len(configurator.ClusterExtensions))
+	for c := range configurator.Clusters {
+		clusters = append(clusters, c)
+	}
+	standard := len(clusters)
+	slices.SortStableFunc(clusters, func(a, b *matter.Cluster) int { return strings.Compare(a.Name, b.Name) })
+	clusters = append(clusters, sortedClusterExtensions(configurator)...)
+	for i, cluster := range clusters {
+		var ce *etree.Element
+		if i < standard {
+			ce = findExistingCluster(existing, "cluster", cluster.ID)
+			base := baseDefine(cluster.Name+" Cluster", "")
+			d := p.newDefine(configurator, DefineKindCluster, cluster, cluster.ID, getDefine(cluster.Name+" Cluster", "", errata), selectText(ce, "define"))
+			p.suggestDefineOverride(configurator, d, base)
+		} else {
+			ce = findExistingCluster(existing, "clusterExtension", cluster.ID)
+		}
+		for _, a := range cluster.Attributes {
+			if conformance.IsZigbee(cluster, a.Conformance) || conformance.IsDeprecated(a.Conformance) || conformance.IsDisallowed(a.Conformance) {
+				continue
+			}
+			if !a.ID.Valid() || matter.NonGlobalIDInvalidForEntity(a.ID, types.EntityTypeAttribute) {
+				continue
+			}
+			var existingDefine string
+			if ae := findElementByCode(ce, "attribute", a.ID, ""); ae != nil {
+				existingDefine = ae.SelectAttrValue("define", "")
+			}
+			d := p.newDefine(configurator, DefineKindAttribute, cluster, a.ID, getDefine(a.Name, errata.ClusterDefinePrefix, errata), existingDefine)
+			d.Entity = a
+			p.suggestDefineOverride(configurator, d, baseDefine(a.Name, errata.ClusterDefinePrefix))
+		}
+		_, _, commands := clusterElements(cluster)
+		for _, c := range cluster.Commands {
+			if _, ok := commands[c]; !ok || !c.ID.Valid() {
+				continue
+			}
+			source := "client"
+			if c.Direction == matter.InterfaceClient {
+				source = "server"
+			}
+			var existingName string
+			if ce := findElementByCode(ce, "command", c.ID, source); ce != nil {
+				existingName = ce.SelectAttrValue("name", "")
+			}
+			d := p.newDefine(configurator, DefineKindCommand, cluster, c.ID, zap.CleanName(c.Name), "")
+			d.Entity = c
+			if existingName != "" && existingName != d.Generated {
+				// Command names are always overwritten, and there's no errata for them
+				d.Existing = existingName
+			}
+		}
+	}
+}
+
+func addTypeDefines[T interface {
+	*matter.Bitmap | *matter.Enum | *matter.Struct
+	types.Entity
+}](p *DefineReporter, kind DefineKind, entities map[T][]*matter.Number, element string, suffix string, configurator *zap.Configurator, existing *etree.Element, errata *errata.ZAP) {
+	type namedEntity struct {
+		entity T
+		name   string
+	}
+	var named []namedEntity
+	for e, clusterIDs := range entities {
+		if len(clusterIDs) == 0 {
+			// The template doesn't declare types that belong to other clusters
+			continue
+		}
+		named = append(named, namedEntity{entity: e, name: typeEntityName(e)})
+	}
+	slices.SortFunc(named, func(a, b namedEntity) int { return strings.Compare(a.name, b.name) })
+	for _, ne := range named {
+		d := p.newDefine(configurator, kind, nil, nil, errata.TypeName(ne.name), "")
+		d.Entity = ne.entity
+		d.ClusterIDs = entities[ne.entity]
+		if existing == nil {
+			continue
+		}
+		for _, te := range existing.SelectElements(element) {
+			name := te.SelectAttrValue("name", "")
+			if errata.TypeName(ne.name) != name && errata.TypeName(strings.TrimSuffix(ne.name, suffix)) != name {
+				continue
+			}
+			if name != d.Generated {
+				// The generator renames existing types, so keeping the existing name needs an errata entry
+				d.Existing = name
+				p.suggestion(configurator.Doc.Path.Relative).TypeNames = setSuggestion(p.suggestion(configurator.Doc.Path.Relative).TypeNames, ne.name, name)
+			}
+			break
+		}
+	}
+}
+
+func typeEntityName(e types.Entity) string {
+	switch e := e.(type) {
+	case *matter.Bitmap:
+		return e.Name
+	case *matter.Enum:
+		return e.Name
+	case *matter.Struct:
+		return e.Name
+	}
+	return ""
+}
+
+// addGlobalDefines adds the types in the global object templates, which aren't generated from any one doc
+func (p *DefineReporter) addGlobalDefines(s *spec.Specification) {
+	var defines []*Define
+	for o := range s.GlobalObjects {
+		var kind DefineKind
+		var template string
+		switch o.(type) {
+		case *matter.Bitmap:
+			kind, template = DefineKindBitmap, "global-bitmaps"
+		case *matter.Enum:
+			kind, template = DefineKindEnum, "global-enums"
+		case *matter.Struct:
+			kind, template = DefineKindStruct, "global-structs"
+		default:
+			continue
+		}
+		name := typeEntityName(o)
+		defines = append(defines, &Define{Kind: kind, Name: name, Generated: name, Entity: o, Template: getZapPath(p.sdkRoot, template)})
+	}
+	slices.SortFunc(defines, func(a, b *Define) int { return strings.Compare(a.Name, b.Name) })
+	p.Defines = append(p.Defines, defines...)
+}
+
+func (p *DefineReporter) newDefine(configurator *zap.Configurator, kind DefineKind, cluster *matter.Cluster, id *matter.Number, generated string, existing string) *Define {
+	d := &Define{Kind: kind, Name: generated, Generated: generated, ID: id, Cluster: cluster, DocPath: configurator.Doc.Path.Relative, Template: configurator.OutPath}
+	if existing != "" {
+		d.Name = existing
+		if existing != generated {
+			d.Existing = existing
+		}
+	}
+	p.Defines = append(p.Defines, d)
+	return d
+}
+
+// suggestDefineOverride suggests an override that makes the generated define match the existing one
+func (p *DefineReporter) suggestDefineOverride(configurator *zap.Configurator, d *Define, base string) {
+	if d.Existing == "" {
+		return
+	}
+	s := p.suggestion(configurator.Doc.Path.Relative)
+	s.DefineOverrides = setSuggestion(s.DefineOverrides, base, d.Existing)
+}
+
+func (p *DefineReporter) suggestion(path string) *errata.ZAP {
+	s, ok := p.Suggestions[path]
+	if !ok {
+		s = &errata.ZAP{}
+		p.Suggestions[path] = s
+	}
+	return s
+}
+
+func setSuggestion(m map[string]string, key string, value string) map[string]string {
+	if m == nil {
+		m = make(map[string]string)
+	}
+	m[key] = value
+	return m
+}
+
+func (p *DefineReporter) findCollisions() {
+	type key struct {
+		kind DefineKind
+		name string
+	}
+	var keys []key
+	byName := make(map[key][]*Define)
+	for _, d := range p.Defines {
+		k := key{kind: d.Kind, name: d.Name}
+		if d.Kind == DefineKindBitmap || d.Kind == DefineKindEnum || d.Kind == DefineKindStruct {
+			// Bitmaps, enums and structs share a namespace
+			k.kind = "type name"
+		}
+		if _, ok := byName[k]; !ok {
+			keys = append(keys, k)
+		}
+		byName[k] = append(byName[k], d)
+	}
+	for _, k := range keys {
+		defines := byName[k]
+		if len(defines) < 2 {
+			continue
+		}
+		var collides bool
+		for i, a := range defines {
+			for _, b := range defines[i+1:] {
+				if definesCollide(a, b) {
+					collides = true
+				}
+			}
+		}
+		if !collides {
+			continue
+		}
+		p.Collisions = append(p.Collisions, &DefineCollision{Kind: k.kind, Name: k.name, Defines: defines})
+		p.suggestCollisionFix(defines)
+	}
+}
+
+func definesCollide(a *Define, b *Define) bool {
+	switch a.Kind {
+	case DefineKindCluster:
+		return a.Cluster != b.Cluster
+	case DefineKindAttribute:
+		// Clusters derived from the same base share attribute defines; that's only a problem if the IDs differ, or
+		// the cluster declares the define twice
+		return !a.ID.Equals(b.ID) || a.Cluster == b.Cluster
+	case DefineKindCommand:
+		return a.Cluster == b.Cluster && a.Entity != b.Entity
+	default:
+		if a.Entity == b.Entity {
+			return false
+		}
+		// Types scoped to different clusters can share a name; global types can't share one with anything
+		if len(a.ClusterIDs) == 0 || len(b.ClusterIDs) == 0 {
+			return true
+		}
+		for _, ac := range a.ClusterIDs {
+			for _, bc := range b.ClusterIDs {
+				if ac.Equals(bc) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// suggestCollisionFix suggests errata for every colliding define but one, preferring to leave a define that's already
+// in the SDK alone
+func (p *DefineReporter) suggestCollisionFix(defines []*Define) {
+	keep := defines[0]
+	for _, d := range defines {
+		if exists, _ := files.Exists(d.Template); exists {
+			keep = d
+			break
+		}
+	}
+	for _, d := range defines {
+		if d == keep || d.DocPath == "" {
+			continue
+		}
+		errata := errata.GetZAP(d.DocPath)
+		s := p.suggestion(d.DocPath)
+		switch d.Kind {
+		case DefineKindCluster:
+			docName := strcase.ToScreamingSnake(strings.TrimSuffix(filepath.Base(d.DocPath), filepath.Ext(d.DocPath)))
+			s.DefineOverrides = setSuggestion(s.DefineOverrides, baseDefine(d.Cluster.Name+" Cluster", ""), docName+"_"+d.Generated)
+		case DefineKindAttribute:
+			if errata.ClusterDefinePrefix == "" && d.Cluster != nil {
+				s.ClusterDefinePrefix = strings.TrimSuffix(baseDefine(d.Cluster.Name+" Cluster", ""), "CLUSTER")
+			}
+		case DefineKindBitmap, DefineKindEnum, DefineKindStruct:
+			name := typeEntityName(d.Entity)
+			var clusterName string
+			if d.Cluster != nil {
+				clusterName = d.Cluster.Name
+			} else if c := p.typeCluster(d); c != nil {
+				clusterName = c.Name
+			}
+			if clusterName != "" {
+				s.TypeNames = setSuggestion(s.TypeNames, name, zap.CleanName(clusterName)+d.Generated)
+			}
+		}
+	}
+}
+
+// typeCluster finds the cluster a type is scoped to among the clusters declared by the same template
+func (p *DefineReporter) typeCluster(d *Define) *matter.Cluster {
+	if len(d.ClusterIDs) == 0 {
+		return nil
+	}
+	for _, o := range p.Defines {
+		if o.Kind == DefineKindCluster && o.Template == d.Template && o.ID.Equals(d.ClusterIDs[0]) {
+			return o.Cluster
+		}
+	}
+	return nil
+}
+
+func findExistingCluster(root *etree.Element, tag string, id *matter.Number) *etree.Element {
+	if root == nil {
+		return nil
+	}
+	for _, ce := range root.SelectElements(tag) {
+		var code string
+		if tag == "clusterExtension" {
+			code = ce.SelectAttrValue("code", "")
+		} else {
+			code = selectText(ce, "code")
+		}
+		if matter.ParseNumber(code).Equals(id) {
+			return ce
+		}
+	}
+	return nil
+}
+
+func findElementByCode(parent *etree.Element, tag string, id *matter.Number, source string) *etree.Element {
+	if parent == nil {
+		return nil
+	}
+	for _, e := range parent.SelectElements(tag) {
+		if !matter.ParseNumber(e.SelectAttrValue("code", "")).Equals(id) {
+			continue
+		}
+		if source != "" && e.SelectAttrValue("source", source) != source {
+			continue
+		}
+		return e
+	}
+	return nil
+}
+
+func selectText(parent *etree.Element, tag string) string {
+	if parent == nil {
+		return ""
+	}
+	e := parent.SelectElement(tag)
+	if e == nil {
+		return ""
+	}
+	return strings.TrimSpace(e.Text())
+}
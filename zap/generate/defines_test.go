@@ -0,0 +1,114 @@
+package generate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/errata"
+)
+
+func TestBaseDefine(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		expected string
+	}{
+		{"Level Control Cluster", "", "LEVEL_CONTROL_CLUSTER"},
+		{"CurrentLevel", "", "CURRENT_LEVEL"},
+		{"ColorTempPhysicalMinMireds", "", "COLOR_TEMP_PHYSICAL_MIN_MIREDS"},
+		// A run of capitals is one word, so an acronym runs into the word after it; the SDK's defines for those
+		// come from errata overrides
+		{"HSVColor Cluster", "", "HSVCOLOR_CLUSTER"},
+		{"NumberOfPINUsersSupported", "DOOR_LOCK_", "DOOR_LOCK_NUMBER_OF_PINUSERS_SUPPORTED"},
+		// The prefix isn't doubled up on names that already start with it
+		{"DoorLockState", "DOOR_LOCK_", "DOOR_LOCK_STATE"},
+	}
+	for _, tt := range tests {
+		if define := baseDefine(tt.name, tt.prefix); define != tt.expected {
+			t.Errorf("expected define for %q to be %s, got %s", tt.name, tt.expected, define)
+		}
+	}
+	// Overrides are keyed by the base define
+	overrides := &errata.ZAP{DefineOverrides: map[string]string{"CURRENT_LEVEL": "CURRENT_LEVEL_ATTR"}}
+	if define := getDefine("CurrentLevel", "", overrides); define != "CURRENT_LEVEL_ATTR" {
+		t.Errorf("expected overridden define CURRENT_LEVEL_ATTR, got %s", define)
+	}
+}
+
+func TestDefineReporter(t *testing.T) {
+	specRoot := t.TempDir()
+	err := os.CopyFS(specRoot, os.DirFS("../../disco/testdata/rename"))
+	if err != nil {
+		t.Fatalf("failed copying spec: %v", err)
+	}
+	_, docs := buildTestSpec(t, specRoot)
+
+	sdkRoot := t.TempDir()
+	widgetPath := getZapPath(sdkRoot, "widget-cluster")
+	err = os.MkdirAll(filepath.Dir(widgetPath), 0755)
+	if err != nil {
+		t.Fatalf("failed creating SDK: %v", err)
+	}
+	err = os.WriteFile(widgetPath, []byte(`<?xml version="1.0"?>
+<configurator>
+  <cluster>
+    <name>Widget</name>
+    <code>0xFFF1</code>
+    <define>WIDGET_CLUSTER</define>
+    <attribute side="server" code="0x0001" define="WIDGET_SPEED" type="int8u">Speed</attribute>
+  </cluster>
+</configurator>
+`), 0644)
+	if err != nil {
+		t.Fatalf("failed writing Widget template: %v", err)
+	}
+
+	p := NewDefineReporter(sdkRoot)
+	_, err = p.Process(context.Background(), docs)
+	if err != nil {
+		t.Fatalf("failed computing defines: %v", err)
+	}
+	var defines []string
+	for _, d := range p.Defines {
+		defines = append(defines, d.String())
+	}
+	// Clusters are in doc path order, and an existing define is kept over the generated one
+	expected := []string{
+		"cluster define FANCY_WIDGET_CLUSTER in Fancy Widget (0xFFF3) from src/app_clusters/FancyWidget.adoc",
+		"attribute define SPEED in Fancy Widget (0x0001) from src/app_clusters/FancyWidget.adoc",
+		"attribute define LIMIT in Fancy Widget (0x0002) from src/app_clusters/FancyWidget.adoc",
+		"attribute define MODE in Fancy Widget (0x0000) from src/app_clusters/FancyWidget.adoc",
+		"cluster define GADGET_CLUSTER in Gadget (0xFFF2) from src/app_clusters/Gadget.adoc",
+		"attribute define MODE in Gadget (0x0000) from src/app_clusters/Gadget.adoc",
+		"attribute define SPEED in Gadget (0x0001) from src/app_clusters/Gadget.adoc",
+		"attribute define LIMIT in Gadget (0x0002) from src/app_clusters/Gadget.adoc",
+		"cluster define WIDGET_CLUSTER in Widget (0xFFF1) from src/app_clusters/Widget.adoc",
+		"attribute define MODE in Widget (0x0000) from src/app_clusters/Widget.adoc",
+		"attribute define WIDGET_SPEED in Widget (0x0001) from src/app_clusters/Widget.adoc",
+		"attribute define LIMIT in Widget (0x0002) from src/app_clusters/Widget.adoc",
+		"cluster define BASIC_INFORMATION_CLUSTER in Basic Information (0x0028) from src/service_device_management/BasicInformation.adoc",
+		"attribute define VENDOR_NAME in Basic Information (0x0000) from src/service_device_management/BasicInformation.adoc",
+		"cluster define BRIDGED_DEVICE_BASIC_INFORMATION_CLUSTER in Bridged Device Basic Information (0x0039) from src/service_device_management/BridgedDeviceBasicInformation.adoc",
+		"attribute define VENDOR_NAME in Bridged Device Basic Information (0x0000) from src/service_device_management/BridgedDeviceBasicInformation.adoc",
+	}
+	if !slices.Equal(defines, expected) {
+		t.Fatalf("unexpected defines:\n%s\nexpected:\n%s", strings.Join(defines, "\n"), strings.Join(expected, "\n"))
+	}
+
+	speed := p.Defines[10]
+	if speed.Generated != "SPEED" || speed.Existing != "WIDGET_SPEED" {
+		t.Errorf("expected generated define SPEED and existing define WIDGET_SPEED, got %s and %s", speed.Generated, speed.Existing)
+	}
+	// Attributes of different clusters can share a define as long as their IDs match
+	if len(p.Collisions) != 0 {
+		t.Errorf("expected no collisions, got %d", len(p.Collisions))
+	}
+	suggestion, ok := p.Suggestions["src/app_clusters/Widget.adoc"]
+	if !ok || suggestion.DefineOverrides["SPEED"] != "WIDGET_SPEED" {
+		t.Errorf("expected a define override suggestion of SPEED to WIDGET_SPEED, got %v", suggestion)
+	}
+}
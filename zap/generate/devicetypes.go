@@ -2,10 +2,13 @@ package generate
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -27,10 +30,11 @@ type DeviceTypesPatcher struct {
 	sdkRoot        string
 	spec           *spec.Specification
 	clusterAliases map[string]string
+	overwrite      bool
 }
 
-func NewDeviceTypesPatcher(sdkRoot string, spec *spec.Specification, clusterAliases pipeline.Map[string, []string]) *DeviceTypesPatcher {
-	dtp := &DeviceTypesPatcher{sdkRoot: sdkRoot, spec: spec, clusterAliases: make(map[string]string)}
+func NewDeviceTypesPatcher(sdkRoot string, spec *spec.Specification, clusterAliases pipeline.Map[string, []string], overwrite bool) *DeviceTypesPatcher {
+	dtp := &DeviceTypesPatcher{sdkRoot: sdkRoot, spec: spec, clusterAliases: make(map[string]string), overwrite: overwrite}
 	clusterAliases.Range(func(cluster string, aliases []string) bool {
 		for _, alias := range aliases {
 			dtp.clusterAliases[alias] = cluster
@@ -62,21 +66,37 @@ func (p DeviceTypesPatcher) Process(cxt context.Context, inputs []*pipeline.Data
 		}
 	}
 
+	// Only the given device types are patched, but a device type is only removed if it's gone from the spec entirely
+	specDeviceTypeIDs := make(map[uint64]struct{})
+	specDeviceTypeNames := make(map[string]struct{})
+	for _, dt := range p.spec.DeviceTypes {
+		if dt.ID.Valid() {
+			specDeviceTypeIDs[dt.ID.Value()] = struct{}{}
+		}
+		specDeviceTypeNames[matterDeviceTypeName(dt)] = struct{}{}
+	}
+
 	deviceTypesXMLPath := filepath.Join(p.sdkRoot, "/src/app/zap-templates/zcl/data-model/chip/matter-devices.xml")
 
+	var x *etree.Document
 	var deviceTypesXML []byte
 	deviceTypesXML, err = os.ReadFile(deviceTypesXMLPath)
-	if err != nil {
-		return
-	}
-
-	xml := etree.NewDocument()
-	err = xml.ReadFromBytes(deviceTypesXML)
-	if err != nil {
+	if errors.Is(err, os.ErrNotExist) {
+		slog.InfoContext(cxt, "Rendering new device types", "path", deviceTypesXMLPath)
+		x = newZapTemplate()
+		x.CreateElement("configurator")
+		err = nil
+	} else if err != nil {
 		return
+	} else {
+		x = etree.NewDocument()
+		err = x.ReadFromBytes(deviceTypesXML)
+		if err != nil {
+			return
+		}
 	}
 
-	configurator := xml.SelectElement("configurator")
+	configurator := x.SelectElement("configurator")
 	if configurator == nil {
 		err = fmt.Errorf("missing configurator element in %s", deviceTypesXMLPath)
 		return
@@ -85,6 +105,7 @@ func (p DeviceTypesPatcher) Process(cxt context.Context, inputs []*pipeline.Data
 	deviceTypeElements := configurator.SelectElements("deviceType")
 	for _, deviceTypeElement := range deviceTypeElements {
 		var deviceType *matter.DeviceType
+		var inSpec bool
 		deviceIDElement := deviceTypeElement.SelectElement("deviceId")
 		if deviceIDElement != nil {
 			deviceTypeIDText := deviceIDElement.Text()
@@ -94,6 +115,7 @@ func (p DeviceTypesPatcher) Process(cxt context.Context, inputs []*pipeline.Data
 					// Exception for the all clusters app, etc
 					continue
 				}
+				_, inSpec = specDeviceTypeIDs[deviceTypeID.Value()]
 				deviceType = deviceTypesByID[deviceTypeID.Value()]
 				if deviceType != nil {
 					delete(deviceTypesByID, deviceTypeID.Value())
@@ -110,35 +132,55 @@ func (p DeviceTypesPatcher) Process(cxt context.Context, inputs []*pipeline.Data
 				continue
 			}
 			deviceTypeIDText := deviceTypeElement.Text()
+			if _, ok := specDeviceTypeNames[deviceTypeIDText]; ok {
+				inSpec = true
+			}
 			deviceType = deviceTypesByName[deviceTypeIDText]
 			if deviceType != nil {
 				delete(deviceTypesByName, deviceTypeIDText)
 			}
 		}
 		if deviceType != nil && !matter.NonGlobalIDInvalidForEntity(deviceType.ID, types.EntityTypeDeviceType) {
+			if p.overwrite {
+				dte := etree.NewElement("deviceType")
+				configurator.InsertChildAt(deviceTypeElement.Index(), dte)
+				configurator.RemoveChild(deviceTypeElement)
+				deviceTypeElement = dte
+			}
 			p.applyDeviceTypeToElement(p.spec, deviceType, deviceTypeElement)
-		} else {
+		} else if deviceType != nil || !inSpec {
+			name, _ := xml.ReadSimpleElement(deviceTypeElement, "typeName")
+			slog.Info("Removing device type", slog.String("name", name))
 			configurator.RemoveChild(deviceTypeElement)
 		}
 	}
 
+	newDeviceTypes := make([]*matter.DeviceType, 0, len(deviceTypesByID))
 	for _, dt := range deviceTypesByID {
-		slog.Info("Adding new device type", slog.String("name", dt.Name))
 		if matter.NonGlobalIDInvalidForEntity(dt.ID, types.EntityTypeDeviceType) {
 			continue
 		}
-		p.applyDeviceTypeToElement(p.spec, dt, configurator.CreateElement("deviceType"))
+		newDeviceTypes = append(newDeviceTypes, dt)
+	}
+	slices.SortFunc(newDeviceTypes, func(a, b *matter.DeviceType) int { return a.ID.Compare(b.ID) })
+	for _, dt := range newDeviceTypes {
+		slog.Info("Adding new device type", slog.String("name", dt.Name))
+		dte := etree.NewElement("deviceType")
+		insertDeviceType(configurator, dte, dt.ID)
+		p.applyDeviceTypeToElement(p.spec, dt, dte)
 	}
 
-	for _, dt := range deviceTypesByName {
+	names := slices.Sorted(maps.Keys(deviceTypesByName))
+	for _, name := range names {
+		dt := deviceTypesByName[name]
 		slog.Info("Adding new device type", slog.String("name", dt.Name))
 		p.applyDeviceTypeToElement(p.spec, dt, configurator.CreateElement("deviceType"))
 	}
 
 	var out string
-	xml.Indent(4)
-	xml.WriteSettings.CanonicalEndTags = true
-	out, err = xml.WriteToString()
+	x.Indent(4)
+	x.WriteSettings.CanonicalEndTags = true
+	out, err = x.WriteToString()
 	if err != nil {
 		return
 	}
@@ -147,6 +189,30 @@ func (p DeviceTypesPatcher) Process(cxt context.Context, inputs []*pipeline.Data
 	return
 }
 
+// insertDeviceType inserts a new device type element before the first device type with a higher ID, leaving the
+// utility device types at the end
+func insertDeviceType(configurator *etree.Element, dte *etree.Element, id *matter.Number) {
+	for i, child := range configurator.Child {
+		el, ok := child.(*etree.Element)
+		if !ok || el.Tag != "deviceType" {
+			continue
+		}
+		idElement := el.SelectElement("deviceId")
+		if idElement == nil {
+			continue
+		}
+		elID := matter.ParseNumber(idElement.Text())
+		if !elID.Valid() {
+			continue
+		}
+		if (elID.Value()&utilityDevicesMask) == utilityDevicesMask || elID.Compare(id) > 0 {
+			configurator.InsertChildAt(i, dte)
+			return
+		}
+	}
+	xml.AppendElement(configurator, dte)
+}
+
 type clusterRequirements struct {
 	name                    string
 	clusterRequirements     []*matter.ClusterRequirement
@@ -155,6 +221,10 @@ type clusterRequirements struct {
 }
 
 func (p DeviceTypesPatcher) applyDeviceTypeToElement(spec *spec.Specification, deviceType *matter.DeviceType, dte *etree.Element) (err error) {
+	baseDeviceType := spec.BaseDeviceType
+	if baseDeviceType == nil {
+		baseDeviceType = &matter.DeviceType{}
+	}
 	xml.SetOrCreateSimpleElement(dte, "name", zap.DeviceTypeName(deviceType))
 	xml.SetOrCreateSimpleElement(dte, "domain", "CHIP")
 	xml.SetOrCreateSimpleElement(dte, "typeName", matterDeviceTypeName(deviceType))
@@ -162,6 +232,14 @@ func (p DeviceTypesPatcher) applyDeviceTypeToElement(spec *spec.Specification, d
 	xml.SetOrCreateSimpleElement(dte, "deviceId", deviceType.ID.HexString()).CreateAttr("editable", "false")
 	xml.SetOrCreateSimpleElement(dte, "class", deviceType.Class)
 	xml.SetOrCreateSimpleElement(dte, "scope", deviceType.Scope)
+	if deviceType.Conformance != nil {
+		if conformance.IsProvisional(deviceType.Conformance) {
+			dte.CreateAttr("apiMaturity", "provisional")
+		} else {
+			dte.RemoveAttr("apiMaturity")
+		}
+	}
+	setDeviceTypeConditions(deviceType, dte)
 	clustersElement := dte.SelectElement("clusters")
 	if len(deviceType.ClusterRequirements) == 0 {
 		if clustersElement != nil {
@@ -182,7 +260,7 @@ func (p DeviceTypesPatcher) applyDeviceTypeToElement(spec *spec.Specification, d
 		}
 		crr.clusterRequirements = append(crr.clusterRequirements, cr)
 	}
-	for _, cr := range spec.BaseDeviceType.ClusterRequirements {
+	for _, cr := range baseDeviceType.ClusterRequirements {
 		name := strings.ToLower(cr.ClusterName)
 		crr, ok := clusterRequirementsByName[name]
 		if !ok {
@@ -192,7 +270,7 @@ func (p DeviceTypesPatcher) applyDeviceTypeToElement(spec *spec.Specification, d
 		crr.baseClusterRequirements = append(crr.baseClusterRequirements, cr)
 		slog.Debug("adding base device type cluster requirement", slog.String("cluster", cr.ClusterName))
 	}
-	for _, ers := range [][]*matter.ElementRequirement{deviceType.ElementRequirements, spec.BaseDeviceType.ElementRequirements} {
+	for _, ers := range [][]*matter.ElementRequirement{deviceType.ElementRequirements, baseDeviceType.ElementRequirements} {
 		for _, er := range ers {
 			name := strings.ToLower(er.ClusterName)
 			crr, ok := clusterRequirementsByName[name]
@@ -220,7 +298,7 @@ func (p DeviceTypesPatcher) applyDeviceTypeToElement(spec *spec.Specification, d
 		p.setIncludeAttributes(clustersElement, include, spec, deviceType, crs)
 		delete(clusterRequirementsByName, strings.ToLower(ca.Value))
 	}
-	for _, crs := range [][]*matter.ClusterRequirement{spec.BaseDeviceType.ClusterRequirements, deviceType.ClusterRequirements} {
+	for _, crs := range [][]*matter.ClusterRequirement{baseDeviceType.ClusterRequirements, deviceType.ClusterRequirements} {
 		for _, cr := range crs {
 			crr, ok := clusterRequirementsByName[strings.ToLower(cr.ClusterName)]
 			if ok {
@@ -306,6 +384,7 @@ func (p DeviceTypesPatcher) setIncludeAttributes(clustersElement *etree.Element,
 	requiredCommands := make(map[string]struct{})
 	requiredCommandFields := make(map[string]map[string]struct{})
 	requiredEvents := make(map[string]struct{})
+	requiredFeatures := make(map[string]struct{})
 
 	for _, er := range cr.elementRequirements {
 		conf, err := er.Conformance.Eval(cxt)
@@ -316,6 +395,7 @@ func (p DeviceTypesPatcher) setIncludeAttributes(clustersElement *etree.Element,
 		if conf == conformance.StateMandatory || conf == conformance.StateProvisional {
 			switch er.Element {
 			case types.EntityTypeFeature:
+				requiredFeatures[er.Name] = struct{}{}
 				cxt.Values[er.Name] = true
 			case types.EntityTypeAttribute:
 				requiredAttributes[er.Name] = struct{}{}
@@ -465,6 +545,66 @@ func (p DeviceTypesPatcher) setIncludeAttributes(clustersElement *etree.Element,
 		rae.SetText(ra)
 		xml.InsertElementByName(include, rae, "requireAttribute", "requireCommand", "requireCommandField")
 	}
+	setIncludeFeatures(include, cluster, requiredFeatures)
+}
+
+func setDeviceTypeConditions(deviceType *matter.DeviceType, dte *etree.Element) {
+	ce := dte.SelectElement("conditions")
+	if len(deviceType.Conditions) == 0 {
+		if ce != nil {
+			dte.RemoveChild(ce)
+		}
+		return
+	}
+	if ce == nil {
+		ce = etree.NewElement("conditions")
+		xml.AppendElement(dte, ce, "scope", "class", "deviceId")
+	} else {
+		ce.Child = nil
+	}
+	for _, c := range deviceType.Conditions {
+		cx := ce.CreateElement("condition")
+		cx.CreateAttr("name", c.Feature)
+		if c.Description != "" {
+			cx.CreateAttr("summary", strings.Join(strings.Fields(c.Description), " "))
+		}
+	}
+}
+
+// setIncludeFeatures lists the features of the cluster that the device type requires
+func setIncludeFeatures(include *etree.Element, cluster *matter.Cluster, requiredFeatures map[string]struct{}) {
+	fe := include.SelectElement("features")
+	var features []*matter.Feature
+	if cluster.Features != nil {
+		for _, b := range cluster.Features.Bits {
+			f, ok := b.(*matter.Feature)
+			if !ok {
+				continue
+			}
+			if _, ok := requiredFeatures[f.Code]; ok {
+				features = append(features, f)
+			} else if _, ok := requiredFeatures[f.Name()]; ok {
+				features = append(features, f)
+			}
+		}
+	}
+	if len(features) == 0 {
+		if fe != nil {
+			include.RemoveChild(fe)
+		}
+		return
+	}
+	if fe == nil {
+		fe = include.CreateElement("features")
+	} else {
+		fe.Child = nil
+	}
+	for _, f := range features {
+		fx := fe.CreateElement("feature")
+		fx.CreateAttr("code", f.Code)
+		fx.CreateAttr("name", f.Name())
+		fx.CreateElement("mandatoryConform")
+	}
 }
 
 func matterDeviceTypeName(deviceType *matter.DeviceType) string {
@@ -0,0 +1,107 @@
+package generate
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+func TestDeviceTypesRoundTrip(t *testing.T) {
+	specRoot := t.TempDir()
+	for _, dir := range []string{"../../disco/testdata/rename", "testdata/devicetypes"} {
+		err := os.CopyFS(specRoot, os.DirFS(dir))
+		if err != nil {
+			t.Fatalf("failed copying spec: %v", err)
+		}
+	}
+	s, _ := buildTestSpec(t, specRoot)
+
+	sdkRoot := t.TempDir()
+	deviceTypesXMLPath := filepath.Join(sdkRoot, "src/app/zap-templates/zcl/data-model/chip/matter-devices.xml")
+	existing, err := os.ReadFile(filepath.Join(specRoot, "matter-devices.xml"))
+	if err != nil {
+		t.Fatalf("failed reading device types XML: %v", err)
+	}
+	err = os.MkdirAll(filepath.Dir(deviceTypesXMLPath), 0755)
+	if err != nil {
+		t.Fatalf("failed creating SDK: %v", err)
+	}
+
+	patch := func(in []byte) []byte {
+		err := os.WriteFile(deviceTypesXMLPath, in, 0644)
+		if err != nil {
+			t.Fatalf("failed writing device types XML: %v", err)
+		}
+		patcher := NewDeviceTypesPatcher(sdkRoot, s, pipeline.NewConcurrentMap[string, []string](), true)
+		outputs, err := patcher.Process(context.Background(), []*pipeline.Data[[]*matter.DeviceType]{pipeline.NewData("device_types", s.DeviceTypes)})
+		if err != nil {
+			t.Fatalf("failed patching device types: %v", err)
+		}
+		if len(outputs) != 1 {
+			t.Fatalf("expected 1 output, got %d", len(outputs))
+		}
+		return outputs[0].Content
+	}
+
+	patched := patch(existing)
+	x := etree.NewDocument()
+	err = x.ReadFromBytes(patched)
+	if err != nil {
+		t.Fatalf("failed parsing patched device types XML: %v", err)
+	}
+	var ids []string
+	for _, dte := range x.FindElements("/configurator/deviceType") {
+		ids = append(ids, dte.SelectElement("deviceId").Text())
+	}
+	// The removed device type is gone, and the new ones are inserted in ID order, before the utility device types
+	expected := []string{"0x0500", "0x0510", "0x0515", "0xFFF10001"}
+	if !slices.Equal(ids, expected) {
+		t.Errorf("expected device types %v, got %v", expected, ids)
+	}
+	sprocket := x.FindElement("/configurator/deviceType[deviceId='0x0510']")
+	if sprocket == nil {
+		t.Fatalf("missing sprocket device type in:\n%s", patched)
+	}
+	if sprocket.FindElement("clusters/include[@cluster='Stale']") != nil {
+		t.Errorf("expected stale requirements to be overwritten, got:\n%s", patched)
+	}
+	if sprocket.FindElement("clusters/include[@cluster='Widget']") == nil {
+		t.Errorf("expected sprocket device type to include Widget cluster, got:\n%s", patched)
+	}
+
+	if repatched := patch(patched); string(repatched) != string(patched) {
+		t.Errorf("device types XML did not round trip; first pass:\n%s\nsecond pass:\n%s", patched, repatched)
+	}
+}
+
+func buildTestSpec(t *testing.T, specRoot string) (*spec.Specification, []*pipeline.Data[*spec.Doc]) {
+	var docs []*pipeline.Data[*spec.Doc]
+	err := filepath.WalkDir(filepath.Join(specRoot, "src"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".adoc" {
+			return err
+		}
+		doc, err := spec.ReadFile(path, specRoot)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, pipeline.NewData(path, doc))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed reading spec: %v", err)
+	}
+	builder := spec.NewBuilder()
+	_, err = builder.Process(context.Background(), docs)
+	if err != nil {
+		t.Fatalf("failed building spec: %v", err)
+	}
+	return builder.Spec, docs
+}
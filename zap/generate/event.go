@@ -62,6 +62,7 @@ func (tg *TemplateGenerator) populateEvent(configurator *zap.Configurator, ee *e
 	needsAccess := e.Access.Read != matter.PrivilegeUnknown && e.Access.Read != matter.PrivilegeView
 
 	patchNumberAttribute(ee, e.ID, "code")
+	patchManufacturerCode(ee, e.ID)
 	ee.CreateAttr("name", e.Name)
 	ee.CreateAttr("priority", strings.ToLower(e.Priority))
 	ee.CreateAttr("side", "server")
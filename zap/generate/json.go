@@ -27,26 +27,17 @@ func (p *ProvisionalPatcher) patchZapJSONFile(sdkRoot string, file string, files
 	if err != nil {
 		return
 	}
-	val, ok := o.Get("xmlFile")
-	if !ok {
-		err = fmt.Errorf("missing xmlFile element in %s", zclJSONPath)
-		return
-	}
-	is, ok := val.([]any)
-	if !ok {
-		err = fmt.Errorf("xmlFile element in %s is not array", zclJSONPath)
+	var xmls []string
+	xmls, err = zapJSONFiles(o, zclJSONPath)
+	if err != nil {
 		return
 	}
-	xmls := make([]string, 0, len(is)+len(files))
 	fileMap := make(map[string]struct{})
 	for _, file := range files {
 		fileMap[file] = struct{}{}
 	}
-	for _, i := range is {
-		if s, ok := i.(string); ok {
-			xmls = append(xmls, s)
-			delete(fileMap, s)
-		}
+	for _, s := range xmls {
+		delete(fileMap, s)
 	}
 
 	xmls = mergeLines(xmls, fileMap, 2)
@@ -64,6 +55,27 @@ func (p *ProvisionalPatcher) patchZapJSONFile(sdkRoot string, file string, files
 	return
 }
 
+// zapJSONFiles returns the XML files listed in a zcl.json file
+func zapJSONFiles(o *orderedmap.OrderedMap, zclJSONPath string) (xmls []string, err error) {
+	val, ok := o.Get("xmlFile")
+	if !ok {
+		err = fmt.Errorf("missing xmlFile element in %s", zclJSONPath)
+		return
+	}
+	is, ok := val.([]any)
+	if !ok {
+		err = fmt.Errorf("xmlFile element in %s is not array", zclJSONPath)
+		return
+	}
+	xmls = make([]string, 0, len(is))
+	for _, i := range is {
+		if s, ok := i.(string); ok {
+			xmls = append(xmls, s)
+		}
+	}
+	return
+}
+
 func (p *ProvisionalPatcher) patchAttributeAccessInterfaceAttributes(o *orderedmap.OrderedMap) {
 	val, ok := o.Get("attributeAccessInterfaceAttributes")
 	if !ok {
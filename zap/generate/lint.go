@@ -33,19 +33,23 @@ func patchLintBytes(sdkRoot string, files []string) (lintPath string, lintBytes
 
 	paths = mergeLines(paths, newPathMap, 0)
 
+	lintBytes = []byte(replaceLintTemplatePaths(lint, paths))
+	return
+}
+
+// replaceLintTemplatePaths replaces the block of template loads in a lint file with the given load lines
+func replaceLintTemplatePaths(lint string, paths []string) string {
 	var sb strings.Builder
 	for _, p := range paths {
 		sb.WriteString(p)
 	}
 
 	var replaced bool
-	s := templatePathPattern.ReplaceAllStringFunc(lint, func(s string) string {
+	return templatePathPattern.ReplaceAllStringFunc(lint, func(s string) string {
 		if replaced {
 			return ""
 		}
 		replaced = true
 		return sb.String()
 	})
-	lintBytes = []byte(s)
-	return
 }
@@ -0,0 +1,323 @@
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/iancoleman/orderedmap"
+	"github.com/iancoleman/strcase"
+	"github.com/project-chip/alchemy/errata"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// OrphanPatcher finds the ZAP templates in the SDK that no spec document or errata override generates any more, and
+// removes them, along with any templates that no longer exist, from the files that register them
+type OrphanPatcher struct {
+	sdkRoot string
+	pending []pipeline.Map[string, *pipeline.Data[[]byte]]
+
+	// Orphans are the paths of the orphaned templates
+	Orphans []string
+	// Registrations are the entries removed from each registry file, by path
+	Registrations map[string][]string
+}
+
+// NewOrphanPatcher creates an OrphanPatcher; registry files in pending have already been patched, and are amended
+// instead of being read from the SDK
+func NewOrphanPatcher(sdkRoot string, pending ...pipeline.Map[string, *pipeline.Data[[]byte]]) *OrphanPatcher {
+	return &OrphanPatcher{sdkRoot: sdkRoot, pending: pending, Registrations: make(map[string][]string)}
+}
+
+func (p *OrphanPatcher) Name() string {
+	return "Finding orphaned ZAP templates"
+}
+
+func (p *OrphanPatcher) Type() pipeline.ProcessorType {
+	return pipeline.ProcessorTypeCollective
+}
+
+// Process takes every cluster doc in the spec, not just those being generated
+func (p *OrphanPatcher) Process(cxt context.Context, inputs []*pipeline.Data[*spec.Doc]) (outputs []*pipeline.Data[[]byte], err error) {
+	backed := make(map[string]struct{})
+	backedNames := make(map[string]struct{})
+	for _, input := range inputs {
+		var entities []types.Entity
+		entities, err = input.Content.Entities()
+		if err != nil {
+			return
+		}
+		for path := range ZAPTemplateDestinations(p.sdkRoot, input.Content.Path.Relative, entities, errata.GetZAP(input.Content.Path.Relative)) {
+			backed[path] = struct{}{}
+		}
+		backedNames[clusterListName(input.Content)] = struct{}{}
+	}
+	for _, e := range errata.Erratas {
+		if e == nil {
+			continue
+		}
+		if e.ZAP.TemplatePath != "" {
+			backed[getZapPath(p.sdkRoot, e.ZAP.TemplatePath)] = struct{}{}
+		}
+		for _, path := range e.ZAP.ClusterSplit {
+			backed[getZapPath(p.sdkRoot, path)] = struct{}{}
+		}
+	}
+
+	var orphanNames map[string]struct{}
+	orphanNames, err = p.findOrphans(backed)
+	if err != nil {
+		return
+	}
+	orphans := make(map[string]struct{}, len(p.Orphans))
+	for _, o := range p.Orphans {
+		orphans[o] = struct{}{}
+	}
+	// Registrations are stale if they point at an orphan, or at a template that's neither generated nor in the SDK
+	stale := func(path string) bool {
+		path = filepath.Clean(path)
+		if _, ok := orphans[path]; ok {
+			return true
+		}
+		if _, ok := backed[path]; ok {
+			return false
+		}
+		exists, _ := files.Exists(path)
+		return !exists
+	}
+
+	for _, file := range []string{"src/app/zap-templates/zcl/zcl.json", "src/app/zap-templates/zcl/zcl-with-test-extensions.json"} {
+		outputs, err = p.appendOutput(outputs, filepath.Join(p.sdkRoot, file), func(path string, b []byte) ([]byte, []string, error) {
+			return removeZapJSONFiles(path, b, stale)
+		})
+		if err != nil {
+			return
+		}
+	}
+	outputs, err = p.appendOutput(outputs, filepath.Join(p.sdkRoot, "scripts/rules.matterlint"), func(path string, b []byte) ([]byte, []string, error) {
+		return removeLintTemplatePaths(path, b, stale)
+	})
+	if err != nil {
+		return
+	}
+	outputs, err = p.appendOutput(outputs, filepath.Join(p.sdkRoot, ".github/workflows/tests.yaml"), func(path string, b []byte) ([]byte, []string, error) {
+		return removeYamlFileLinks(p.sdkRoot, b, stale)
+	})
+	if err != nil {
+		return
+	}
+	outputs, err = p.appendOutput(outputs, filepath.Join(p.sdkRoot, "src/app/zap_cluster_list.json"), func(path string, b []byte) ([]byte, []string, error) {
+		return removeClusterNames(b, func(name string) bool {
+			_, orphaned := orphanNames[name]
+			_, isBacked := backedNames[name]
+			return orphaned && !isBacked
+		})
+	})
+	return
+}
+
+// findOrphans lists the templates that define Matter clusters but aren't generated from the spec; templates for
+// manufacturer-specific clusters, like the SDK's test clusters, are never orphans
+func (p *OrphanPatcher) findOrphans(backed map[string]struct{}) (clusterNames map[string]struct{}, err error) {
+	clusterNames = make(map[string]struct{})
+	dir := filepath.Dir(getZapPath(p.sdkRoot, "x"))
+	var entries []os.DirEntry
+	entries, err = os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".xml" {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if _, ok := backed[path]; ok {
+			continue
+		}
+		doc := etree.NewDocument()
+		err = doc.ReadFromFile(path)
+		if err != nil {
+			err = fmt.Errorf("failed reading ZAP template %s: %w", path, err)
+			return
+		}
+		configurator := doc.SelectElement("configurator")
+		if configurator == nil {
+			continue
+		}
+		var names []string
+		var matterCluster bool
+		for _, c := range configurator.SelectElements("cluster") {
+			code := c.SelectElement("code")
+			if code == nil {
+				continue
+			}
+			id := matter.ParseNumber(code.Text())
+			if id.Valid() && id.Value() <= 0x7FFF {
+				matterCluster = true
+			}
+			if name := c.SelectElement("name"); name != nil {
+				names = append(names, strcase.ToScreamingSnake(strings.TrimSpace(name.Text())+" Cluster"))
+			}
+		}
+		if !matterCluster {
+			continue
+		}
+		p.Orphans = append(p.Orphans, path)
+		for _, name := range names {
+			clusterNames[name] = struct{}{}
+		}
+	}
+	return
+}
+
+func (p *OrphanPatcher) appendOutput(outputs []*pipeline.Data[[]byte], path string, remove func(path string, b []byte) ([]byte, []string, error)) ([]*pipeline.Data[[]byte], error) {
+	b, err := p.read(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			slog.Warn("Registry file not found; skipping orphan check", "path", path)
+			return outputs, nil
+		}
+		return outputs, err
+	}
+	b, removed, err := remove(path, b)
+	if err != nil {
+		return outputs, err
+	}
+	if len(removed) == 0 {
+		return outputs, nil
+	}
+	p.Registrations[path] = removed
+	return append(outputs, pipeline.NewData(path, b)), nil
+}
+
+func (p *OrphanPatcher) read(path string) ([]byte, error) {
+	for _, m := range p.pending {
+		if m == nil {
+			continue
+		}
+		var found *pipeline.Data[[]byte]
+		m.Range(func(key string, value *pipeline.Data[[]byte]) bool {
+			if value.Path == path {
+				found = value
+				return false
+			}
+			return true
+		})
+		if found != nil {
+			return found.Content, nil
+		}
+	}
+	return os.ReadFile(path)
+}
+
+func removeZapJSONFiles(zclJSONPath string, b []byte, stale func(path string) bool) (out []byte, removed []string, err error) {
+	o := orderedmap.New()
+	err = json.Unmarshal(b, &o)
+	if err != nil {
+		return
+	}
+	var xmls []string
+	xmls, err = zapJSONFiles(o, zclJSONPath)
+	if err != nil {
+		return
+	}
+	roots := []string{"./data-model/chip/"}
+	if val, ok := o.Get("xmlRoot"); ok {
+		if rs, ok := val.([]any); ok {
+			roots = roots[:0]
+			for _, r := range rs {
+				if s, ok := r.(string); ok {
+					roots = append(roots, s)
+				}
+			}
+		}
+	}
+	xmls = slices.DeleteFunc(xmls, func(xml string) bool {
+		for _, root := range roots {
+			if !stale(filepath.Join(filepath.Dir(zclJSONPath), root, xml)) {
+				return false
+			}
+		}
+		removed = append(removed, xml)
+		return true
+	})
+	if len(removed) == 0 {
+		return b, nil, nil
+	}
+	o.Set("xmlFile", xmls)
+	out, err = json.MarshalIndent(o, "", "    ")
+	if err != nil {
+		err = fmt.Errorf("error marshaling %s: %w", zclJSONPath, err)
+	}
+	return
+}
+
+func removeLintTemplatePaths(lintPath string, b []byte, stale func(path string) bool) (out []byte, removed []string, err error) {
+	lint := string(b)
+	var paths []string
+	for _, m := range templatePathPattern.FindAllString(lint, -1) {
+		file := strings.TrimSuffix(strings.TrimPrefix(m, `load "`), "\";\n")
+		if stale(filepath.Join(filepath.Dir(lintPath), file)) {
+			removed = append(removed, file)
+			continue
+		}
+		paths = append(paths, m)
+	}
+	if len(removed) == 0 {
+		return b, nil, nil
+	}
+	return []byte(replaceLintTemplatePaths(lint, paths)), removed, nil
+}
+
+func removeYamlFileLinks(sdkRoot string, b []byte, stale func(path string) bool) (out []byte, removed []string, err error) {
+	yaml := string(b)
+	var lines []string
+	for _, m := range yamlFileLinkPattern.FindAllStringSubmatch(yaml, -1) {
+		if stale(filepath.Join(sdkRoot, m[2])) {
+			removed = append(removed, m[2])
+			continue
+		}
+		lines = append(lines, m[0])
+	}
+	if len(removed) == 0 {
+		return b, nil, nil
+	}
+	return []byte(replaceYamlFileLinks(yaml, lines)), removed, nil
+}
+
+func removeClusterNames(b []byte, orphaned func(name string) bool) (out []byte, removed []string, err error) {
+	o := orderedmap.New()
+	err = json.Unmarshal(b, &o)
+	if err != nil {
+		return
+	}
+	for _, key := range []string{"ClientDirectories", "ServerDirectories"} {
+		var is orderedmap.OrderedMap
+		is, err = clusterDirectories(o, key)
+		if err != nil {
+			return
+		}
+		for _, name := range is.Keys() {
+			if orphaned(name) {
+				is.Delete(name)
+				removed = append(removed, key+"."+name)
+			}
+		}
+		o.Set(key, is)
+	}
+	if len(removed) == 0 {
+		return b, nil, nil
+	}
+	out, err = json.MarshalIndent(o, "", "    ")
+	return
+}
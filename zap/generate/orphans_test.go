@@ -0,0 +1,79 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFindOrphans(t *testing.T) {
+	sdkRoot := t.TempDir()
+	dir := filepath.Dir(getZapPath(sdkRoot, "x"))
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		t.Fatalf("failed creating SDK: %v", err)
+	}
+	cluster := func(name string, code string) string {
+		return fmt.Sprintf("  <cluster>\n    <name>%s</name>\n    <code>%s</code>\n  </cluster>\n", name, code)
+	}
+	templates := map[string]string{
+		"backed.xml":   cluster("On/Off", "0x0006"),
+		"orphan.xml":   cluster("Door Lock", "0x0101"),
+		"highest.xml":  cluster("Last Standard", "0x7FFF"),
+		"lowest.xml":   cluster("First Proprietary", "0x8000"),
+		"mei.xml":      cluster("Unit Testing", "0xFFF1FC05"),
+		"mixed.xml":    cluster("Sample MEI", "0xFFF1FC20") + cluster("Legacy Widget", "0x0FFF"),
+		"types.xml":    "  <struct name=\"LocationStruct\"/>\n",
+		"notes.txt":    cluster("Not A Template", "0x0042"),
+		"nocode.xml":   "  <cluster>\n    <name>No Code</name>\n  </cluster>\n",
+		"unnamed.xml":  "  <cluster>\n    <code>0x0300</code>\n  </cluster>\n",
+		"spaced.xml":   cluster(" Fan Control ", "0x0202"),
+		"noconfig.xml": "",
+	}
+	for name, body := range templates {
+		content := "<?xml version=\"1.0\"?>\n<configurator>\n" + body + "</configurator>\n"
+		if name == "noconfig.xml" {
+			content = "<?xml version=\"1.0\"?>\n<other/>\n"
+		}
+		err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("failed writing %s: %v", name, err)
+		}
+	}
+
+	p := NewOrphanPatcher(sdkRoot)
+	names, err := p.findOrphans(map[string]struct{}{filepath.Join(dir, "backed.xml"): {}})
+	if err != nil {
+		t.Fatalf("failed finding orphans: %v", err)
+	}
+	var orphans []string
+	for _, o := range p.Orphans {
+		orphans = append(orphans, filepath.Base(o))
+	}
+	// Cluster IDs up to 0x7FFF are standard clusters; anything in the manufacturer-specific range is left alone,
+	// unless it shares a template with a standard cluster
+	expected := []string{"highest.xml", "mixed.xml", "orphan.xml", "spaced.xml", "unnamed.xml"}
+	if !slices.Equal(orphans, expected) {
+		t.Errorf("expected orphans %v, got %v", expected, orphans)
+	}
+	expectedNames := []string{"DOOR_LOCK_CLUSTER", "FAN_CONTROL_CLUSTER", "LAST_STANDARD_CLUSTER", "LEGACY_WIDGET_CLUSTER", "SAMPLE_MEI_CLUSTER"}
+	var clusterNames []string
+	for name := range names {
+		clusterNames = append(clusterNames, name)
+	}
+	slices.Sort(clusterNames)
+	if !slices.Equal(clusterNames, expectedNames) {
+		t.Errorf("expected cluster names %v, got %v", expectedNames, clusterNames)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "broken.xml"), []byte("<configurator><cluster>"), 0644)
+	if err != nil {
+		t.Fatalf("failed writing broken.xml: %v", err)
+	}
+	_, err = NewOrphanPatcher(sdkRoot).findOrphans(nil)
+	if err == nil {
+		t.Errorf("expected an error reading a broken template")
+	}
+}
@@ -80,6 +80,16 @@ func patchNumberAttribute(e *etree.Element, n *matter.Number, name string) {
 	e.CreateAttr(name, n.HexString())
 }
 
+// patchManufacturerCode sets the manufacturerCode attribute on elements with manufacturer extended IDs
+func patchManufacturerCode(e *etree.Element, id *matter.Number) {
+	code, ok := id.ManufacturerCode()
+	if !ok {
+		e.RemoveAttr("manufacturerCode")
+		return
+	}
+	e.CreateAttr("manufacturerCode", matter.NewNumber(code).HexString())
+}
+
 func patchNumberElement(e *etree.Element, n *matter.Number) {
 	if !n.Valid() {
 		return
@@ -30,6 +30,7 @@ type TemplateGenerator struct {
 
 	generateFeaturesXML    bool
 	generateConformanceXML bool
+	overwrite              bool
 
 	ProvisionalZclFiles      pipeline.Map[string, *pipeline.Data[struct{}]]
 	globalObjectDependencies pipeline.Map[types.Entity, struct{}]
@@ -51,6 +52,13 @@ func GenerateConformanceXML(generate bool) TemplateOption {
 	}
 }
 
+// Overwrite regenerates existing ZAP templates and device type entries from scratch instead of amending them
+func Overwrite(overwrite bool) TemplateOption {
+	return func(tg *TemplateGenerator) {
+		tg.overwrite = overwrite
+	}
+}
+
 func AsciiAttributes(attributes []asciidoc.AttributeName) TemplateOption {
 	return func(tg *TemplateGenerator) {
 		tg.attributes = attributes
@@ -69,6 +77,7 @@ func NewTemplateGenerator(spec *spec.Specification, fileOptions files.Options, p
 		file:                     fileOptions,
 		pipeline:                 pipelineOptions,
 		sdkRoot:                  sdkRoot,
+		generateConformanceXML:   true,
 		ProvisionalZclFiles:      pipeline.NewConcurrentMap[string, *pipeline.Data[struct{}]](),
 		globalObjectDependencies: pipeline.NewConcurrentMap[types.Entity, struct{}](),
 		ClusterAliases:           pipeline.NewConcurrentMap[string, []string](),
@@ -86,6 +95,11 @@ func NewTemplateGenerator(spec *spec.Specification, fileOptions files.Options, p
 	return tg
 }
 
+// Overwrite returns whether existing ZAP templates and device types are regenerated from scratch
+func (tg *TemplateGenerator) Overwrite() bool {
+	return tg.overwrite
+}
+
 func (tg TemplateGenerator) Name() string {
 	return "Generating ZAP XML"
 }
@@ -153,6 +167,11 @@ func (tg TemplateGenerator) Process(cxt context.Context, input *pipeline.Data[*s
 			doc = newZapTemplate()
 		} else if err != nil {
 			return
+		} else if tg.overwrite {
+			if tg.pipeline.Serial {
+				slog.InfoContext(cxt, "Overwriting existing ZAP template", "from", input.Content.Path, "to", newPath)
+			}
+			doc = newZapTemplate()
 		} else {
 			if tg.pipeline.Serial {
 				slog.InfoContext(cxt, "Rendering existing ZAP template", "from", input.Content.Path, "to", newPath)
@@ -33,7 +33,6 @@ func patchTestsYamlBytes(sdkRoot string, files []string) (testsYamlPath string,
 		filesMap[path] = struct{}{}
 	}
 
-	var sb strings.Builder
 	lines := make([]string, 0, len(matches))
 	for _, m := range matches {
 		line := m[0]
@@ -59,18 +58,23 @@ func patchTestsYamlBytes(sdkRoot string, files []string) (testsYamlPath string,
 		}
 	}
 
+	yamlBytes = []byte(replaceYamlFileLinks(yaml, lines))
+	return
+}
+
+// replaceYamlFileLinks replaces the block of template paths in tests.yaml with the given lines
+func replaceYamlFileLinks(yaml string, lines []string) string {
+	var sb strings.Builder
 	for _, line := range lines {
 		sb.WriteString(line)
 	}
 
 	var replaced bool
-	yaml = yamlFileLinkPattern.ReplaceAllStringFunc(yaml, func(s string) string {
+	return yamlFileLinkPattern.ReplaceAllStringFunc(yaml, func(s string) string {
 		if replaced {
 			return ""
 		}
 		replaced = true
 		return sb.String()
 	})
-	yamlBytes = []byte(yaml)
-	return
 }
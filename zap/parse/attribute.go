@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
 	"github.com/project-chip/alchemy/matter/types"
 )
 
@@ -17,6 +18,7 @@ func readAttribute(d *xml.Decoder, e xml.StartElement) (attr *matter.Field, err
 	if err != nil {
 		return
 	}
+	var conf conformance.Set
 	for {
 		var tok xml.Token
 		tok, err = d.Token()
@@ -34,11 +36,22 @@ func readAttribute(d *xml.Decoder, e xml.StartElement) (attr *matter.Field, err
 			case "description":
 				attr.Name, err = readSimpleElement(d, t.Name.Local)
 			default:
-				err = fmt.Errorf("unexpected attribute level element: %s", t.Name.Local)
+				if !isConformanceElement(t.Name.Local) {
+					err = fmt.Errorf("unexpected attribute level element: %s", t.Name.Local)
+					break
+				}
+				var con conformance.Conformance
+				con, err = readConformance(d, t)
+				if err == nil {
+					conf = appendConformance(conf, con)
+				}
 			}
 		case xml.EndElement:
 			switch t.Name.Local {
 			case "attribute":
+				if len(conf) > 0 {
+					attr.Conformance = conf
+				}
 				return
 			default:
 				err = fmt.Errorf("unexpected attribute end element: %s", t.Name.Local)
@@ -68,6 +68,8 @@ func readCluster(path string, d *xml.Decoder, e xml.StartElement) (cluster *matt
 				if err == nil {
 					cluster.Commands = append(cluster.Commands, command)
 				}
+			case "features":
+				cluster.Features, err = readFeatures(d, t)
 			case "globalAttribute", "global":
 				err = Ignore(d, t.Name.Local)
 			case "tag":
@@ -67,6 +67,7 @@ func readCommand(path string, d *xml.Decoder, e xml.StartElement) (c *matter.Com
 		c.Response = types.NewCustomDataType("Y", false)
 	}
 
+	var conf conformance.Set
 	for {
 		var tok xml.Token
 		tok, err = d.Token()
@@ -92,11 +93,22 @@ func readCommand(path string, d *xml.Decoder, e xml.StartElement) (c *matter.Com
 					c.Fields = append(c.Fields, f)
 				}
 			default:
-				err = fmt.Errorf("unexpected command level element: %s", t.Name.Local)
+				if !isConformanceElement(t.Name.Local) {
+					err = fmt.Errorf("unexpected command level element: %s", t.Name.Local)
+					break
+				}
+				var con conformance.Conformance
+				con, err = readConformance(d, t)
+				if err == nil {
+					conf = appendConformance(conf, con)
+				}
 			}
 		case xml.EndElement:
 			switch t.Name.Local {
 			case "command":
+				if len(conf) > 0 {
+					c.Conformance = conf
+				}
 				return
 			default:
 				err = fmt.Errorf("unexpected command end element: %s", t.Name.Local)
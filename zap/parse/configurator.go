@@ -106,8 +106,10 @@ func (sp *ZapParser) readConfigurator(path string, d *xml.Decoder) (entities []t
 						sp.lock.Unlock()
 					}
 				}
-				for _, c := range clusters {
-					c.Features = features
+				if features != nil {
+					for _, c := range clusters {
+						c.Features = features
+					}
 				}
 				return
 			default:
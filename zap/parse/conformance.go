@@ -0,0 +1,337 @@
+package parse
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/shopspring/decimal"
+)
+
+func isConformanceElement(name string) bool {
+	switch name {
+	case "mandatoryConform", "optionalConform", "provisionalConform", "disallowConform", "deprecateConform", "describedConform", "otherwiseConform":
+		return true
+	}
+	return false
+}
+
+func readConformance(d *xml.Decoder, e xml.StartElement) (c conformance.Conformance, err error) {
+	switch e.Name.Local {
+	case "mandatoryConform":
+		var exp conformance.Expression
+		exp, err = readConformanceExpression(d, e.Name.Local)
+		c = &conformance.Mandatory{Expression: exp}
+	case "optionalConform":
+		o := &conformance.Optional{}
+		o.Choice, err = readChoice(e)
+		if err != nil {
+			return
+		}
+		o.Expression, err = readConformanceExpression(d, e.Name.Local)
+		c = o
+	case "provisionalConform":
+		c = &conformance.Provisional{}
+		err = Ignore(d, e.Name.Local)
+	case "disallowConform":
+		c = &conformance.Disallowed{}
+		err = Ignore(d, e.Name.Local)
+	case "deprecateConform":
+		c = &conformance.Deprecated{}
+		err = Ignore(d, e.Name.Local)
+	case "describedConform":
+		c = &conformance.Described{}
+		err = Ignore(d, e.Name.Local)
+	case "otherwiseConform":
+		var set conformance.Set
+		for {
+			var tok xml.Token
+			tok, err = d.Token()
+			if tok == nil || err == io.EOF {
+				err = fmt.Errorf("EOF before end of %s", e.Name.Local)
+			}
+			if err != nil {
+				return
+			}
+			switch t := tok.(type) {
+			case xml.StartElement:
+				var oc conformance.Conformance
+				oc, err = readConformance(d, t)
+				if err != nil {
+					return
+				}
+				set = append(set, oc)
+			case xml.EndElement:
+				return set, nil
+			case xml.CharData, xml.Comment:
+			default:
+				return nil, fmt.Errorf("unexpected %s level type: %T", e.Name.Local, t)
+			}
+		}
+	default:
+		err = fmt.Errorf("unexpected conformance element: %s", e.Name.Local)
+	}
+	return
+}
+
+// appendConformance adds a conformance read from XML to a set, flattening an otherwiseConform into its parts
+func appendConformance(set conformance.Set, c conformance.Conformance) conformance.Set {
+	if cs, ok := c.(conformance.Set); ok {
+		return append(set, cs...)
+	}
+	return append(set, c)
+}
+
+func readChoice(e xml.StartElement) (choice *conformance.Choice, err error) {
+	var set string
+	var min, max int = -1, -1
+	var more bool
+	for _, a := range e.Attr {
+		switch a.Name.Local {
+		case "choice":
+			set = a.Value
+		case "more":
+			more = a.Value == "true"
+		case "min":
+			min, err = strconv.Atoi(a.Value)
+		case "max":
+			max, err = strconv.Atoi(a.Value)
+		default:
+			err = fmt.Errorf("unexpected %s attribute: %s", e.Name.Local, a.Name.Local)
+		}
+		if err != nil {
+			return
+		}
+	}
+	if set == "" {
+		return
+	}
+	choice = &conformance.Choice{Set: set}
+	switch {
+	case min >= 0 && max >= 0 && (more || min != max):
+		choice.Limit = &conformance.ChoiceRangeLimit{Min: min, Max: max}
+	case min >= 0 && max >= 0:
+		choice.Limit = &conformance.ChoiceExactLimit{Limit: min}
+	case min >= 0:
+		choice.Limit = &conformance.ChoiceMinLimit{Min: min}
+	case max >= 0:
+		choice.Limit = &conformance.ChoiceMaxLimit{Max: max}
+	}
+	return
+}
+
+// readConformanceExpression reads the single expression, if any, inside a conformance element
+func readConformanceExpression(d *xml.Decoder, name string) (exp conformance.Expression, err error) {
+	var exps []conformance.Expression
+	exps, err = readExpressions(d, name)
+	if err != nil {
+		return
+	}
+	switch len(exps) {
+	case 0:
+	case 1:
+		exp = exps[0]
+	default:
+		err = fmt.Errorf("too many expressions in %s", name)
+	}
+	return
+}
+
+func readExpressions(d *xml.Decoder, name string) (exps []conformance.Expression, err error) {
+	for {
+		var tok xml.Token
+		tok, err = d.Token()
+		if tok == nil || err == io.EOF {
+			err = fmt.Errorf("EOF before end of %s", name)
+		}
+		if err != nil {
+			return
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var exp conformance.Expression
+			exp, err = readExpression(d, t)
+			if err != nil {
+				return
+			}
+			exps = append(exps, exp)
+		case xml.EndElement:
+			return
+		case xml.CharData, xml.Comment:
+		default:
+			err = fmt.Errorf("unexpected %s level type: %T", name, t)
+			return
+		}
+	}
+}
+
+func readExpression(d *xml.Decoder, e xml.StartElement) (exp conformance.Expression, err error) {
+	switch e.Name.Local {
+	case "feature":
+		exp = &conformance.FeatureExpression{Feature: nameAttribute(e)}
+		err = Ignore(d, e.Name.Local)
+	case "condition", "attribute", "command", "field":
+		exp = &conformance.IdentifierExpression{ID: nameAttribute(e)}
+		err = Ignore(d, e.Name.Local)
+	case "notTerm":
+		exp, err = readConformanceExpression(d, e.Name.Local)
+		if err != nil {
+			return
+		}
+		switch exp := exp.(type) {
+		case *conformance.FeatureExpression:
+			exp.Not = true
+		case *conformance.IdentifierExpression:
+			exp.Not = true
+		case *conformance.LogicalExpression:
+			exp.Not = true
+		case *conformance.EqualityExpression:
+			exp.Not = !exp.Not
+		default:
+			err = fmt.Errorf("unexpected expression in notTerm: %T", exp)
+		}
+	case "andTerm", "orTerm", "xorTerm":
+		var exps []conformance.Expression
+		exps, err = readExpressions(d, e.Name.Local)
+		if err != nil {
+			return
+		}
+		if len(exps) < 2 {
+			err = fmt.Errorf("%s needs at least two expressions", e.Name.Local)
+			return
+		}
+		le := &conformance.LogicalExpression{Left: exps[0], Right: exps[1:]}
+		switch e.Name.Local {
+		case "andTerm":
+			le.Operand = "&"
+		case "orTerm":
+			le.Operand = "|"
+		case "xorTerm":
+			le.Operand = "^"
+		}
+		exp = le
+	case "equalTerm", "notEqualTerm":
+		var exps []conformance.Expression
+		exps, err = readExpressions(d, e.Name.Local)
+		if err != nil {
+			return
+		}
+		if len(exps) != 2 {
+			err = fmt.Errorf("%s needs two expressions", e.Name.Local)
+			return
+		}
+		exp = &conformance.EqualityExpression{Not: e.Name.Local == "notEqualTerm", Left: exps[0], Right: exps[1]}
+	case "greaterTerm", "greaterOrEqualTerm", "lessTerm", "lessOrEqualTerm":
+		ce := &conformance.ComparisonExpression{}
+		switch e.Name.Local {
+		case "greaterTerm":
+			ce.Op = conformance.ComparisonOperatorGreaterThan
+		case "greaterOrEqualTerm":
+			ce.Op = conformance.ComparisonOperatorGreaterThanOrEqual
+		case "lessTerm":
+			ce.Op = conformance.ComparisonOperatorLessThan
+		case "lessOrEqualTerm":
+			ce.Op = conformance.ComparisonOperatorLessThanOrEqual
+		}
+		var values []conformance.ComparisonValue
+		values, err = readComparisonValues(d, e.Name.Local)
+		if err != nil {
+			return
+		}
+		if len(values) != 2 {
+			err = fmt.Errorf("%s needs two values", e.Name.Local)
+			return
+		}
+		ce.Left, ce.Right = values[0], values[1]
+		exp = ce
+	default:
+		err = fmt.Errorf("unsupported conformance expression element: %s", e.Name.Local)
+	}
+	return
+}
+
+func readComparisonValues(d *xml.Decoder, name string) (values []conformance.ComparisonValue, err error) {
+	for {
+		var tok xml.Token
+		tok, err = d.Token()
+		if tok == nil || err == io.EOF {
+			err = fmt.Errorf("EOF before end of %s", name)
+		}
+		if err != nil {
+			return
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var value conformance.ComparisonValue
+			switch t.Name.Local {
+			case "feature":
+				value = &conformance.FeatureValue{Feature: nameAttribute(t)}
+			case "condition", "attribute", "command", "field":
+				value = &conformance.IdentifierValue{ID: nameAttribute(t)}
+			case "literal":
+				value, err = readLiteral(t)
+			default:
+				err = fmt.Errorf("unsupported comparison value element: %s", t.Name.Local)
+			}
+			if err != nil {
+				return
+			}
+			err = Ignore(d, t.Name.Local)
+			if err != nil {
+				return
+			}
+			values = append(values, value)
+		case xml.EndElement:
+			return
+		case xml.CharData, xml.Comment:
+		default:
+			err = fmt.Errorf("unexpected %s level type: %T", name, t)
+			return
+		}
+	}
+}
+
+func readLiteral(e xml.StartElement) (value conformance.ComparisonValue, err error) {
+	var raw string
+	for _, a := range e.Attr {
+		if a.Name.Local == "value" {
+			raw = a.Value
+		}
+	}
+	switch {
+	case strings.HasPrefix(raw, "0x") || strings.HasPrefix(raw, "0X"):
+		var h uint64
+		h, err = strconv.ParseUint(raw[2:], 16, 64)
+		if err == nil {
+			value = conformance.NewHexValue(h, raw)
+		}
+	case strings.ContainsAny(raw, ".eE"):
+		var f decimal.Decimal
+		f, err = decimal.NewFromString(raw)
+		if err == nil {
+			value = conformance.NewFloatValue(f, raw)
+		}
+	default:
+		var i int64
+		i, err = strconv.ParseInt(raw, 10, 64)
+		if err == nil {
+			value = conformance.NewIntValue(i, raw)
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("invalid literal value %q: %w", raw, err)
+	}
+	return
+}
+
+func nameAttribute(e xml.StartElement) string {
+	for _, a := range e.Attr {
+		if a.Name.Local == "name" {
+			return a.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package parse
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/dm"
+	"github.com/project-chip/alchemy/matter/conformance"
+)
+
+var conformanceRoundTripTests = []string{
+	"M",
+	"O",
+	"P",
+	"D",
+	"X",
+	"desc",
+	"AB",
+	"!AB",
+	"[AB]",
+	"AB & CD",
+	"AB | CD | EF",
+	"AB ^ CD",
+	"!(AB & CD)",
+	"(AB & !CD) | EF",
+	"Foo",
+	"!Foo",
+	"[Foo]",
+	"Foo == Bar",
+	"!(Foo == Bar)",
+	"[AB & (Foo != Bar)]",
+	"Foo > 3",
+	"Foo >= 0x10",
+	"Foo < 1.5",
+	"Foo <= -2",
+	"AB > 1",
+	"O.a",
+	"O.a+",
+	"O.a2+",
+	"O.b2",
+	"O.c1-2",
+	"O.d2-",
+	"[AB].a",
+	"[AB | CD].b+",
+	"AB, O",
+	"P, M",
+	"AB, [CD], D",
+	"AB, desc",
+}
+
+func TestConformanceRoundTrip(t *testing.T) {
+	for _, s := range conformanceRoundTripTests {
+		expected := conformance.ParseConformance(s)
+		if _, ok := expected[0].(*conformance.Generic); ok {
+			t.Errorf("failed parsing conformance %q", s)
+			continue
+		}
+		doc := etree.NewDocument()
+		root := doc.CreateElement("attribute")
+		err := dm.RenderConformanceElement(nil, nil, expected, root)
+		if err != nil {
+			t.Errorf("failed rendering conformance %q: %v", s, err)
+			continue
+		}
+		x, err := doc.WriteToString()
+		if err != nil {
+			t.Errorf("failed writing conformance %q: %v", s, err)
+			continue
+		}
+		actual, err := readConformanceXML(x)
+		if err != nil {
+			t.Errorf("failed reading conformance %q from %s: %v", s, x, err)
+			continue
+		}
+		if !expected.Equal(actual) {
+			t.Errorf("conformance %q did not round trip: got %q from %s", s, actual.ASCIIDocString(), x)
+		}
+	}
+}
+
+func readConformanceXML(x string) (set conformance.Set, err error) {
+	d := xml.NewDecoder(strings.NewReader(x))
+	for {
+		var tok xml.Token
+		tok, err = d.Token()
+		if err == io.EOF {
+			return set, nil
+		}
+		if err != nil {
+			return
+		}
+		if t, ok := tok.(xml.StartElement); ok && isConformanceElement(t.Name.Local) {
+			var c conformance.Conformance
+			c, err = readConformance(d, t)
+			if err != nil {
+				return
+			}
+			set = appendConformance(set, c)
+		}
+	}
+}
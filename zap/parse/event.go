@@ -44,6 +44,7 @@ func readEvent(path string, d *xml.Decoder, e xml.StartElement) (event *matter.E
 		event.Access.FabricSensitivity = matter.FabricSensitivityInsensitive
 	}
 
+	var conf conformance.Set
 	for {
 		var tok xml.Token
 		tok, err = d.Token()
@@ -70,11 +71,22 @@ func readEvent(path string, d *xml.Decoder, e xml.StartElement) (event *matter.E
 					event.Fields = append(event.Fields, field)
 				}
 			default:
-				err = fmt.Errorf("unexpected event level element: %s", t.Name.Local)
+				if !isConformanceElement(t.Name.Local) {
+					err = fmt.Errorf("unexpected event level element: %s", t.Name.Local)
+					break
+				}
+				var con conformance.Conformance
+				con, err = readConformance(d, t)
+				if err == nil {
+					conf = appendConformance(conf, con)
+				}
 			}
 		case xml.EndElement:
 			switch t.Name.Local {
 			case "event":
+				if len(conf) > 0 {
+					event.Conformance = conf
+				}
 				return
 			default:
 				err = fmt.Errorf("unexpected event end element: %s", t.Name.Local)
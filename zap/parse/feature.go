@@ -0,0 +1,107 @@
+package parse
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func readFeatures(d *xml.Decoder, e xml.StartElement) (features *matter.Features, err error) {
+	features = &matter.Features{Bitmap: matter.Bitmap{Name: "Feature", Type: types.NewDataType(types.BaseDataTypeMap32, false)}}
+	for {
+		var tok xml.Token
+		tok, err = d.Token()
+		if tok == nil || err == io.EOF {
+			err = fmt.Errorf("EOF before end of features")
+		}
+		if err != nil {
+			return
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "feature":
+				var f *matter.Feature
+				f, err = readFeature(d, t)
+				if err == nil {
+					features.Bits = append(features.Bits, f)
+				}
+			default:
+				err = fmt.Errorf("unexpected features level element: %s", t.Name.Local)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "features":
+				return
+			default:
+				err = fmt.Errorf("unexpected features end element: %s", t.Name.Local)
+			}
+		case xml.CharData, xml.Comment:
+		default:
+			err = fmt.Errorf("unexpected features level type: %T", t)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func readFeature(d *xml.Decoder, e xml.StartElement) (f *matter.Feature, err error) {
+	var bit, code, name, summary string
+	for _, a := range e.Attr {
+		switch a.Name.Local {
+		case "bit":
+			bit = a.Value
+		case "code":
+			code = a.Value
+		case "name":
+			name = a.Value
+		case "summary":
+			summary = a.Value
+		case "apiMaturity":
+		default:
+			return nil, fmt.Errorf("unexpected feature attribute: %s", a.Name.Local)
+		}
+	}
+	var conf conformance.Set
+	for {
+		var tok xml.Token
+		tok, err = d.Token()
+		if tok == nil || err == io.EOF {
+			err = fmt.Errorf("EOF before end of feature")
+		}
+		if err != nil {
+			return
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if !isConformanceElement(t.Name.Local) {
+				err = fmt.Errorf("unexpected feature level element: %s", t.Name.Local)
+				break
+			}
+			var c conformance.Conformance
+			c, err = readConformance(d, t)
+			if err == nil {
+				conf = appendConformance(conf, c)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "feature":
+				f = matter.NewFeature(bit, name, code, summary, conf)
+				return
+			default:
+				err = fmt.Errorf("unexpected feature end element: %s", t.Name.Local)
+			}
+		case xml.CharData, xml.Comment:
+		default:
+			err = fmt.Errorf("unexpected feature level type: %T", t)
+		}
+		if err != nil {
+			return
+		}
+	}
+}